@@ -0,0 +1,9 @@
+package blob
+
+type Document struct {
+	ID      int64 `xql:"pk"`
+	Content []byte
+	Preview *[]byte
+}
+
+func (Document) Table() string { return "documents" }
@@ -0,0 +1,14 @@
+package comment
+
+// Account is a customer account.
+//
+// Orders reference it by AccountID.
+type Account struct {
+	ID int64 `xql:"pk"`
+	// Email is where the customer's
+	// receipts are sent.
+	Email    string
+	Nickname string // shown on reviews
+}
+
+func (Account) Table() string { return "accounts" }
@@ -0,0 +1,16 @@
+package document
+
+type Settings struct {
+	Theme string
+}
+
+type Article struct {
+	ID       int64             `xql:"pk"`
+	Settings Settings          `xql:"type:jsonb"`
+	Labels   map[string]string `xql:"type:json"`
+	Tags     []string          `xql:"type:jsonb;index:gin"`
+	Draft    *Settings         `xql:"type:jsonb"`
+	Skipped  Settings
+}
+
+func (Article) Table() string { return "articles" }
@@ -0,0 +1,22 @@
+package foreignkey
+
+type Account struct {
+	ID int64 `xql:"pk"`
+}
+
+func (Account) Table() string { return "accounts" }
+
+type Order struct {
+	ID        int64  `xql:"pk"`
+	AccountID int64  `xql:"index;fk:accounts.id,onDelete:cascade,onUpdate:restrict"`
+	ParentID  *int64 `xql:"fk:orders.id,onDelete:set_null"`
+}
+
+func (Order) Table() string { return "orders" }
+
+type Invalid struct {
+	ID        int64 `xql:"pk"`
+	AccountID int64 `xql:"not null;fk:accounts.id,onDelete:set null"`
+}
+
+func (Invalid) Table() string { return "invalids" }
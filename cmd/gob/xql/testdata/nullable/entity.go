@@ -0,0 +1,12 @@
+package nullable
+
+import "time"
+
+type Profile struct {
+	ID       int64 `xql:"pk"`
+	Bio      *string
+	Age      *int32 `xql:"default:0"`
+	Birthday *time.Time
+}
+
+func (Profile) Table() string { return "profiles" }
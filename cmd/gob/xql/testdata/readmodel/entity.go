@@ -0,0 +1,26 @@
+package readmodel
+
+type AccountOrders struct {
+	AccountID int64
+	Email     string
+	Total     float64
+}
+
+func (AccountOrders) Table() string { return "account_orders" }
+
+func (AccountOrders) View() string {
+	return `
+SELECT a.id AS account_id, a.email, SUM(o.amount) AS total
+FROM accounts a JOIN orders o ON o.account_id = a.id
+GROUP BY a.id, a.email`
+}
+
+type Dynamic struct {
+	ID int64
+}
+
+func (Dynamic) Table() string { return "dynamics" }
+
+func (Dynamic) View() string { return query() }
+
+func query() string { return "SELECT 1 AS id" }
@@ -0,0 +1,25 @@
+package softdelete
+
+import "time"
+
+type Post struct {
+	ID        int64 `xql:"pk"`
+	Title     string
+	DeletedAt *time.Time
+}
+
+func (Post) Table() string { return "posts" }
+
+type Comment struct {
+	ID        int64     `xql:"pk"`
+	RemovedAt time.Time `xql:"softdelete"`
+}
+
+func (Comment) Table() string { return "comments" }
+
+type Invalid struct {
+	ID      int64  `xql:"pk"`
+	Removed string `xql:"softdelete"`
+}
+
+func (Invalid) Table() string { return "invalids" }
@@ -0,0 +1,18 @@
+package timestamps
+
+import "time"
+
+type Note struct {
+	ID        int64     `xql:"pk"`
+	CreatedAt time.Time `xql:"autoCreateTime"`
+	UpdatedAt time.Time `xql:"autoUpdateTime;not null"`
+}
+
+func (Note) Table() string { return "notes" }
+
+type Invalid struct {
+	ID        int64  `xql:"pk"`
+	CreatedAt string `xql:"autoCreateTime"`
+}
+
+func (Invalid) Table() string { return "invalids" }
@@ -20,6 +20,8 @@ const (
 	dbaAdapterKey = "xql.dbAdapter"
 	// entityFilterKey is the context key used to store the entity filter function.
 	entityFilterKey = "xql.entityFilter"
+	// fixturesKey is the context key set when fixtures are generated too.
+	fixturesKey = "xql.fixtures"
 )
 
 //go:embed resources/drivers.json
@@ -59,6 +61,12 @@ var XqlCmd = &cobra.Command{
 		registered := lo.FilterMapToSlice(driverMap, func(key string, values []string) (string, bool) {
 			return key, len(lo.Intersect(driverOpt.MustGet(), values)) > 0
 		})
+		// the type mapping overrides of xql.yaml apply to every subcommand
+		cfg, err := loadConfig(internal.Current.Root)
+		if err != nil {
+			return err
+		}
+		typeMappings = cfg.TypeMapping
 		// 3: in all the structs which implements internal.ToolEntityInterface()
 		// 4: put registered database names into context for subcommands to use
 		parent := cmd.Context()
@@ -85,10 +93,44 @@ var schemaCmd = &cobra.Command{
 		if len(names) > 0 {
 			ctx = context.WithValue(ctx, entityFilterKey, names)
 		}
+		if fixtures, _ := cmd.Flags().GetBool("fixtures"); fixtures {
+			ctx = context.WithValue(ctx, fixturesKey, true)
+		}
 		return generate(ctx)
 	},
 }
 
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [entities...]",
+	Short: "Generate versioned migrations from the changes made to entities since the last migration (e.g. `xql migrate Account`).",
+	Args:  cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		names := lo.Uniq(lo.FilterMap(args, func(a string, _ int) (string, bool) {
+			a = strings.TrimSpace(a)
+			return a, a != ""
+		}))
+		if len(names) > 0 {
+			ctx = context.WithValue(ctx, entityFilterKey, names)
+		}
+		return migrate(ctx)
+	},
+}
+
+var introspectCmd = &cobra.Command{
+	Use:   "introspect <adapter> <dsn> [tables...]",
+	Short: "Generate entities and their field packages from the tables of a live sqlite, mysql or postgres database (e.g. `xql introspect postgres postgres://localhost/shop orders`).",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		tables := lo.Uniq(lo.FilterMap(args[2:], func(a string, _ int) (string, bool) {
+			a = strings.TrimSpace(a)
+			return a, a != ""
+		}))
+		return introspect(cmd.Context(), args[0], args[1], dir, tables)
+	},
+}
+
 var validateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validate entity and schema definitions.",
@@ -106,7 +148,11 @@ var indexCmd = &cobra.Command{
 }
 
 func init() {
+	schemaCmd.Flags().Bool("fixtures", false, "also generate fixture factories building valid entities and their JSON payloads under gen/fixture")
 	XqlCmd.AddCommand(schemaCmd)
+	XqlCmd.AddCommand(migrateCmd)
+	introspectCmd.Flags().String("dir", "entity", "project folder the entity package is written to")
+	XqlCmd.AddCommand(introspectCmd)
 	XqlCmd.AddCommand(validateCmd)
 	XqlCmd.AddCommand(indexCmd)
 }
@@ -0,0 +1,63 @@
+package xql
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"gopkg.in/yaml.v3"
+)
+
+// configFile is the name of the optional project configuration of xql, read
+// from the project root.
+const configFile = "xql.yaml"
+
+// Config is the project configuration read from xql.yaml.
+//
+// Example:
+//
+//	typeMapping:
+//	  postgres:
+//	    string: VARCHAR(255)
+//	    Money: NUMERIC(19,4)
+type Config struct {
+	// TypeMapping maps an adapter to the SQL types of Go types, written as
+	// they are spelled in entities. It overrides and extends the typeMapping
+	// of drivers.json; a `type` directive still takes precedence.
+	TypeMapping map[string]map[string]string `yaml:"typeMapping"`
+}
+
+// typeMappings holds the TypeMapping of the project configuration, consulted
+// by sqlTypeFor before drivers.json.
+var typeMappings map[string]map[string]string
+
+// loadConfig reads the xql.yaml of the project at root, if any, and validates
+// it against drivers.json.
+func loadConfig(root string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(filepath.Join(root, configFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read %s: %w", configFile, err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse %s: %w", configFile, err)
+	}
+	for adapter, mapping := range cfg.TypeMapping {
+		if !gjson.GetBytes(driversJSON, adapter).Exists() {
+			return cfg, fmt.Errorf("%s: unknown adapter %q in typeMapping", configFile, adapter)
+		}
+		for goType, sqlType := range mapping {
+			if strings.TrimSpace(goType) == "" || strings.TrimSpace(sqlType) == "" {
+				return cfg, fmt.Errorf("%s: typeMapping of %s maps %q to %q: both must be set", configFile, adapter, goType, sqlType)
+			}
+			mapping[goType] = strings.TrimSpace(sqlType)
+		}
+	}
+	return cfg, nil
+}
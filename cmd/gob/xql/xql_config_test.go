@@ -0,0 +1,48 @@
+package xql
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := loadConfig(dir)
+	require.NoError(t, err)
+	require.Empty(t, cfg.TypeMapping)
+
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, configFile), []byte(content), 0644))
+	}
+	write(`typeMapping:
+  postgres:
+    string: " VARCHAR(255) "
+    Money: NUMERIC(19,4)
+`)
+	cfg, err = loadConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]string{"postgres": {"string": "VARCHAR(255)", "Money": "NUMERIC(19,4)"}}, cfg.TypeMapping)
+
+	typeMappings = cfg.TypeMapping
+	defer func() { typeMappings = nil }()
+	require.Equal(t, "VARCHAR(255)", sqlTypeFor("string", "postgres", driversJSON))
+	require.Equal(t, "NUMERIC(19,4)", sqlTypeFor("Money", "postgres", driversJSON))
+	require.Equal(t, "BIGINT", sqlTypeFor("int64", "postgres", driversJSON))
+	require.Equal(t, "TEXT", sqlTypeFor("string", "sqlite", driversJSON))
+	fields := enrichFieldsForAdapter([]Field{{GoName: "Code", GoType: "string", Name: "code", DBType: "CHAR(3)"}, {GoName: "Name", GoType: "string", Name: "name"}}, "postgres")
+	require.Equal(t, "CHAR(3)", fields[0].DBType)
+	require.Equal(t, "VARCHAR(255)", fields[1].DBType)
+
+	write("typeMapping:\n  db2:\n    string: VARCHAR(255)\n")
+	_, err = loadConfig(dir)
+	require.ErrorContains(t, err, `unknown adapter "db2"`)
+	write("typeMapping:\n  mysql:\n    string: \"\"\n")
+	_, err = loadConfig(dir)
+	require.ErrorContains(t, err, "both must be set")
+	write("typeMapping: [")
+	_, err = loadConfig(dir)
+	require.ErrorContains(t, err, "failed to parse xql.yaml")
+}
@@ -38,10 +38,53 @@ var fieldsTmpl string
 //go:embed resources/schema.tmpl
 var schemaTmpl string
 
+//go:embed resources/repo.tmpl
+var repoTmpl string
+
+//go:embed resources/viewgen.tmpl
+var viewgenTmpl string
+
+//go:embed resources/fixture.tmpl
+var fixtureTmpl string
+
+// varcharPattern and decimalPattern capture the length of varchar(N) and the
+// precision and scale of decimal(P,S) DB types.
+var (
+	varcharPattern = regexp.MustCompile(`(?i)^varchar\((\d+)\)`)
+	decimalPattern = regexp.MustCompile(`(?i)^(?:decimal|numeric)\s*\(\s*(\d+)\s*,\s*(\d+)\s*\)`)
+)
+
+// schemaFuncs are the template functions available to schema.tmpl.
+var schemaFuncs = template.FuncMap{
+	"plus1":       func(i int) int { return i + 1 },
+	"createTable": createTable,
+	"createIndex": createIndex,
+	"fieldIndex":  fieldIndex,
+	"nativeEnum":  nativeEnum,
+	"enumType":    enumType,
+	"createEnum":  createEnum,
+	"sqlList":     sqlList,
+	"createView":  createView,
+	"foreignKeys": foreignKeys,
+	"comments":    comments,
+	"sqlString":   sqlString,
+}
+
+// fieldFuncs are the template functions available to fields.tmpl.
+var fieldFuncs = template.FuncMap{
+	"ago":    func(t time.Time) string { return t.Format(time.RFC3339) },
+	"pascal": lo.PascalCase,
+	"quote":  strconv.Quote,
+}
+
 // SchemaTemplateData holds the data passed to the schema template.
 type SchemaTemplateData struct {
+	Adapter     string
 	TableName   string
+	ViewQuery   string
+	Comment     string
 	Fields      []Field
+	Indexes     []Index
 	GeneratedAt time.Time
 	Version     string
 }
@@ -50,6 +93,7 @@ type SchemaTemplateData struct {
 type TemplateData struct {
 	PackageName      string
 	StructName       string
+	TableName        string
 	Imports          []string
 	Fields           []Field
 	ModulePath       string
@@ -59,6 +103,68 @@ type TemplateData struct {
 	Version          string
 }
 
+// RepoTemplateData holds the data passed to the repository template.
+type RepoTemplateData struct {
+	PackageName      string
+	StructName       string
+	Imports          []string
+	ModulePath       string
+	EntityImportPath string
+	FieldImportPath  string      // the generated field package of the entity
+	ReadOnly         bool        // read models get no write functions
+	PK               *Field      // the single primary key, nil when there is none or several
+	SoftDelete       *Field      // the soft-delete column, nil when rows are deleted for good
+	Fields           []RepoField // every column, read by List
+	Updates          []RepoField // the columns written by Update
+	GeneratedAt      time.Time
+	Version          string
+}
+
+// RepoField is a Field with what the repository template renders for it.
+type RepoField struct {
+	Field
+	ScanType    string // the type read by sqlx.Scan: a pointer for nullable columns
+	SkipInsert  bool   // database-generated keys are left out of inserts
+	InsertGuard string // the condition binding the field on insert, empty to always bind it
+}
+
+// ViewgenTemplateData holds the data passed to the view schema template.
+type ViewgenTemplateData struct {
+	PackageName     string
+	StructName      string
+	ModulePath      string
+	FieldImportPath string        // the generated field package of the entity
+	Create          ViewgenSchema // the fields of CreateSchema
+	Update          ViewgenSchema // the fields of UpdateSchema
+	GeneratedAt     time.Time
+	Version         string
+}
+
+// ViewgenSchema lists the Go names of the required and optional fields of a
+// generated view schema.
+type ViewgenSchema struct {
+	Required []string
+	Optional []string
+}
+
+// FixtureTemplateData holds the data passed to the fixture template.
+type FixtureTemplateData struct {
+	PackageName      string
+	StructName       string
+	Imports          []string
+	EntityImportPath string
+	Values           []FixtureValue // the fields set by New, in field order
+	UsesText         bool           // some value calls the text helper
+	GeneratedAt      time.Time
+	Version          string
+}
+
+// FixtureValue is the expression of seq New assigns to a field.
+type FixtureValue struct {
+	GoName string
+	Expr   string
+}
+
 // Field represents a single column in a database table, derived from a Go struct field.
 type Field struct {
 	Name          string // The database column name (e.g., "creation_time").
@@ -66,15 +172,196 @@ type Field struct {
 	GoType        string // The Go type of the field (e.g., "time.Time").
 	DBType        string // The specific SQL type for the column (e.g., "TIMESTAMP WITH TIME ZONE").
 	IsPK          bool   // True if this field is the primary key.
+	IsAuto        bool   // True if the database generates the primary key value.
+	PKClause      string // The adapter-specific primary key clause for IsAuto (e.g., "PRIMARY KEY AUTO_INCREMENT").
 	IsNotNull     bool   // True if the column has a NOT NULL constraint.
+	IsNullable    bool   // True for pointer fields (e.g., *string): GoType is the pointed-to type and NULL maps to nil.
+	IsPointer     bool   // True when the Go field is a pointer; soft-delete timestamps are nullable without one.
+	IsSoftDelete  bool   // True for the nullable timestamp marking deleted rows, declared by softdelete or named DeletedAt.
+	IsCreateTime  bool   // True for the timestamp set on insert, declared by autoCreateTime.
+	IsUpdateTime  bool   // True for the timestamp set on insert and update, declared by autoUpdateTime.
 	IsUnique      bool   // True if the column has a UNIQUE constraint.
 	IsIndexed     bool   // True if an index should be created on this column.
+	IndexMethod   string // The index access method from the index directives (e.g., "gin"), empty for the adapter default.
+	IndexOrder    string // The column ordering in its indexes, "ASC" or "DESC", empty for the default.
 	Default       string // The default value for the column, as a string.
 	FKTable       string // The table referenced by a foreign key.
 	FKColumn      string // The column referenced by a foreign key.
+	FKOnDelete    string // The referential action on delete of the referenced row (e.g., "CASCADE"), empty for the database default.
+	FKOnUpdate    string // The referential action on update of the referenced key, empty for the database default.
+	Comment       string // The column comment, the first paragraph of the field's doc comment.
 	Warning       string // A warning message associated with this field, e.g., for discouraged PK types.
 	IsEmbedded    bool
-	ValidatorArgs string // pre-rendered validator arguments (prefixed with ", ") to inject into templates
+	Document      string   // The JSON shape, "object" or "array", of a struct, map or slice field stored in a json column.
+	EnumValues    []string // The closed set of values declared by the enum directive.
+	IndexGroups   []string // Names of the multi-column indexes the column belongs to, in tag order.
+	UniqueGroups  []string // Names of the multi-column unique indexes the column belongs to, in tag order.
+	ValidatorArgs string   // pre-rendered validator arguments (prefixed with ", ") to inject into templates
+}
+
+// Index is a named index over one or more columns, declared by giving several
+// fields the same `index:<name>` or `uniqueIndex:<name>` directive. Columns
+// hold the column name followed by its ordering when one was declared
+// (e.g., "created_at DESC").
+type Index struct {
+	Name    string   `json:"name"`
+	Unique  bool     `json:"unique,omitempty"`
+	Method  string   `json:"method,omitempty"`
+	Columns []string `json:"columns"`
+	Where   string   `json:"where,omitempty"` // The predicate of a partial index, rendered by adapters with `partialIndex` in drivers.json.
+}
+
+// indexMethods are the index access methods accepted by the index directives.
+// Adapters render the ones listed under `indexMethods` in drivers.json and
+// fall back to their default method for the others.
+var indexMethods = []string{"btree", "hash", "gin", "gist", "brin"}
+
+// parseIndexOptions reads the value of an index directive, such as
+// "acct_created,brin,desc": it records the access method and ordering on
+// field and returns the group name, empty when there is none.
+func parseIndexOptions(value string, field *Field) string {
+	var name string
+	for _, opt := range strings.Split(value, ",") {
+		opt = strings.TrimSpace(opt)
+		switch lower := strings.ToLower(opt); {
+		case lower == "asc" || lower == "desc":
+			field.IndexOrder = strings.ToUpper(lower)
+		case lo.Contains(indexMethods, lower):
+			field.IndexMethod = lower
+		default:
+			name = opt
+		}
+	}
+	return name
+}
+
+// fieldIndex returns the single-column index declared by a bare `index` directive.
+func fieldIndex(table string, f Field) Index {
+	idx := Index{Name: fmt.Sprintf("idx_%s_%s", table, f.Name), Method: f.IndexMethod, Columns: []string{strings.TrimSpace(f.Name + " " + f.IndexOrder)}}
+	if f.IsSoftDelete {
+		// only live rows are looked up by the soft-delete column
+		idx.Where = f.Name + " IS NULL"
+	}
+	return idx
+}
+
+// createTable renders the start of the CREATE TABLE statement of table.
+// SQL Server has no IF NOT EXISTS and checks the catalog instead.
+func createTable(adapter, table string) string {
+	if adapter == "mssql" {
+		return fmt.Sprintf("IF OBJECT_ID(N'%s', N'U') IS NULL\nCREATE TABLE %s", table, table)
+	}
+	return "CREATE TABLE IF NOT EXISTS " + table
+}
+
+// createIndex renders the CREATE INDEX statement of idx for the adapter.
+// PostgreSQL takes the access method before the column list and MySQL after
+// it; methods the adapter does not list in drivers.json are left out, as are
+// partial index predicates on adapters without `partialIndex`. SQL Server
+// checks the catalog for the index as it has no IF NOT EXISTS.
+func createIndex(adapter, table string, idx Index) string {
+	var before, after string
+	if idx.Method != "" && lo.ContainsBy(gjson.GetBytes(driversJSON, adapter+".indexMethods").Array(), func(m gjson.Result) bool {
+		return m.String() == idx.Method
+	}) {
+		if adapter == "mysql" {
+			after = " USING " + strings.ToUpper(idx.Method)
+		} else {
+			before = " USING " + idx.Method
+		}
+	}
+	if idx.Where != "" && gjson.GetBytes(driversJSON, adapter+".partialIndex").Bool() {
+		after += " WHERE " + idx.Where
+	}
+	unique := lo.Ternary(idx.Unique, "UNIQUE ", "")
+	if adapter == "mssql" {
+		return fmt.Sprintf("IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'%s' AND object_id = OBJECT_ID(N'%s'))\nCREATE %sINDEX %s ON %s (%s)%s;", idx.Name, table, unique, idx.Name, table, strings.Join(idx.Columns, ", "), after)
+	}
+	return fmt.Sprintf("CREATE %sINDEX IF NOT EXISTS %s ON %s%s (%s)%s;", unique, idx.Name, table, before, strings.Join(idx.Columns, ", "), after)
+}
+
+// createView renders the statement creating the view of a read model with
+// the `createView` prefix of the adapter in drivers.json: views are replaced
+// where the database supports it, and created once elsewhere.
+func createView(adapter, name, query string) string {
+	prefix := gjson.GetBytes(driversJSON, adapter+".createView").String()
+	if prefix == "" {
+		prefix = "CREATE VIEW"
+	}
+	return fmt.Sprintf("%s %s AS\n%s;", prefix, name, strings.TrimSuffix(strings.TrimSpace(query), ";"))
+}
+
+// indexNameRe is the shape of index group names.
+var indexNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// buildIndexes collects the index groups declared on fields, keeping the
+// columns in field order. A group must have a valid name, be declared either
+// unique or non-unique on all of its fields and use a single access method.
+func buildIndexes(structName string, fields []Field) ([]Index, error) {
+	var indexes []Index
+	byName := map[string]int{}
+	add := func(name string, unique bool, f Field) error {
+		if !indexNameRe.MatchString(name) {
+			return fmt.Errorf("invalid index name %q on %s.%s", name, structName, f.Name)
+		}
+		column := strings.TrimSpace(f.Name + " " + f.IndexOrder)
+		i, ok := byName[name]
+		if !ok {
+			byName[name] = len(indexes)
+			indexes = append(indexes, Index{Name: name, Unique: unique, Method: f.IndexMethod, Columns: []string{column}})
+			return nil
+		}
+		if indexes[i].Unique != unique {
+			return fmt.Errorf("index %s of %s is declared both as index and uniqueIndex", name, structName)
+		}
+		if f.IndexMethod != "" {
+			if indexes[i].Method != "" && indexes[i].Method != f.IndexMethod {
+				return fmt.Errorf("index %s of %s is declared with methods %s and %s", name, structName, indexes[i].Method, f.IndexMethod)
+			}
+			indexes[i].Method = f.IndexMethod
+		}
+		if lo.ContainsBy(indexes[i].Columns, func(c string) bool { return strings.Fields(c)[0] == f.Name }) {
+			return fmt.Errorf("index %s of %s lists column %s twice", name, structName, f.Name)
+		}
+		indexes[i].Columns = append(indexes[i].Columns, column)
+		return nil
+	}
+	for _, f := range fields {
+		for _, name := range f.IndexGroups {
+			if err := add(name, false, f); err != nil {
+				return nil, err
+			}
+		}
+		for _, name := range f.UniqueGroups {
+			if err := add(name, true, f); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return indexes, nil
+}
+
+// documentShape returns the JSON type of the documents a field of type typ
+// holds when stored in a json column: "object" for structs other than
+// time.Time and maps, "array" for slices other than []byte, and an empty
+// string for the other types.
+func documentShape(typ types.Type) string {
+	if isSupportedType(typ) {
+		return ""
+	}
+	switch typ.Underlying().(type) {
+	case *types.Struct, *types.Map:
+		return "object"
+	case *types.Slice, *types.Array:
+		return "array"
+	}
+	return ""
+}
+
+// isJSONType reports whether the column type declared by the type directive
+// is a JSON document type, resolved per adapter by enrichFieldsForAdapter.
+func isJSONType(dbType string) bool {
+	return strings.EqualFold(dbType, "json") || strings.EqualFold(dbType, "jsonb")
 }
 
 // isSupportedType checks if a field type is valid.
@@ -86,6 +373,12 @@ func isSupportedType(typ types.Type) bool {
 		}
 	}
 
+	// []byte maps to a binary column
+	if slice, ok := typ.(*types.Slice); ok {
+		elem, ok := slice.Elem().(*types.Basic)
+		return ok && elem.Kind() == types.Uint8
+	}
+
 	// Check for basic types
 	basic, ok := typ.Underlying().(*types.Basic)
 	if !ok {
@@ -145,7 +438,10 @@ type EntityMeta struct {
 	Pkg        *packages.Package
 	TypeSpec   *ast.TypeSpec
 	TableName  string
+	ViewQuery  string  // the query of a read model (entity.ReadModel), empty for a table
+	Comment    string  // the table comment, the first paragraph of the struct's doc comment
 	Fields     []Field // adapter-agnostic field info (no DBType)
+	Indexes    []Index // multi-column indexes declared through index groups
 }
 
 // OutputWriter abstracts file writing so generation can be directed to disk or memory (tests).
@@ -210,19 +506,27 @@ func generateWithWriter(ctx context.Context, w OutputWriter) (map[string][]byte,
 	}
 
 	// prepare templates
-	fieldTmpl, err := template.New("fields").Funcs(template.FuncMap{
-		"ago": func(t time.Time) string { return t.Format(time.RFC3339) },
-	}).Parse(fieldsTmpl)
+	fieldTmpl, err := template.New("fields").Funcs(fieldFuncs).Parse(fieldsTmpl)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse fields template: %w", err)
 	}
-	funcMap := template.FuncMap{
-		"plus1": func(i int) int { return i + 1 },
-	}
-	schemaTmplParsed, err := template.New("schema").Funcs(funcMap).Parse(schemaTmpl)
+	schemaTmplParsed, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse schema template: %w", err)
 	}
+	repoTmplParsed, err := template.New("repo").Parse(repoTmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse repo template: %w", err)
+	}
+	viewgenTmplParsed, err := template.New("viewgen").Parse(viewgenTmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse viewgen template: %w", err)
+	}
+	fixtureTmplParsed, err := template.New("fixture").Parse(fixtureTmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture template: %w", err)
+	}
+	fixtures, _ := ctx.Value(fixturesKey).(bool)
 
 	// precompile regexes
 	varcharRe := regexp.MustCompile(`(?i)^varchar\((\d+)\)`)                                  // capture length
@@ -288,6 +592,9 @@ func generateWithWriter(ctx context.Context, w OutputWriter) (map[string][]byte,
 					}
 				}
 			}
+			if len(f.EnumValues) > 0 {
+				args = append(args, fmt.Sprintf("%s.OneOf(%s)", modulePkgName, strings.Join(lo.Map(f.EnumValues, func(v string, _ int) string { return strconv.Quote(v) }), ", ")))
+			}
 			if len(args) > 0 {
 				f.ValidatorArgs = ", " + strings.Join(args, ", ")
 			} else {
@@ -298,6 +605,7 @@ func generateWithWriter(ctx context.Context, w OutputWriter) (map[string][]byte,
 		data := TemplateData{
 			PackageName:      strings.ToLower(meta.StructName),
 			StructName:       meta.StructName,
+			TableName:        meta.TableName,
 			Imports:          imports,
 			Fields:           fieldsCopy,
 			ModulePath:       internal.ToolModulePath(),
@@ -325,6 +633,58 @@ func generateWithWriter(ctx context.Context, w OutputWriter) (map[string][]byte,
 			return nil, fmt.Errorf("failed to write generated file for %s: %w", meta.StructName, err)
 		}
 
+		// render the repository of the entity
+		buf.Reset()
+		if err := repoTmplParsed.Execute(&buf, repoData(project, meta, data)); err != nil {
+			return nil, fmt.Errorf("failed to execute repo template for %s: %w", meta.StructName, err)
+		}
+		if formatted, err = format.Source(buf.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to format generated repo for %s: %w", meta.StructName, err)
+		}
+		repoDir := filepath.Join(project.GenPath(), "repo", data.PackageName)
+		if err := w.MkdirAll(repoDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory %s: %w", repoDir, err)
+		}
+		if err := w.WriteFile(filepath.Join(repoDir, fmt.Sprintf("%s_gen.go", data.PackageName)), formatted, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write generated repo for %s: %w", meta.StructName, err)
+		}
+
+		// render the view schemas of writable entities
+		if meta.ViewQuery == "" {
+			buf.Reset()
+			if err := viewgenTmplParsed.Execute(&buf, viewgenData(project, meta, data)); err != nil {
+				return nil, fmt.Errorf("failed to execute viewgen template for %s: %w", meta.StructName, err)
+			}
+			if formatted, err = format.Source(buf.Bytes()); err != nil {
+				return nil, fmt.Errorf("failed to format generated view schemas for %s: %w", meta.StructName, err)
+			}
+			viewgenDir := filepath.Join(project.GenPath(), "viewgen", data.PackageName)
+			if err := w.MkdirAll(viewgenDir, 0755); err != nil {
+				return nil, fmt.Errorf("failed to create output directory %s: %w", viewgenDir, err)
+			}
+			if err := w.WriteFile(filepath.Join(viewgenDir, fmt.Sprintf("%s_gen.go", data.PackageName)), formatted, 0644); err != nil {
+				return nil, fmt.Errorf("failed to write generated view schemas for %s: %w", meta.StructName, err)
+			}
+		}
+
+		// render the fixtures of writable entities when asked for
+		if fixtures && meta.ViewQuery == "" {
+			buf.Reset()
+			if err := fixtureTmplParsed.Execute(&buf, fixtureData(meta, data)); err != nil {
+				return nil, fmt.Errorf("failed to execute fixture template for %s: %w", meta.StructName, err)
+			}
+			if formatted, err = format.Source(buf.Bytes()); err != nil {
+				return nil, fmt.Errorf("failed to format generated fixtures for %s: %w", meta.StructName, err)
+			}
+			fixtureDir := filepath.Join(project.GenPath(), "fixture", data.PackageName)
+			if err := w.MkdirAll(fixtureDir, 0755); err != nil {
+				return nil, fmt.Errorf("failed to create output directory %s: %w", fixtureDir, err)
+			}
+			if err := w.WriteFile(filepath.Join(fixtureDir, fmt.Sprintf("%s_gen.go", data.PackageName)), formatted, 0644); err != nil {
+				return nil, fmt.Errorf("failed to write generated fixtures for %s: %w", meta.StructName, err)
+			}
+		}
+
 		// render schemas for adapters
 		for _, adapter := range adapters {
 			fields := enrichFieldsForAdapter(meta.Fields, adapter)
@@ -332,8 +692,12 @@ func generateWithWriter(ctx context.Context, w OutputWriter) (map[string][]byte,
 				continue
 			}
 			data := SchemaTemplateData{
+				Adapter:     adapter,
 				TableName:   meta.TableName,
+				ViewQuery:   meta.ViewQuery,
+				Comment:     meta.Comment,
 				Fields:      fields,
+				Indexes:     meta.Indexes,
 				GeneratedAt: time.Now(),
 				Version:     computeEntityVersion(meta),
 			}
@@ -345,7 +709,7 @@ func generateWithWriter(ctx context.Context, w OutputWriter) (map[string][]byte,
 			if err := w.MkdirAll(outputDir, 0755); err != nil {
 				return nil, fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
 			}
-			outputPath := filepath.Join(outputDir, fmt.Sprintf("%s_schema.sql", lo.SnakeCase(meta.StructName)))
+			outputPath := filepath.Join(outputDir, schemaFileName(meta))
 			if err := w.WriteFile(outputPath, sb.Bytes(), 0644); err != nil {
 				return nil, fmt.Errorf("failed to write generated schema for %s: %w", meta.StructName, err)
 			}
@@ -359,6 +723,182 @@ func generateWithWriter(ctx context.Context, w OutputWriter) (map[string][]byte,
 	return nil, nil
 }
 
+// repoData builds the repository template data of meta from the data of its
+// field package.
+func repoData(project *internal.Project, meta EntityMeta, fields TemplateData) RepoTemplateData {
+	data := RepoTemplateData{
+		PackageName:      fields.PackageName,
+		StructName:       meta.StructName,
+		Imports:          fields.Imports,
+		ModulePath:       fields.ModulePath,
+		EntityImportPath: meta.PkgPath,
+		FieldImportPath:  genImportPath(project, "field", fields.PackageName),
+		ReadOnly:         meta.ViewQuery != "",
+		GeneratedAt:      fields.GeneratedAt,
+		Version:          fields.Version,
+	}
+	if pks := lo.Filter(meta.Fields, func(f Field, _ int) bool { return f.IsPK }); len(pks) == 1 {
+		data.PK = &pks[0]
+	}
+	if sds := lo.Filter(meta.Fields, func(f Field, _ int) bool { return f.IsSoftDelete }); len(sds) == 1 {
+		data.SoftDelete = &sds[0]
+	}
+	for _, f := range meta.Fields {
+		rf := RepoField{Field: f, ScanType: lo.Ternary(f.IsPointer, "*"+f.GoType, f.GoType), SkipInsert: f.IsPK && f.IsAuto}
+		switch {
+		case f.IsPK && f.Default != "":
+			// a zero key is left to the database default
+			rf.InsertGuard = fmt.Sprintf("e.%s != %s", f.GoName, lo.Ternary(f.GoType == "string", `""`, "0"))
+		case !f.IsPointer && (f.IsCreateTime || f.IsUpdateTime || f.IsSoftDelete):
+			// a zero timestamp is left to the sqlx builders, or NULL
+			rf.InsertGuard = fmt.Sprintf("!e.%s.IsZero()", f.GoName)
+		}
+		data.Fields = append(data.Fields, rf)
+		if !f.IsPK && !f.IsCreateTime && !f.IsUpdateTime && !f.IsSoftDelete {
+			data.Updates = append(data.Updates, rf)
+		}
+	}
+	return data
+}
+
+// viewgenData builds the view schema template data of meta from the data of
+// its field package. Timestamps kept by sqlx and the soft-delete column are
+// left out. CreateSchema requires the NOT NULL columns without a default but
+// the keys; UpdateSchema requires the keys only.
+func viewgenData(project *internal.Project, meta EntityMeta, fields TemplateData) ViewgenTemplateData {
+	data := ViewgenTemplateData{
+		PackageName:     fields.PackageName,
+		StructName:      meta.StructName,
+		ModulePath:      fields.ModulePath,
+		FieldImportPath: genImportPath(project, "field", fields.PackageName),
+		GeneratedAt:     fields.GeneratedAt,
+		Version:         fields.Version,
+	}
+	for _, f := range meta.Fields {
+		if f.IsCreateTime || f.IsUpdateTime || f.IsSoftDelete {
+			continue
+		}
+		if f.IsNotNull && f.Default == "" && !f.IsPK {
+			data.Create.Required = append(data.Create.Required, f.GoName)
+		} else {
+			data.Create.Optional = append(data.Create.Optional, f.GoName)
+		}
+		if f.IsPK {
+			data.Update.Required = append(data.Update.Required, f.GoName)
+		} else {
+			data.Update.Optional = append(data.Update.Optional, f.GoName)
+		}
+	}
+	return data
+}
+
+// fixtureData builds the fixture template data of meta from the data of its
+// field package. Fields of types New cannot fill are left out.
+func fixtureData(meta EntityMeta, fields TemplateData) FixtureTemplateData {
+	data := FixtureTemplateData{
+		PackageName:      fields.PackageName,
+		StructName:       meta.StructName,
+		EntityImportPath: meta.PkgPath,
+		GeneratedAt:      fields.GeneratedAt,
+		Version:          fields.Version,
+	}
+	imports := []string{"encoding/json"}
+	for _, f := range meta.Fields {
+		expr := fixtureExpr(f)
+		if expr == "" {
+			continue
+		}
+		data.Values = append(data.Values, FixtureValue{GoName: f.GoName, Expr: expr})
+		if strings.Contains(expr, "text(") {
+			data.UsesText = true
+		}
+		if strings.Contains(expr, "fmt.") {
+			imports = append(imports, "fmt")
+		}
+		if strings.Contains(expr, "time.") {
+			imports = append(imports, "time")
+		}
+	}
+	if data.UsesText {
+		imports = append(imports, "fmt")
+	}
+	data.Imports = lo.Uniq(imports)
+	sort.Strings(data.Imports)
+	return data
+}
+
+// fixtureExpr returns the expression of seq giving a valid value of f: enum
+// values rotate, varchar values fit the length and decimal values the
+// precision and scale. It returns "" for fields left zero: pointers,
+// documents, soft-delete timestamps and types other than the scalars.
+func fixtureExpr(f Field) string {
+	if f.IsPointer || f.Document != "" || f.IsSoftDelete {
+		return ""
+	}
+	// the largest integer part a decimal(P,S) column holds, plus one
+	bound, scale := 0, 0
+	if m := decimalPattern.FindStringSubmatch(strings.TrimSpace(f.DBType)); len(m) == 3 {
+		p, _ := strconv.Atoi(m[1])
+		scale, _ = strconv.Atoi(m[2])
+		bound = 1
+		for range max(p-scale, 1) {
+			bound *= 10
+		}
+	}
+	switch f.GoType {
+	case "string":
+		switch {
+		case len(f.EnumValues) > 0:
+			return fmt.Sprintf("[]string{%s}[seq%%%d]", strings.Join(lo.Map(f.EnumValues, func(v string, _ int) string { return strconv.Quote(v) }), ", "), len(f.EnumValues))
+		case bound > 0:
+			return fmt.Sprintf("fmt.Sprint(seq %% %d)", bound)
+		}
+		length := 0
+		if m := varcharPattern.FindStringSubmatch(strings.TrimSpace(f.DBType)); len(m) == 2 {
+			length, _ = strconv.Atoi(m[1])
+		}
+		return fmt.Sprintf("text(%q, seq, %d)", f.Name, length)
+	case "[]byte":
+		return fmt.Sprintf("[]byte(text(%q, seq, 0))", f.Name)
+	case "bool":
+		return "seq%2 == 1"
+	case "time.Time":
+		return "time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(seq) * time.Hour)"
+	case "int8", "int16", "uint8", "uint16":
+		return fmt.Sprintf("%s(seq %% %d)", f.GoType, lo.Ternary(bound > 0 && bound < 100, bound, 100))
+	case "int", "int32", "int64", "uint", "uint32", "uint64":
+		if bound > 0 {
+			return fmt.Sprintf("%s(seq %% %d)", f.GoType, bound)
+		}
+		return fmt.Sprintf("%s(seq)", f.GoType)
+	case "float32", "float64":
+		if bound > 0 {
+			return fmt.Sprintf("%s(seq%%%d)%s", f.GoType, bound, lo.Ternary(scale > 0, " + 0.5", ""))
+		}
+		return fmt.Sprintf("%s(seq) + 0.5", f.GoType)
+	}
+	return ""
+}
+
+// genImportPath returns the import path of the generated package at
+// {gen}/elem..., the gen folder being a directory of the project module.
+func genImportPath(project *internal.Project, elem ...string) string {
+	rel, err := filepath.Rel(project.Root, project.GenPath())
+	if err != nil {
+		rel = "gen"
+	}
+	return path.Join(append([]string{project.Mod.Module.Mod.Path, filepath.ToSlash(rel)}, elem...)...)
+}
+
+// schemaFileName returns the name of the schema script of meta: read models
+// get a `_view.sql` script, to be applied after the tables they select from.
+func schemaFileName(meta EntityMeta) string {
+	if meta.ViewQuery != "" {
+		return fmt.Sprintf("%s_view.sql", lo.SnakeCase(meta.StructName))
+	}
+	return fmt.Sprintf("%s_schema.sql", lo.SnakeCase(meta.StructName))
+}
+
 // generateToMemory runs the generation and returns generated files in-memory.
 func generateToMemory(ctx context.Context) (map[string][]byte, error) {
 	mw := NewMemoryWriter()
@@ -426,12 +966,37 @@ func generateMeta(ctx context.Context) ([]EntityMeta, error) {
 		if len(fields) == 0 {
 			return nil, fmt.Errorf("no supported fields found for entity %s", structName)
 		}
+		roles := []struct {
+			name string
+			is   func(f Field, _ int) bool
+		}{
+			{"soft-delete", func(f Field, _ int) bool { return f.IsSoftDelete }},
+			{"create time", func(f Field, _ int) bool { return f.IsCreateTime }},
+			{"update time", func(f Field, _ int) bool { return f.IsUpdateTime }},
+		}
+		for _, role := range roles {
+			if fs := lo.Filter(fields, role.is); len(fs) > 1 {
+				return nil, fmt.Errorf("entity %s has more than one %s field: %s and %s", structName, role.name, fs[0].GoName, fs[1].GoName)
+			}
+		}
+		if lo.ContainsBy(fields, func(f Field) bool { return f.GoName == "Table" }) {
+			return nil, fmt.Errorf("field Table of entity %s collides with the generated Table constant; rename it", structName)
+		}
+		// index columns follow the declaration order, not the column order policy
+		indexes, err := buildIndexes(structName, fields)
+		if err != nil {
+			return nil, err
+		}
 		fields = applyOrderPolicy(fields)
 
 		tableName, err := resolveTableName(project, entityInfo.PkgPath, structName)
 		if err != nil {
 			return nil, err
 		}
+		viewQuery, err := resolveViewQuery(project, entityInfo.PkgPath, structName)
+		if err != nil {
+			return nil, err
+		}
 
 		metas = append(metas, EntityMeta{
 			StructName: structName,
@@ -439,29 +1004,53 @@ func generateMeta(ctx context.Context) ([]EntityMeta, error) {
 			Pkg:        entityInfo.Pkg,
 			TypeSpec:   entityInfo.TypeSpec,
 			TableName:  tableName,
+			ViewQuery:  viewQuery,
+			Comment:    docComment(typeDoc(entityInfo.Pkg, entityInfo.TypeSpec)),
 			Fields:     fields,
+			Indexes:    indexes,
 		})
 	}
 
 	if len(metas) == 0 {
 		return nil, fmt.Errorf("no entity structs found")
 	}
+	if err := checkReferences(metas, ctx.Value(entityFilterKey) != nil); err != nil {
+		return nil, err
+	}
 	return metas, nil
 }
 
 func resolveTableName(project *internal.Project, pkgPath, structName string) (string, error) {
+	if tableName, _, ok := resolveMethodString(project, pkgPath, structName, "Table"); ok {
+		return tableName, nil
+	}
 	// default fallback
-	tableName := lo.SnakeCase(structName)
+	return lo.SnakeCase(structName), nil
+}
+
+// resolveViewQuery returns the query of a read model, the string constant
+// returned by its View method, or an empty string for a table entity.
+func resolveViewQuery(project *internal.Project, pkgPath, structName string) (string, error) {
+	query, declared, ok := resolveMethodString(project, pkgPath, structName, "View")
+	if declared && !ok {
+		return "", fmt.Errorf("View() of %s must return a string constant", structName)
+	}
+	return strings.TrimSpace(query), nil
+}
 
-	// Find Table() method receiver matching structName in that package.
+// resolveMethodString evaluates the first value returned by the method of
+// structName to a string constant. declared reports whether the method
+// exists and ok whether its result could be evaluated.
+func resolveMethodString(project *internal.Project, pkgPath, structName, method string) (value string, declared bool, ok bool) {
+	// Find the method receiver matching structName in that package.
 	for _, pkg := range project.Pkgs {
 		if pkg.PkgPath != pkgPath {
 			continue
 		}
 		for _, file := range pkg.Syntax {
 			ast.Inspect(file, func(n ast.Node) bool {
-				fn, ok := n.(*ast.FuncDecl)
-				if !ok || fn.Name == nil || fn.Name.Name != "Table" {
+				fn, isFn := n.(*ast.FuncDecl)
+				if !isFn || fn.Name == nil || fn.Name.Name != method {
 					return true
 				}
 				if fn.Recv == nil || len(fn.Recv.List) == 0 {
@@ -483,6 +1072,7 @@ func resolveTableName(project *internal.Project, pkgPath, structName string) (st
 				if !recvMatches(fn.Recv.List[0].Type) {
 					return true
 				}
+				declared = true
 
 				// Try to find a return statement and evaluate its result to a stable string.
 				if fn.Body == nil || len(fn.Body.List) == 0 {
@@ -492,7 +1082,7 @@ func resolveTableName(project *internal.Project, pkgPath, structName string) (st
 				// look for the first ReturnStmt with at least one result
 				var retExpr ast.Expr
 				for _, stmt := range fn.Body.List {
-					if r, ok := stmt.(*ast.ReturnStmt); ok && len(r.Results) > 0 {
+					if r, isRet := stmt.(*ast.ReturnStmt); isRet && len(r.Results) > 0 {
 						retExpr = r.Results[0]
 						break
 					}
@@ -501,16 +1091,15 @@ func resolveTableName(project *internal.Project, pkgPath, structName string) (st
 					return true
 				}
 
-				if s, ok := evalStringExpr(retExpr, pkg, file); ok {
-					tableName = s
+				if s, evaluated := evalStringExpr(retExpr, pkg, file); evaluated {
+					value, ok = s, true
 					return false
 				}
 				return true
 			})
 		}
 	}
-
-	return tableName, nil
+	return value, declared, ok
 }
 
 // evalStringExpr attempts to evaluate an AST expression to a string constant.
@@ -524,6 +1113,10 @@ func evalStringExpr(expr ast.Expr, pkg *packages.Package, file *ast.File) (strin
 	switch e := expr.(type) {
 	case *ast.BasicLit:
 		if e.Kind == token.STRING {
+			// raw strings keep multi-line queries readable
+			if v, err := strconv.Unquote(e.Value); err == nil {
+				return v, true
+			}
 			return strings.Trim(e.Value, `"`), true
 		}
 		return "", false
@@ -605,7 +1198,7 @@ func generateFieldsFromMeta(metas []EntityMeta) error {
 		return fmt.Errorf("project context not initialized")
 	}
 
-	tmpl, err := template.New("fields").Parse(fieldsTmpl)
+	tmpl, err := template.New("fields").Funcs(fieldFuncs).Parse(fieldsTmpl)
 	if err != nil {
 		return fmt.Errorf("failed to parse fields template: %w", err)
 	}
@@ -690,6 +1283,9 @@ func generateFieldsFromMeta(metas []EntityMeta) error {
 					}
 				}
 			}
+			if len(f.EnumValues) > 0 {
+				args = append(args, fmt.Sprintf("%s.OneOf(%s)", modulePkgName, strings.Join(lo.Map(f.EnumValues, func(v string, _ int) string { return strconv.Quote(v) }), ", ")))
+			}
 			if len(args) > 0 {
 				// prefix with comma and space to append into template call
 				f.ValidatorArgs = ", " + strings.Join(args, ", ")
@@ -701,6 +1297,7 @@ func generateFieldsFromMeta(metas []EntityMeta) error {
 		data := TemplateData{
 			PackageName:      strings.ToLower(meta.StructName),
 			StructName:       meta.StructName,
+			TableName:        meta.TableName,
 			Imports:          imports,
 			Fields:           fieldsCopy,
 			ModulePath:       internal.ToolModulePath(),
@@ -746,11 +1343,7 @@ func generateSchemaFromMeta(ctx context.Context, metas []EntityMeta) error {
 		return fmt.Errorf("no database adapters are configured or detected")
 	}
 
-	funcMap := template.FuncMap{
-		"plus1": func(i int) int { return i + 1 },
-	}
-
-	tmpl, err := template.New("schema").Funcs(funcMap).Parse(schemaTmpl)
+	tmpl, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
 	if err != nil {
 		return fmt.Errorf("failed to parse schema template: %w", err)
 	}
@@ -763,8 +1356,12 @@ func generateSchemaFromMeta(ctx context.Context, metas []EntityMeta) error {
 			}
 
 			data := SchemaTemplateData{
+				Adapter:     adapter,
 				TableName:   meta.TableName,
+				ViewQuery:   meta.ViewQuery,
+				Comment:     meta.Comment,
 				Fields:      fields,
+				Indexes:     meta.Indexes,
 				GeneratedAt: time.Now(),
 				Version:     computeEntityVersion(meta),
 			}
@@ -773,7 +1370,7 @@ func generateSchemaFromMeta(ctx context.Context, metas []EntityMeta) error {
 			if err := os.MkdirAll(outputDir, 0755); err != nil {
 				return fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
 			}
-			outputPath := filepath.Join(outputDir, fmt.Sprintf("%s_schema.sql", lo.SnakeCase(meta.StructName)))
+			outputPath := filepath.Join(outputDir, schemaFileName(meta))
 
 			var buf bytes.Buffer
 			if err := tmpl.Execute(&buf, data); err != nil {
@@ -797,11 +1394,23 @@ func enrichFieldsForAdapter(base []Field, adapter string) []Field {
 	for i := range fields {
 		if fields[i].DBType == "" {
 			fields[i].DBType = sqlTypeFor(fields[i].GoType, adapter, driversJSON)
+		} else if isJSONType(fields[i].DBType) {
+			fields[i].DBType = jsonTypeFor(fields[i].DBType, adapter, driversJSON)
 		}
 		if fields[i].IsPK {
-			_, warning := pkConstraintFor(fields[i].GoType, fields[i].DBType, adapter, driversJSON)
+			clause, warning := pkConstraintFor(fields[i].GoType, fields[i].DBType, adapter, driversJSON)
 			fields[i].Warning = warning
+			if fields[i].IsAuto {
+				if clause != "" {
+					fields[i].PKClause = clause
+				} else {
+					fields[i].Warning = fmt.Sprintf("auto on %s: only integer primary keys can be generated by %s", fields[i].GoName, adapter)
+				}
+			}
+		} else if fields[i].IsAuto {
+			fields[i].Warning = fmt.Sprintf("auto on %s is ignored: it requires pk", fields[i].GoName)
 		}
+		fields[i].Default = defaultFor(fields[i].Default, adapter, driversJSON)
 	}
 	return fields
 }
@@ -844,21 +1453,6 @@ func parseFields(pkg *packages.Package, spec *ast.TypeSpec, adapter string) ([]F
 			continue // Skip private fields
 		}
 
-		// Check if the field is a struct type that should be skipped
-		if tv, ok := pkg.TypesInfo.Types[field.Type]; ok {
-			if !isSupportedType(tv.Type) {
-				if _, ok := tv.Type.Underlying().(*types.Struct); !ok {
-					return nil, fmt.Errorf("unsupported field type %s for field %s", tv.Type.String(), field.Names[0].Name)
-				}
-			}
-			if _, ok := tv.Type.Underlying().(*types.Struct); ok {
-				// Allow time.Time, but skip other structs
-				if tv.Type.String() != "time.Time" {
-					continue
-				}
-			}
-		}
-
 		xqlTag := ""
 		if field.Tag != nil {
 			tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
@@ -869,22 +1463,76 @@ func parseFields(pkg *packages.Package, spec *ast.TypeSpec, adapter string) ([]F
 			continue // Skip ignored fields
 		}
 
-		goType := types.ExprString(field.Type)
+		// A pointer to a supported type maps to a nullable column of that type.
+		typeExpr := field.Type
+		star, nullable := typeExpr.(*ast.StarExpr)
+		if nullable {
+			typeExpr = star.X
+		}
+
+		goType := types.ExprString(typeExpr)
 		// For selector expressions like `time.Time`, we need to get the full type string.
-		if se, ok := field.Type.(*ast.SelectorExpr); ok {
+		if se, ok := typeExpr.(*ast.SelectorExpr); ok {
 			if x, ok := se.X.(*ast.Ident); ok {
 				goType = fmt.Sprintf("%s.%s", x.Name, se.Sel.Name)
 			}
 		}
 
 		entityField := Field{
-			GoName: field.Names[0].Name,
-			GoType: goType,
-			Name:   lo.SnakeCase(field.Names[0].Name),
+			GoName:     field.Names[0].Name,
+			GoType:     goType,
+			Name:       lo.SnakeCase(field.Names[0].Name),
+			IsNullable: nullable,
+			IsPointer:  nullable,
+			Comment:    docComment(lo.Ternary(field.Doc != nil, field.Doc, field.Comment)),
 		}
-
 		parseDirectives(xqlTag, &entityField)
 
+		// Check if the field is a struct type that should be skipped
+		if tv, ok := pkg.TypesInfo.Types[typeExpr]; ok {
+			_, isStruct := tv.Type.Underlying().(*types.Struct)
+			if shape := documentShape(tv.Type); shape != "" && isJSONType(entityField.DBType) {
+				// struct, map and slice fields declared as json are stored as documents
+				entityField.Document = shape
+			} else if !isSupportedType(tv.Type) && (!isStruct || nullable) {
+				return nil, fmt.Errorf("unsupported field type %s for field %s", types.ExprString(field.Type), field.Names[0].Name)
+			} else if isStruct && tv.Type.String() != "time.Time" {
+				// Allow time.Time, but skip other structs unless declared as json
+				continue
+			}
+		}
+		if entityField.GoName == "DeletedAt" && entityField.GoType == "time.Time" {
+			entityField.IsSoftDelete = true
+		}
+		if entityField.IsSoftDelete {
+			if entityField.GoType != "time.Time" || entityField.IsPK || entityField.IsNotNull {
+				return nil, fmt.Errorf("soft-delete field %s must be a nullable time.Time", entityField.GoName)
+			}
+			// deleted_at stays NULL until the row is deleted
+			entityField.IsNullable = true
+			entityField.IsIndexed = true
+		}
+		if entityField.IsCreateTime || entityField.IsUpdateTime {
+			if entityField.GoType != "time.Time" || entityField.IsPK || entityField.IsSoftDelete || (entityField.IsCreateTime && entityField.IsUpdateTime) {
+				return nil, fmt.Errorf("field %s: autoCreateTime and autoUpdateTime apply to distinct time.Time fields", entityField.GoName)
+			}
+			if entityField.Default == "" {
+				entityField.Default = "now"
+			}
+		}
+		if entityField.Document != "" && entityField.IsPK {
+			return nil, fmt.Errorf("field %s is a json document and cannot be pk", entityField.GoName)
+		}
+		if nullable && (entityField.IsPK || entityField.IsNotNull) {
+			return nil, fmt.Errorf("field %s is a pointer and cannot be pk or not null", entityField.GoName)
+		}
+		if err := checkEnum(entityField); err != nil {
+			return nil, err
+		}
+		if err := checkForeignKey(entityField); err != nil {
+			return nil, err
+		}
+
 		fields = append(fields, entityField)
 	}
 	return fields, nil
@@ -908,12 +1556,28 @@ func parseDirectives(tag string, field *Field) {
 		switch key {
 		case "pk":
 			field.IsPK = true
+		case "auto":
+			field.IsAuto = true
 		case "not null":
 			field.IsNotNull = true
+		case "softdelete":
+			field.IsSoftDelete = true
+		case "autocreatetime":
+			field.IsCreateTime = true
+		case "autoupdatetime":
+			field.IsUpdateTime = true
 		case "unique":
 			field.IsUnique = true
 		case "index":
-			field.IsIndexed = true
+			if name := parseIndexOptions(value, field); name != "" {
+				field.IndexGroups = append(field.IndexGroups, name)
+			} else {
+				field.IsIndexed = true
+			}
+		case "enum":
+			field.EnumValues = lo.Map(strings.Split(value, ","), func(v string, _ int) string { return strings.TrimSpace(v) })
+		case "uniqueindex":
+			field.UniqueGroups = append(field.UniqueGroups, parseIndexOptions(value, field))
 		case "name":
 			field.Name = value
 		case "type":
@@ -921,19 +1585,28 @@ func parseDirectives(tag string, field *Field) {
 		case "default":
 			field.Default = value
 		case "fk":
-			fkParts := strings.SplitN(value, ".", 2)
-			if len(fkParts) == 2 {
-				field.FKTable = fkParts[0]
-				field.FKColumn = fkParts[1]
-			}
+			parseForeignKey(value, field)
 		}
 	}
 }
 
+// jsonTypeFor returns the column type of the adapter for the json or jsonb
+// type directive: adapters without jsonb use their json type, and adapters
+// without json types store the text.
+func jsonTypeFor(dbType string, adapter string, driversJSON []byte) string {
+	if res := gjson.GetBytes(driversJSON, fmt.Sprintf("%s.json.%s", adapter, strings.ToLower(dbType))); res.Exists() {
+		return res.String()
+	}
+	return "TEXT"
+}
+
 // sqlTypeFor returns the SQL type for a given Go type and adapter using the
-// parsed drivers JSON (queried via gjson). If no mapping exists, it falls back
-// to a sensible default.
+// typeMapping of xql.yaml, then the parsed drivers JSON (queried via gjson).
+// If no mapping exists, it falls back to a sensible default.
 func sqlTypeFor(goType string, adapter string, driversJSON []byte) string {
+	if sqlType, ok := typeMappings[adapter][goType]; ok {
+		return sqlType
+	}
 	if len(driversJSON) > 0 {
 		path := fmt.Sprintf("%s.typeMapping.%s", adapter, goType)
 		if res := gjson.GetBytes(driversJSON, path); res.Exists() {
@@ -951,8 +1624,11 @@ func sqlTypeFor(goType string, adapter string, driversJSON []byte) string {
 	case "int8":
 		return "SMALLINT"
 	case "bool":
-		if adapter == "mysql" {
+		switch adapter {
+		case "mysql":
 			return "TINYINT(1)"
+		case "mssql":
+			return "BIT"
 		}
 		return "BOOLEAN"
 	case "string":
@@ -960,18 +1636,29 @@ func sqlTypeFor(goType string, adapter string, driversJSON []byte) string {
 	case "float32":
 		return "REAL"
 	case "float64":
-		if adapter == "postgres" {
+		switch adapter {
+		case "postgres":
 			return "DOUBLE PRECISION"
+		case "mssql":
+			return "FLOAT"
+		case "oracle":
+			return "BINARY_DOUBLE"
 		}
 		return "DOUBLE"
 	case "time.Time":
-		if adapter == "postgres" {
+		switch adapter {
+		case "postgres", "oracle":
 			return "TIMESTAMP WITH TIME ZONE"
+		case "mssql":
+			return "DATETIMEOFFSET"
 		}
 		return "DATETIME"
 	case "[]byte":
-		if adapter == "postgres" {
+		switch adapter {
+		case "postgres":
 			return "BYTEA"
+		case "mssql":
+			return "VARBINARY(MAX)"
 		}
 		return "BLOB"
 	default:
@@ -979,6 +1666,202 @@ func sqlTypeFor(goType string, adapter string, driversJSON []byte) string {
 	}
 }
 
+// checkEnum validates the values of an enum directive: the field must be a
+// string and every value must yield a distinct Go constant name.
+func checkEnum(f Field) error {
+	if len(f.EnumValues) == 0 {
+		return nil
+	}
+	if f.GoType != "string" {
+		return fmt.Errorf("enum on field %s requires a string field, got %s", f.GoName, f.GoType)
+	}
+	seen := map[string]string{}
+	for _, v := range f.EnumValues {
+		name := lo.PascalCase(v)
+		if name == "" {
+			return fmt.Errorf("invalid enum value %q on field %s", v, f.GoName)
+		}
+		if prev, ok := seen[name]; ok {
+			return fmt.Errorf("enum values %q and %q of field %s map to the same constant %s%s", prev, v, f.GoName, f.GoName, name)
+		}
+		seen[name] = v
+	}
+	return nil
+}
+
+// fkActions are the referential actions accepted by the onDelete and
+// onUpdate options of the fk directive.
+var fkActions = []string{"CASCADE", "RESTRICT", "NO ACTION", "SET NULL", "SET DEFAULT"}
+
+// parseForeignKey reads the value of an fk directive, such as
+// "accounts.id,onDelete:cascade,onUpdate:restrict", into field. Actions are
+// upper-cased with underscores read as spaces, so set_null is SET NULL.
+func parseForeignKey(value string, field *Field) {
+	opts := strings.Split(value, ",")
+	field.FKTable, field.FKColumn, _ = strings.Cut(strings.TrimSpace(opts[0]), ".")
+	for _, opt := range opts[1:] {
+		key, action, _ := strings.Cut(opt, ":")
+		action = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(action), "_", " "))
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "ondelete":
+			field.FKOnDelete = action
+		case "onupdate":
+			field.FKOnUpdate = action
+		default:
+			// not an action: kept as is for checkForeignKey to report
+			field.FKOnDelete = strings.TrimSpace(opt)
+		}
+	}
+}
+
+// checkForeignKey validates the shape of the fk directive of f. Whether the
+// referenced table and column exist is checked by checkReferences once all
+// entities are parsed.
+func checkForeignKey(f Field) error {
+	if f.FKTable == "" && f.FKColumn == "" {
+		return nil
+	}
+	if f.FKTable == "" || f.FKColumn == "" {
+		return fmt.Errorf("fk of field %s must reference table.column, got %q", f.GoName, f.FKTable+f.FKColumn)
+	}
+	for _, action := range []string{f.FKOnDelete, f.FKOnUpdate} {
+		if action != "" && !lo.Contains(fkActions, action) {
+			return fmt.Errorf("invalid fk option %q on field %s", action, f.GoName)
+		}
+	}
+	if (f.FKOnDelete == "SET NULL" || f.FKOnUpdate == "SET NULL") && (f.IsPK || f.IsNotNull) {
+		return fmt.Errorf("fk of field %s sets null but the column is not null", f.GoName)
+	}
+	return nil
+}
+
+// checkReferences ensures every foreign key references a column of a table
+// entity. With partial metas, from an entity filter, references to tables
+// outside of them are left unchecked.
+func checkReferences(metas []EntityMeta, partial bool) error {
+	tables := lo.KeyBy(lo.Filter(metas, func(m EntityMeta, _ int) bool { return m.ViewQuery == "" }), func(m EntityMeta) string { return m.TableName })
+	for _, meta := range metas {
+		for _, f := range meta.Fields {
+			if f.FKTable == "" {
+				continue
+			}
+			ref, ok := tables[f.FKTable]
+			if !ok {
+				if partial {
+					continue
+				}
+				return fmt.Errorf("fk of %s.%s references unknown table %s", meta.StructName, f.GoName, f.FKTable)
+			}
+			if !lo.ContainsBy(ref.Fields, func(rf Field) bool { return rf.Name == f.FKColumn }) {
+				return fmt.Errorf("fk of %s.%s references unknown column %s.%s", meta.StructName, f.GoName, f.FKTable, f.FKColumn)
+			}
+		}
+	}
+	return nil
+}
+
+// references renders the target and actions of the foreign key of f, as
+// they follow REFERENCES, or an empty string when f has none.
+func references(f Field) string {
+	if f.FKTable == "" {
+		return ""
+	}
+	ref := fmt.Sprintf("%s (%s)", f.FKTable, f.FKColumn)
+	if f.FKOnDelete != "" {
+		ref += " ON DELETE " + f.FKOnDelete
+	}
+	if f.FKOnUpdate != "" {
+		ref += " ON UPDATE " + f.FKOnUpdate
+	}
+	return ref
+}
+
+// foreignKey renders the named table constraint of a foreign key. Table
+// constraints are used rather than column ones, which MySQL ignores.
+func foreignKey(table, column, references string) string {
+	return fmt.Sprintf("CONSTRAINT fk_%s_%s FOREIGN KEY (%s) REFERENCES %s", table, column, column, references)
+}
+
+// foreignKeys renders the foreign key constraints of the fields of table.
+func foreignKeys(table string, fields []Field) []string {
+	return lo.FilterMap(fields, func(f Field, _ int) (string, bool) {
+		return foreignKey(table, f.Name, references(f)), f.FKTable != ""
+	})
+}
+
+// nativeEnum reports whether the adapter has enum types; the others get a
+// CHECK constraint.
+func nativeEnum(adapter string) bool {
+	return gjson.GetBytes(driversJSON, adapter+".nativeEnum").Bool()
+}
+
+// enumType returns the name of the enum type created for a column.
+func enumType(table, column string) string {
+	return table + "_" + column
+}
+
+// createEnum returns the statement creating the enum type of a column. Types
+// have no IF NOT EXISTS, so an existing type is skipped by catching the error.
+func createEnum(table, column string, values []string) string {
+	return fmt.Sprintf("DO $$ BEGIN CREATE TYPE %s AS ENUM (%s); EXCEPTION WHEN duplicate_object THEN NULL; END $$;", enumType(table, column), sqlList(values))
+}
+
+// sqlList renders values as a comma-separated list of SQL string literals.
+func sqlList(values []string) string {
+	return strings.Join(lo.Map(values, func(v string, _ int) string {
+		return sqlString(v)
+	}), ", ")
+}
+
+// sqlString renders v as a SQL string literal.
+func sqlString(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
+
+// typeDoc returns the doc comment of the type declared by spec, which is
+// attached to the enclosing declaration unless the type sits in a group.
+func typeDoc(pkg *packages.Package, spec *ast.TypeSpec) *ast.CommentGroup {
+	if spec.Doc != nil || pkg == nil {
+		return spec.Doc
+	}
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if gd, ok := decl.(*ast.GenDecl); ok && len(gd.Specs) == 1 && gd.Specs[0] == spec {
+				return gd.Doc
+			}
+		}
+	}
+	return nil
+}
+
+// docComment returns the first paragraph of a doc comment on a single line,
+// the summary kept as the comment of a table or column.
+func docComment(cg *ast.CommentGroup) string {
+	paragraph, _, _ := strings.Cut(cg.Text(), "\n\n")
+	return strings.Join(strings.Fields(paragraph), " ")
+}
+
+// comments reports how the adapter documents tables and columns, from
+// `comments` in drivers.json: "statement" for COMMENT ON statements,
+// "inline" for COMMENT clauses in the table definition, empty for none.
+func comments(adapter string) string {
+	return gjson.GetBytes(driversJSON, adapter+".comments").String()
+}
+
+// defaultFor resolves a generated default such as `uuid` or `now` to the
+// adapter's expression from the drivers JSON. Other values are returned as is.
+func defaultFor(value string, adapter string, driversJSON []byte) string {
+	if value == "" || len(driversJSON) == 0 {
+		return value
+	}
+	// look the key up in the map: default values may hold gjson path syntax
+	defaults := gjson.GetBytes(driversJSON, adapter+".defaults").Map()
+	if res, ok := defaults[strings.ToLower(strings.TrimSpace(value))]; ok {
+		return res.String()
+	}
+	return value
+}
+
 // pkConstraintFor returns the PK constraint clause for the given Go type and
 // SQL type for the adapter. It normalizes the SQL type, tries exact and family
 // fallbacks, and returns an optional warning if PK is used on a discouraged Go type.
@@ -1017,18 +1900,28 @@ func pkConstraintFor(goType string, sqlType string, adapter string, driversJSON
 // reordering.
 func computeEntityVersion(meta EntityMeta) string {
 	type vf struct {
-		GoName     string `json:"goName"`
-		GoType     string `json:"goType"`
-		Name       string `json:"name"`
-		DBType     string `json:"dbType"`
-		IsPK       bool   `json:"isPK"`
-		IsNotNull  bool   `json:"isNotNull"`
-		IsUnique   bool   `json:"isUnique"`
-		IsIndexed  bool   `json:"isIndexed"`
-		Default    string `json:"default"`
-		FKTable    string `json:"fkTable"`
-		FKColumn   string `json:"fkColumn"`
-		IsEmbedded bool   `json:"isEmbedded"`
+		GoName     string   `json:"goName"`
+		GoType     string   `json:"goType"`
+		Name       string   `json:"name"`
+		DBType     string   `json:"dbType"`
+		IsPK       bool     `json:"isPK"`
+		IsAuto     bool     `json:"isAuto,omitempty"`
+		IsNotNull  bool     `json:"isNotNull"`
+		IsNullable bool     `json:"isNullable,omitempty"`
+		SoftDelete bool     `json:"softDelete,omitempty"`
+		CreateTime bool     `json:"createTime,omitempty"`
+		UpdateTime bool     `json:"updateTime,omitempty"`
+		IsUnique   bool     `json:"isUnique"`
+		IsIndexed  bool     `json:"isIndexed"`
+		IndexKind  string   `json:"indexKind,omitempty"`
+		Enum       []string `json:"enum,omitempty"`
+		Default    string   `json:"default"`
+		FKTable    string   `json:"fkTable"`
+		FKColumn   string   `json:"fkColumn"`
+		FKOnDelete string   `json:"fkOnDelete,omitempty"`
+		FKOnUpdate string   `json:"fkOnUpdate,omitempty"`
+		Comment    string   `json:"comment,omitempty"`
+		IsEmbedded bool     `json:"isEmbedded"`
 	}
 
 	vfs := make([]vf, 0, len(meta.Fields))
@@ -1039,12 +1932,22 @@ func computeEntityVersion(meta EntityMeta) string {
 			Name:       f.Name,
 			DBType:     f.DBType,
 			IsPK:       f.IsPK,
+			IsAuto:     f.IsAuto,
 			IsNotNull:  f.IsNotNull,
+			IsNullable: f.IsNullable,
+			SoftDelete: f.IsSoftDelete,
+			CreateTime: f.IsCreateTime,
+			UpdateTime: f.IsUpdateTime,
 			IsUnique:   f.IsUnique,
 			IsIndexed:  f.IsIndexed,
+			IndexKind:  strings.TrimSpace(f.IndexMethod + " " + f.IndexOrder),
+			Enum:       f.EnumValues,
 			Default:    f.Default,
 			FKTable:    f.FKTable,
 			FKColumn:   f.FKColumn,
+			FKOnDelete: f.FKOnDelete,
+			FKOnUpdate: f.FKOnUpdate,
+			Comment:    f.Comment,
 			IsEmbedded: f.IsEmbedded,
 		})
 	}
@@ -1058,11 +1961,17 @@ func computeEntityVersion(meta EntityMeta) string {
 	})
 
 	payload := struct {
-		Table  string `json:"table"`
-		Fields []vf   `json:"fields"`
+		Table   string  `json:"table"`
+		View    string  `json:"view,omitempty"`
+		Comment string  `json:"comment,omitempty"`
+		Fields  []vf    `json:"fields"`
+		Indexes []Index `json:"indexes,omitempty"`
 	}{
-		Table:  meta.TableName,
-		Fields: vfs,
+		Table:   meta.TableName,
+		View:    meta.ViewQuery,
+		Comment: meta.Comment,
+		Fields:  vfs,
+		Indexes: meta.Indexes,
 	}
 
 	b, _ := json.Marshal(payload)
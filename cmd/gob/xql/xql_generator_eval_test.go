@@ -1,11 +1,17 @@
 package xql
 
 import (
+	"bytes"
 	"go/ast"
+	"go/format"
 	"path/filepath"
 	"testing"
+	"text/template"
 
+	"github.com/kcmvp/xql/cmd/internal"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -101,3 +107,660 @@ func TestComputeEntityVersion_ChangeDetected(t *testing.T) {
 	v2 := computeEntityVersion(m)
 	require.NotEqual(t, v1, v2)
 }
+
+func TestEnrichFieldsForAdapter_Generated(t *testing.T) {
+	base := []Field{
+		{GoName: "ID", GoType: "int64", Name: "id", IsPK: true, IsAuto: true},
+		{GoName: "Token", GoType: "string", Name: "token", Default: "uuid"},
+		{GoName: "CreatedAt", GoType: "time.Time", Name: "created_at", Default: "NOW"},
+		{GoName: "Nickname", GoType: "string", Name: "nick_name", Default: "'anonymous'"},
+	}
+	tests := []struct {
+		adapter  string
+		pkClause string
+		uuid     string
+	}{
+		{"postgres", "PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY", "gen_random_uuid()"},
+		{"mysql", "PRIMARY KEY AUTO_INCREMENT", "(UUID())"},
+		{"sqlite", "PRIMARY KEY AUTOINCREMENT", ""},
+		{"mssql", "IDENTITY(1,1) PRIMARY KEY", "NEWID()"},
+		{"oracle", "GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.adapter, func(t *testing.T) {
+			fields := enrichFieldsForAdapter(base, tt.adapter)
+			require.Equal(t, tt.pkClause, fields[0].PKClause)
+			require.Empty(t, fields[0].Warning)
+			if tt.uuid != "" {
+				require.Equal(t, tt.uuid, fields[1].Default)
+			} else if tt.adapter == "oracle" {
+				require.Contains(t, fields[1].Default, "SYS_GUID()")
+			} else {
+				require.Contains(t, fields[1].Default, "randomblob")
+			}
+			require.Equal(t, "CURRENT_TIMESTAMP", fields[2].Default)
+			require.Equal(t, "'anonymous'", fields[3].Default)
+		})
+	}
+	// the base fields are left untouched
+	require.Equal(t, "uuid", base[1].Default)
+
+	t.Run("non integer", func(t *testing.T) {
+		fields := enrichFieldsForAdapter([]Field{{GoName: "Code", GoType: "string", Name: "code", IsPK: true, IsAuto: true}}, "postgres")
+		require.Empty(t, fields[0].PKClause)
+		require.Contains(t, fields[0].Warning, "only integer primary keys")
+	})
+	t.Run("without pk", func(t *testing.T) {
+		fields := enrichFieldsForAdapter([]Field{{GoName: "Seq", GoType: "int64", Name: "seq", IsAuto: true}}, "postgres")
+		require.Contains(t, fields[0].Warning, "requires pk")
+	})
+}
+
+func TestBuildIndexes(t *testing.T) {
+	parse := func(name, tag string) Field {
+		f := Field{GoName: name, Name: name}
+		parseDirectives(tag, &f)
+		return f
+	}
+	fields := []Field{
+		parse("email", "uniqueIndex:acct_email_region;index"),
+		parse("nick", "index:acct_nick_created"),
+		parse("region", "uniqueIndex:acct_email_region"),
+		parse("created", "index:acct_nick_created"),
+	}
+	require.True(t, fields[0].IsIndexed)
+	indexes, err := buildIndexes("Account", fields)
+	require.NoError(t, err)
+	require.Equal(t, []Index{
+		{Name: "acct_email_region", Unique: true, Columns: []string{"email", "region"}},
+		{Name: "acct_nick_created", Columns: []string{"nick", "created"}},
+	}, indexes)
+
+	_, err = buildIndexes("Account", []Field{parse("a", "index:grp"), parse("b", "uniqueIndex:grp")})
+	require.ErrorContains(t, err, "declared both as index and uniqueIndex")
+	_, err = buildIndexes("Account", []Field{parse("a", "uniqueIndex:")})
+	require.ErrorContains(t, err, "invalid index name")
+	_, err = buildIndexes("Account", []Field{parse("a", "index:grp;index:grp")})
+	require.ErrorContains(t, err, "lists column a twice")
+}
+
+func TestCreateIndex(t *testing.T) {
+	parse := func(tag string) Field {
+		f := Field{GoName: "Tags", Name: "tags"}
+		parseDirectives(tag, &f)
+		return f
+	}
+	gin := parse("index:gin")
+	require.True(t, gin.IsIndexed)
+	require.Equal(t, "gin", gin.IndexMethod)
+	desc := parse("index:DESC")
+	require.Equal(t, "DESC", desc.IndexOrder)
+	hash := parse("index:hash")
+
+	tests := []struct {
+		adapter string
+		field   Field
+		want    string
+	}{
+		{"postgres", gin, "CREATE INDEX IF NOT EXISTS idx_posts_tags ON posts USING gin (tags);"},
+		{"mysql", gin, "CREATE INDEX IF NOT EXISTS idx_posts_tags ON posts (tags);"},
+		{"mysql", hash, "CREATE INDEX IF NOT EXISTS idx_posts_tags ON posts (tags) USING HASH;"},
+		{"sqlite", hash, "CREATE INDEX IF NOT EXISTS idx_posts_tags ON posts (tags);"},
+		{"sqlite", desc, "CREATE INDEX IF NOT EXISTS idx_posts_tags ON posts (tags DESC);"},
+		{"mssql", hash, "IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'idx_posts_tags' AND object_id = OBJECT_ID(N'posts'))\nCREATE INDEX idx_posts_tags ON posts (tags);"},
+		{"oracle", gin, "CREATE INDEX IF NOT EXISTS idx_posts_tags ON posts (tags);"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, createIndex(tt.adapter, "posts", fieldIndex("posts", tt.field)))
+	}
+
+	created := Field{GoName: "CreatedAt", Name: "created_at"}
+	parseDirectives("index:posts_recent,brin,desc", &created)
+	author := Field{GoName: "AuthorID", Name: "author_id"}
+	parseDirectives("index:posts_recent", &author)
+	indexes, err := buildIndexes("Post", []Field{author, created})
+	require.NoError(t, err)
+	require.Equal(t, "CREATE INDEX IF NOT EXISTS posts_recent ON posts USING brin (author_id, created_at DESC);", createIndex("postgres", "posts", indexes[0]))
+
+	_, err = buildIndexes("Post", []Field{created, parse("index:posts_recent,gin")})
+	require.ErrorContains(t, err, "declared with methods brin and gin")
+}
+
+func TestEnum(t *testing.T) {
+	status := Field{GoName: "Status", GoType: "string", Name: "status"}
+	parseDirectives("enum:active, inactive,in-progress;not null", &status)
+	require.Equal(t, []string{"active", "inactive", "in-progress"}, status.EnumValues)
+	require.NoError(t, checkEnum(status))
+
+	require.ErrorContains(t, checkEnum(Field{GoName: "Level", GoType: "int64", EnumValues: []string{"a"}}), "requires a string field")
+	require.ErrorContains(t, checkEnum(Field{GoName: "Status", GoType: "string", EnumValues: []string{"in_progress", "in-progress"}}), "same constant StatusInProgress")
+	require.ErrorContains(t, checkEnum(Field{GoName: "Status", GoType: "string", EnumValues: []string{"active", ""}}), "invalid enum value")
+
+	schema, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
+	require.NoError(t, err)
+	render := func(adapter string) string {
+		var buf bytes.Buffer
+		data := SchemaTemplateData{Adapter: adapter, TableName: "tasks", Fields: enrichFieldsForAdapter([]Field{status}, adapter)}
+		require.NoError(t, schema.ExecuteTemplate(&buf, "table", data))
+		return buf.String()
+	}
+	require.Equal(t, `DO $$ BEGIN CREATE TYPE tasks_status AS ENUM ('active', 'inactive', 'in-progress'); EXCEPTION WHEN duplicate_object THEN NULL; END $$;
+CREATE TABLE IF NOT EXISTS tasks (
+    status tasks_status NOT NULL
+);`, render("postgres"))
+	require.Equal(t, `CREATE TABLE IF NOT EXISTS tasks (
+    status TEXT NOT NULL CHECK (status IN ('active', 'inactive', 'in-progress'))
+);`, render("sqlite"))
+
+	fields, err := template.New("fields").Funcs(fieldFuncs).Parse(fieldsTmpl)
+	require.NoError(t, err)
+	status.ValidatorArgs = `, xql.OneOf("active", "inactive", "in-progress")`
+	var buf bytes.Buffer
+	require.NoError(t, fields.Execute(&buf, TemplateData{
+		PackageName: "task", StructName: "Task", TableName: "tasks", Fields: []Field{status},
+		ModulePath: "github.com/kcmvp/xql", ModulePkgName: "xql", EntityImportPath: "example.com/entity",
+	}))
+	src, err := format.Source(buf.Bytes())
+	require.NoError(t, err)
+	require.Contains(t, string(src), `Status = xql.NewField[Task, string]("status", "Status", xql.OneOf("active", "inactive", "in-progress"))`)
+	require.Contains(t, string(src), `// Status values of Task.
+const (
+	StatusActive     = "active"
+	StatusInactive   = "inactive"
+	StatusInProgress = "in-progress"
+)`)
+	require.Contains(t, string(src), `// Table and the Col constants are the names of the table and the columns of Task.
+const (
+	Table     = "tasks"
+	ColStatus = "status"
+)`)
+}
+
+func TestForeignKey(t *testing.T) {
+	f := Field{GoName: "AccountID", Name: "account_id"}
+	parseForeignKey("accounts.id, onUpdate:no_action", &f)
+	require.NoError(t, checkForeignKey(f))
+	require.Equal(t, "accounts (id) ON UPDATE NO ACTION", references(f))
+
+	bad := Field{GoName: "AccountID"}
+	parseForeignKey("accounts.id,onDelete:drop", &bad)
+	require.EqualError(t, checkForeignKey(bad), `invalid fk option "DROP" on field AccountID`)
+	bad = Field{GoName: "AccountID"}
+	parseForeignKey("accounts.id,cascade", &bad)
+	require.EqualError(t, checkForeignKey(bad), `invalid fk option "cascade" on field AccountID`)
+	bad = Field{GoName: "AccountID"}
+	parseForeignKey("accounts", &bad)
+	require.EqualError(t, checkForeignKey(bad), `fk of field AccountID must reference table.column, got "accounts"`)
+
+	accounts := EntityMeta{StructName: "Account", TableName: "accounts", Fields: []Field{{Name: "id"}}}
+	totals := EntityMeta{StructName: "Totals", TableName: "totals", ViewQuery: "SELECT 1 AS id", Fields: []Field{{Name: "id"}}}
+	order := func(table, column string) EntityMeta {
+		return EntityMeta{StructName: "Order", TableName: "orders", Fields: []Field{{GoName: "AccountID", FKTable: table, FKColumn: column}}}
+	}
+	require.NoError(t, checkReferences([]EntityMeta{accounts, order("accounts", "id")}, false))
+	require.EqualError(t, checkReferences([]EntityMeta{accounts, order("accounts", "uid")}, false), "fk of Order.AccountID references unknown column accounts.uid")
+	require.EqualError(t, checkReferences([]EntityMeta{totals, order("totals", "id")}, false), "fk of Order.AccountID references unknown table totals")
+	require.NoError(t, checkReferences([]EntityMeta{order("users", "id")}, true))
+}
+
+func TestParseFields_Pointer(t *testing.T) {
+	pkg := loadPkgAtDir(t, filepath.Join("testdata", "nullable"))
+	spec := findTypeSpec(t, pkg, "Profile")
+	fields, err := parseFields(pkg, spec, "")
+	require.NoError(t, err)
+	require.Equal(t, []Field{
+		{GoName: "ID", GoType: "int64", Name: "id", IsPK: true},
+		{GoName: "Bio", GoType: "string", Name: "bio", IsNullable: true, IsPointer: true},
+		{GoName: "Age", GoType: "int32", Name: "age", IsNullable: true, IsPointer: true, Default: "0"},
+		{GoName: "Birthday", GoType: "time.Time", Name: "birthday", IsNullable: true, IsPointer: true},
+	}, fields)
+
+	schema, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, schema.ExecuteTemplate(&buf, "table", SchemaTemplateData{Adapter: "postgres", TableName: "profiles", Fields: enrichFieldsForAdapter(fields, "postgres")}))
+	require.NotContains(t, buf.String(), "NOT NULL")
+}
+
+func TestParseFields_Blob(t *testing.T) {
+	pkg := loadPkgAtDir(t, filepath.Join("testdata", "blob"))
+	spec := findTypeSpec(t, pkg, "Document")
+	fields, err := parseFields(pkg, spec, "")
+	require.NoError(t, err)
+	require.Equal(t, []Field{
+		{GoName: "ID", GoType: "int64", Name: "id", IsPK: true},
+		{GoName: "Content", GoType: "[]byte", Name: "content"},
+		{GoName: "Preview", GoType: "[]byte", Name: "preview", IsNullable: true, IsPointer: true},
+	}, fields)
+
+	for adapter, want := range map[string]string{"postgres": "BYTEA", "mysql": "BLOB", "sqlite": "BLOB"} {
+		require.Equal(t, want, enrichFieldsForAdapter(fields, adapter)[1].DBType, adapter)
+	}
+
+	tmpl, err := template.New("fields").Funcs(fieldFuncs).Parse(fieldsTmpl)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, TemplateData{
+		PackageName: "document", StructName: "Document", Fields: fields,
+		ModulePath: "github.com/kcmvp/xql", ModulePkgName: "xql", EntityImportPath: "example.com/entity",
+	}))
+	src, err := format.Source(buf.Bytes())
+	require.NoError(t, err)
+	require.Contains(t, string(src), `Content = xql.NewBlobField[Document]("content", "Content")`)
+	require.Contains(t, string(src), `Preview = xql.NewBlobField[Document]("preview", "Preview").Nullable()`)
+}
+
+func TestParseFields_Document(t *testing.T) {
+	pkg := loadPkgAtDir(t, filepath.Join("testdata", "document"))
+	spec := findTypeSpec(t, pkg, "Article")
+	fields, err := parseFields(pkg, spec, "")
+	require.NoError(t, err)
+	require.Equal(t, []Field{
+		{GoName: "ID", GoType: "int64", Name: "id", IsPK: true},
+		{GoName: "Settings", GoType: "Settings", Name: "settings", DBType: "jsonb", Document: "object"},
+		{GoName: "Labels", GoType: "map[string]string", Name: "labels", DBType: "json", Document: "object"},
+		{GoName: "Tags", GoType: "[]string", Name: "tags", DBType: "jsonb", Document: "array", IsIndexed: true, IndexMethod: "gin"},
+		{GoName: "Draft", GoType: "Settings", Name: "draft", DBType: "jsonb", Document: "object", IsNullable: true, IsPointer: true},
+	}, fields)
+
+	for adapter, want := range map[string][]string{
+		"postgres": {"JSONB", "JSON"},
+		"mysql":    {"JSON", "JSON"},
+		"sqlite":   {"TEXT", "TEXT"},
+	} {
+		enriched := enrichFieldsForAdapter(fields, adapter)
+		require.Equal(t, want, []string{enriched[1].DBType, enriched[2].DBType}, adapter)
+	}
+
+	tmpl, err := template.New("fields").Funcs(fieldFuncs).Parse(fieldsTmpl)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, TemplateData{
+		PackageName: "article", StructName: "Article", Fields: fields,
+		ModulePath: "github.com/kcmvp/xql", ModulePkgName: "xql", EntityImportPath: "example.com/entity",
+	}))
+	src, err := format.Source(buf.Bytes())
+	require.NoError(t, err)
+	require.Contains(t, string(src), `Settings = xql.NewDocumentField[Article]("settings", "Settings").Object()`)
+	require.Contains(t, string(src), `Tags     = xql.NewDocumentField[Article]("tags", "Tags").Array()`)
+	require.Contains(t, string(src), `Draft    = xql.NewDocumentField[Article]("draft", "Draft").Object().Nullable()`)
+}
+
+func TestParseFields_SoftDelete(t *testing.T) {
+	pkg := loadPkgAtDir(t, filepath.Join("testdata", "softdelete"))
+	fields, err := parseFields(pkg, findTypeSpec(t, pkg, "Post"), "")
+	require.NoError(t, err)
+	require.Equal(t, Field{GoName: "DeletedAt", GoType: "time.Time", Name: "deleted_at", IsNullable: true, IsPointer: true, IsSoftDelete: true, IsIndexed: true}, fields[2])
+	fields, err = parseFields(pkg, findTypeSpec(t, pkg, "Comment"), "")
+	require.NoError(t, err)
+	require.Equal(t, Field{GoName: "RemovedAt", GoType: "time.Time", Name: "removed_at", IsNullable: true, IsSoftDelete: true, IsIndexed: true}, fields[1])
+	_, err = parseFields(pkg, findTypeSpec(t, pkg, "Invalid"), "")
+	require.ErrorContains(t, err, "soft-delete field Removed must be a nullable time.Time")
+
+	schema, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
+	require.NoError(t, err)
+	render := func(adapter string) string {
+		var buf bytes.Buffer
+		data := SchemaTemplateData{Adapter: adapter, TableName: "comments", Fields: enrichFieldsForAdapter(fields, adapter)}
+		require.NoError(t, schema.ExecuteTemplate(&buf, "table", data))
+		return buf.String()
+	}
+	require.Contains(t, render("postgres"), "CREATE INDEX IF NOT EXISTS idx_comments_removed_at ON comments (removed_at) WHERE removed_at IS NULL;")
+	require.Contains(t, render("sqlite"), "ON comments (removed_at) WHERE removed_at IS NULL;")
+	require.Contains(t, render("mysql"), "CREATE INDEX IF NOT EXISTS idx_comments_removed_at ON comments (removed_at);")
+
+	tmpl, err := template.New("fields").Funcs(fieldFuncs).Parse(fieldsTmpl)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, TemplateData{
+		PackageName: "comment", StructName: "Comment", Fields: fields,
+		ModulePath: "github.com/kcmvp/xql", ModulePkgName: "xql", EntityImportPath: "example.com/entity",
+	}))
+	require.Contains(t, buf.String(), `RemovedAt = xql.NewField[Comment, time.Time]("removed_at", "RemovedAt").Nullable().SoftDelete()`)
+}
+
+func TestParseFields_AutoTime(t *testing.T) {
+	pkg := loadPkgAtDir(t, filepath.Join("testdata", "timestamps"))
+	fields, err := parseFields(pkg, findTypeSpec(t, pkg, "Note"), "")
+	require.NoError(t, err)
+	require.Equal(t, []Field{
+		{GoName: "ID", GoType: "int64", Name: "id", IsPK: true},
+		{GoName: "CreatedAt", GoType: "time.Time", Name: "created_at", IsCreateTime: true, Default: "now"},
+		{GoName: "UpdatedAt", GoType: "time.Time", Name: "updated_at", IsUpdateTime: true, IsNotNull: true, Default: "now"},
+	}, fields)
+	_, err = parseFields(pkg, findTypeSpec(t, pkg, "Invalid"), "")
+	require.ErrorContains(t, err, "autoCreateTime and autoUpdateTime apply to distinct time.Time fields")
+
+	schema, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, schema.ExecuteTemplate(&buf, "table", SchemaTemplateData{Adapter: "postgres", TableName: "notes", Fields: enrichFieldsForAdapter(fields, "postgres")}))
+	require.Equal(t, `CREATE TABLE IF NOT EXISTS notes (
+    id BIGINT PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);`, buf.String())
+
+	tmpl, err := template.New("fields").Funcs(fieldFuncs).Parse(fieldsTmpl)
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, tmpl.Execute(&buf, TemplateData{
+		PackageName: "note", StructName: "Note", Fields: fields,
+		ModulePath: "github.com/kcmvp/xql", ModulePkgName: "xql", EntityImportPath: "example.com/entity",
+	}))
+	require.Contains(t, buf.String(), `CreatedAt = xql.NewField[Note, time.Time]("created_at", "CreatedAt").AutoCreateTime()`)
+	require.Contains(t, buf.String(), `UpdatedAt = xql.NewField[Note, time.Time]("updated_at", "UpdatedAt").AutoUpdateTime()`)
+}
+
+func TestParseFields_ForeignKey(t *testing.T) {
+	pkg := loadPkgAtDir(t, filepath.Join("testdata", "foreignkey"))
+	fields, err := parseFields(pkg, findTypeSpec(t, pkg, "Order"), "")
+	require.NoError(t, err)
+	require.Equal(t, []Field{
+		{GoName: "ID", GoType: "int64", Name: "id", IsPK: true},
+		{GoName: "AccountID", GoType: "int64", Name: "account_id", IsIndexed: true, FKTable: "accounts", FKColumn: "id", FKOnDelete: "CASCADE", FKOnUpdate: "RESTRICT"},
+		{GoName: "ParentID", GoType: "int64", Name: "parent_id", IsNullable: true, IsPointer: true, FKTable: "orders", FKColumn: "id", FKOnDelete: "SET NULL"},
+	}, fields)
+	_, err = parseFields(pkg, findTypeSpec(t, pkg, "Invalid"), "")
+	require.ErrorContains(t, err, "fk of field AccountID sets null but the column is not null")
+
+	schema, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, schema.ExecuteTemplate(&buf, "table", SchemaTemplateData{Adapter: "mysql", TableName: "orders", Fields: enrichFieldsForAdapter(fields, "mysql")}))
+	require.Equal(t, `CREATE TABLE IF NOT EXISTS orders (
+    id BIGINT PRIMARY KEY,
+    account_id BIGINT,
+    parent_id BIGINT,
+    CONSTRAINT fk_orders_account_id FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT fk_orders_parent_id FOREIGN KEY (parent_id) REFERENCES orders (id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_orders_account_id ON orders (account_id);`, buf.String())
+}
+
+func TestParseFields_Comment(t *testing.T) {
+	pkg := loadPkgAtDir(t, filepath.Join("testdata", "comment"))
+	spec := findTypeSpec(t, pkg, "Account")
+	require.Equal(t, "Account is a customer account.", docComment(typeDoc(pkg, spec)))
+	fields, err := parseFields(pkg, spec, "")
+	require.NoError(t, err)
+	require.Equal(t, []Field{
+		{GoName: "ID", GoType: "int64", Name: "id", IsPK: true},
+		{GoName: "Email", GoType: "string", Name: "email", Comment: "Email is where the customer's receipts are sent."},
+		{GoName: "Nickname", GoType: "string", Name: "nickname", Comment: "shown on reviews"},
+	}, fields)
+
+	schema, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
+	require.NoError(t, err)
+	data := func(adapter string) SchemaTemplateData {
+		return SchemaTemplateData{Adapter: adapter, TableName: "accounts", Comment: "Account is a customer account.", Fields: enrichFieldsForAdapter(fields, adapter)}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, schema.ExecuteTemplate(&buf, "table", data("postgres")))
+	require.Equal(t, `CREATE TABLE IF NOT EXISTS accounts (
+    id BIGINT PRIMARY KEY,
+    email TEXT,
+    nickname TEXT
+);
+COMMENT ON TABLE accounts IS 'Account is a customer account.';
+COMMENT ON COLUMN accounts.email IS 'Email is where the customer''s receipts are sent.';
+COMMENT ON COLUMN accounts.nickname IS 'shown on reviews';`, buf.String())
+	buf.Reset()
+	require.NoError(t, schema.ExecuteTemplate(&buf, "table", data("mysql")))
+	require.Equal(t, `CREATE TABLE IF NOT EXISTS accounts (
+    id BIGINT PRIMARY KEY,
+    email TEXT COMMENT 'Email is where the customer''s receipts are sent.',
+    nickname TEXT COMMENT 'shown on reviews'
+) COMMENT='Account is a customer account.';`, buf.String())
+	buf.Reset()
+	require.NoError(t, schema.ExecuteTemplate(&buf, "table", data("sqlite")))
+	require.NotContains(t, buf.String(), "COMMENT")
+}
+
+func TestRepoTemplate(t *testing.T) {
+	project := &internal.Project{Root: "/app", Mod: &modfile.File{Module: &modfile.Module{Mod: module.Version{Path: "example.com/app"}}}}
+	meta := EntityMeta{StructName: "Note", PkgPath: "example.com/app/entity", TableName: "notes", Fields: []Field{
+		{GoName: "ID", GoType: "int64", Name: "id", IsPK: true, IsAuto: true},
+		{GoName: "Body", GoType: "string", Name: "body"},
+		{GoName: "Tags", GoType: "[]string", Name: "tags", Document: "array", IsNullable: true, IsPointer: true},
+		{GoName: "CreatedAt", GoType: "time.Time", Name: "created_at", IsCreateTime: true},
+		{GoName: "DeletedAt", GoType: "time.Time", Name: "deleted_at", IsSoftDelete: true, IsNullable: true},
+	}}
+	tmpl, err := template.New("repo").Parse(repoTmpl)
+	require.NoError(t, err)
+	render := func(meta EntityMeta) string {
+		var buf bytes.Buffer
+		require.NoError(t, tmpl.Execute(&buf, repoData(project, meta, TemplateData{PackageName: "note", Imports: buildImports(meta), ModulePath: "github.com/kcmvp/xql"})))
+		out, err := format.Source(buf.Bytes())
+		require.NoError(t, err, buf.String())
+		return string(out)
+	}
+
+	out := render(meta)
+	require.Contains(t, out, `field "example.com/app/sample/gen/field/note"`)
+	require.Contains(t, out, "func FindByID(ctx context.Context, db *sql.DB, id int64) (Note, error) {")
+	require.Contains(t, out, "sqlx.Query[Note](columns)(sqlx.NotDeleted[Note](where)).OrderBy(sqlx.Asc(field.ID)).Paginate(page).Execute(ctx, db)")
+	require.Contains(t, out, `	values := sqlx.FlatMap{
+		field.Body.QualifiedName(): e.Body,
+		field.Tags.QualifiedName(): e.Tags,
+	}
+	if !e.CreatedAt.IsZero() {
+		values[field.CreatedAt.QualifiedName()] = e.CreatedAt
+	}
+	if !e.DeletedAt.IsZero() {
+		values[field.DeletedAt.QualifiedName()] = e.DeletedAt
+	}`)
+	require.Contains(t, out, `	schema := sqlx.Schema{
+		field.Body,
+		field.Tags,
+	}`)
+	require.Contains(t, out, "func DeleteByID(ctx context.Context, db *sql.DB, id int64) (sql.Result, error) {")
+	require.Contains(t, out, "if e.Tags, err = sqlx.Scan[*[]string](row, field.Tags); err != nil {")
+	require.Contains(t, out, "if e.DeletedAt, err = sqlx.Scan[time.Time](row, field.DeletedAt); err != nil {")
+
+	meta.ViewQuery = "SELECT 1"
+	meta.Fields = meta.Fields[1:4]
+	out = render(meta)
+	require.Contains(t, out, "func List(")
+	require.Contains(t, out, "sqlx.Query[Note](columns)(where).Paginate(page).Execute(ctx, db)")
+	for _, fn := range []string{"FindByID", "Insert", "Update", "DeleteByID"} {
+		require.NotContains(t, out, "func "+fn+"(")
+	}
+}
+
+func TestRepoTemplate_SoftDelete(t *testing.T) {
+	project := &internal.Project{Root: "/app", Mod: &modfile.File{Module: &modfile.Module{Mod: module.Version{Path: "example.com/app"}}}}
+	pkg := loadPkgAtDir(t, filepath.Join("testdata", "softdelete"))
+	tmpl, err := template.New("repo").Parse(repoTmpl)
+	require.NoError(t, err)
+	render := func(name, table string) string {
+		fields, err := parseFields(pkg, findTypeSpec(t, pkg, name), "")
+		require.NoError(t, err)
+		meta := EntityMeta{StructName: name, PkgPath: "example.com/app/entity", TableName: table, Fields: fields}
+		var buf bytes.Buffer
+		require.NoError(t, tmpl.Execute(&buf, repoData(project, meta, TemplateData{PackageName: "post", Imports: buildImports(meta), ModulePath: "github.com/kcmvp/xql"})))
+		out, err := format.Source(buf.Bytes())
+		require.NoError(t, err, buf.String())
+		return string(out)
+	}
+
+	out := render("Post", "posts")
+	require.Contains(t, out, "// Rows whose DeletedAt is set are soft-deleted and left out.")
+	require.Contains(t, out, "sqlx.Query[Post](columns)(sqlx.NotDeleted[Post](where)).OrderBy(sqlx.Asc(field.ID)).Paginate(page).Execute(ctx, db)")
+	require.Contains(t, out, "// DeleteByID soft-deletes the Post row whose ID is id, setting its DeletedAt.")
+	require.Contains(t, out, "sqlx.SoftDelete[Post](sqlx.Eq(field.ID, id)).Execute(ctx, db)")
+	require.NotContains(t, out, "sqlx.Delete[Post]")
+	require.Contains(t, out, `	schema := sqlx.Schema{
+		field.Title,
+	}`)
+	require.Contains(t, out, "(sqlx.NotDeleted[Post](sqlx.Eq(field.ID, e.ID))).Execute(ctx, db)")
+	require.Contains(t, out, "// The key, DeletedAt and the autoCreateTime field are left as they are")
+
+	// the softdelete directive is honoured like a DeletedAt timestamp
+	out = render("Comment", "comments")
+	require.Contains(t, out, "sqlx.SoftDelete[Comment](sqlx.Eq(field.ID, id)).Execute(ctx, db)")
+	require.Contains(t, out, "sqlx.NotDeleted[Comment](where)")
+}
+
+func TestViewgenTemplate(t *testing.T) {
+	project := &internal.Project{Root: "/app", Mod: &modfile.File{Module: &modfile.Module{Mod: module.Version{Path: "example.com/app"}}}}
+	meta := EntityMeta{StructName: "Note", Fields: []Field{
+		{GoName: "ID", GoType: "int64", IsPK: true, IsAuto: true},
+		{GoName: "Title", GoType: "string", IsNotNull: true},
+		{GoName: "Status", GoType: "string", IsNotNull: true, Default: "'draft'"},
+		{GoName: "Body", GoType: "string"},
+		{GoName: "CreatedAt", GoType: "time.Time", IsCreateTime: true},
+		{GoName: "DeletedAt", GoType: "time.Time", IsSoftDelete: true, IsNullable: true},
+	}}
+	tmpl, err := template.New("viewgen").Parse(viewgenTmpl)
+	require.NoError(t, err)
+	render := func(meta EntityMeta) string {
+		var buf bytes.Buffer
+		require.NoError(t, tmpl.Execute(&buf, viewgenData(project, meta, TemplateData{PackageName: "note", ModulePath: "github.com/kcmvp/xql"})))
+		out, err := format.Source(buf.Bytes())
+		require.NoError(t, err, buf.String())
+		return string(out)
+	}
+
+	out := render(meta)
+	require.Contains(t, out, `field "example.com/app/sample/gen/field/note"`)
+	require.Contains(t, out, `"github.com/kcmvp/xql/view"`)
+	require.Contains(t, out, `var CreateSchema = view.WithXQLFields(
+	field.Title,
+).Extend(view.WithXQLFields(
+	field.ID,
+	field.Status,
+	field.Body,
+).Partial())`)
+	require.Contains(t, out, `var UpdateSchema = view.WithXQLFields(
+	field.ID,
+).Extend(view.WithXQLFields(
+	field.Title,
+	field.Status,
+	field.Body,
+).Partial())`)
+	require.NotContains(t, out, "field.CreatedAt")
+	require.NotContains(t, out, "field.DeletedAt")
+
+	// without a key every field of UpdateSchema is optional
+	meta.Fields = meta.Fields[1:3]
+	out = render(meta)
+	require.Contains(t, out, `var UpdateSchema = view.WithXQLFields(
+	field.Title,
+	field.Status,
+).Partial()`)
+	require.Contains(t, out, "every field is optional")
+}
+
+func TestFixtureExpr(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+		want  string
+	}{
+		{"Key", Field{GoType: "int64", IsPK: true}, "int64(seq)"},
+		{"Varchar", Field{Name: "nick_name", GoType: "string", DBType: "varchar(20)"}, `text("nick_name", seq, 20)`},
+		{"Text", Field{Name: "bio", GoType: "string"}, `text("bio", seq, 0)`},
+		{"Enum", Field{GoType: "string", EnumValues: []string{"draft", "paid"}}, `[]string{"draft", "paid"}[seq%2]`},
+		{"DecimalString", Field{GoType: "string", DBType: "decimal(5,2)"}, "fmt.Sprint(seq % 1000)"},
+		{"DecimalFloat", Field{GoType: "float64", DBType: "DECIMAL(10, 2)"}, "float64(seq%100000000) + 0.5"},
+		{"DecimalInt", Field{GoType: "int32", DBType: "numeric(4,0)"}, "int32(seq % 10000)"},
+		{"Float", Field{GoType: "float32"}, "float32(seq) + 0.5"},
+		{"SmallInt", Field{GoType: "int8"}, "int8(seq % 100)"},
+		{"Bool", Field{GoType: "bool"}, "seq%2 == 1"},
+		{"Blob", Field{Name: "avatar", GoType: "[]byte"}, `[]byte(text("avatar", seq, 0))`},
+		{"Time", Field{GoType: "time.Time"}, "time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(seq) * time.Hour)"},
+		{"Pointer", Field{GoType: "string", IsPointer: true, IsNullable: true}, ""},
+		{"Document", Field{GoType: "map[string]any", Document: "object"}, ""},
+		{"SoftDelete", Field{GoType: "time.Time", IsSoftDelete: true}, ""},
+		{"Unknown", Field{GoType: "uuid.UUID"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, fixtureExpr(tt.field))
+		})
+	}
+}
+
+func TestFixtureTemplate(t *testing.T) {
+	meta := EntityMeta{StructName: "Note", PkgPath: "example.com/app/entity", Fields: []Field{
+		{GoName: "ID", GoType: "int64", Name: "id", IsPK: true, IsAuto: true},
+		{GoName: "Title", GoType: "string", Name: "title", DBType: "varchar(10)"},
+		{GoName: "Tags", GoType: "[]string", Name: "tags", Document: "array"},
+	}}
+	tmpl, err := template.New("fixture").Parse(fixtureTmpl)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, fixtureData(meta, TemplateData{PackageName: "note"})))
+	formatted, err := format.Source(buf.Bytes())
+	require.NoError(t, err, buf.String())
+	out := string(formatted)
+	require.Contains(t, out, `import (
+	"encoding/json"
+	"fmt"
+
+	. "example.com/app/entity"
+)`)
+	require.Contains(t, out, `func New(seq int) Note {
+	var e Note
+	e.ID = int64(seq)
+	e.Title = text("title", seq, 10)
+	return e
+}`)
+	require.Contains(t, out, `"Title": e.Title,`)
+	require.NotContains(t, out, "Tags")
+	require.Contains(t, out, "func text(name string, seq, max int) string {")
+
+	// the text helper is only emitted when used
+	meta.Fields = meta.Fields[:1]
+	buf.Reset()
+	require.NoError(t, tmpl.Execute(&buf, fixtureData(meta, TemplateData{PackageName: "note"})))
+	require.NotContains(t, buf.String(), "func text(")
+	require.NotContains(t, buf.String(), `"fmt"`)
+}
+
+func TestResolveViewQuery(t *testing.T) {
+	pkg := loadPkgAtDir(t, filepath.Join("testdata", "readmodel"))
+	project := &internal.Project{Pkgs: []*packages.Package{pkg}}
+	query, err := resolveViewQuery(project, pkg.PkgPath, "AccountOrders")
+	require.NoError(t, err)
+	require.Equal(t, `SELECT a.id AS account_id, a.email, SUM(o.amount) AS total
+FROM accounts a JOIN orders o ON o.account_id = a.id
+GROUP BY a.id, a.email`, query)
+	table, err := resolveTableName(project, pkg.PkgPath, "AccountOrders")
+	require.NoError(t, err)
+	require.Equal(t, "account_orders", table)
+	_, err = resolveViewQuery(project, pkg.PkgPath, "Dynamic")
+	require.ErrorContains(t, err, "View() of Dynamic must return a string constant")
+
+	schema, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
+	require.NoError(t, err)
+	meta := EntityMeta{StructName: "AccountOrders", TableName: "account_orders", ViewQuery: query}
+	require.Equal(t, "account_orders_view.sql", schemaFileName(meta))
+	for adapter, prefix := range map[string]string{
+		"postgres": "CREATE OR REPLACE VIEW",
+		"mysql":    "CREATE OR REPLACE VIEW",
+		"sqlite":   "CREATE VIEW IF NOT EXISTS",
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, schema.Execute(&buf, SchemaTemplateData{Adapter: adapter, TableName: meta.TableName, ViewQuery: meta.ViewQuery}))
+		require.Contains(t, buf.String(), prefix+" account_orders AS\nSELECT a.id AS account_id, a.email, SUM(o.amount) AS total\n", adapter)
+		require.NotContains(t, buf.String(), "CREATE TABLE", adapter)
+	}
+}
+
+func findTypeSpec(t *testing.T, pkg *packages.Package, name string) *ast.TypeSpec {
+	for _, f := range pkg.Syntax {
+		for _, decl := range f.Decls {
+			if gd, ok := decl.(*ast.GenDecl); ok {
+				for _, spec := range gd.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == name {
+						return ts
+					}
+				}
+			}
+		}
+	}
+	t.Fatalf("type %s not found in package %s", name, pkg.PkgPath)
+	return nil
+}
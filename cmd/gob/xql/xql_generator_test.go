@@ -187,6 +187,10 @@ func TestGeneration(t *testing.T) {
 		t.Fatalf("failed to read generated field dir: %v", err)
 	}
 
+	// Every entity gets a repository and a view schema package
+	require.Contains(t, generated, filepath.Join(internal.Current.GenPath(), "repo", "account", "account_gen.go"))
+	require.Contains(t, generated, filepath.Join(internal.Current.GenPath(), "viewgen", "account", "account_gen.go"))
+
 	// Verify the output for schemas
 	for _, db := range []string{"sqlite", "postgres", "mysql"} {
 		compareInMemoryWithFiles(t, generated,
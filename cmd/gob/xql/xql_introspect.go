@@ -0,0 +1,552 @@
+package xql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/kcmvp/xql/cmd/internal"
+	"github.com/samber/lo"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+//go:embed resources/entity.tmpl
+var entityTmpl string
+
+// introspectDrivers maps the adapters introspect can connect to onto their
+// database/sql driver names.
+var introspectDrivers = map[string]string{
+	"sqlite":   "sqlite3",
+	"mysql":    "mysql",
+	"postgres": "postgres",
+}
+
+// dbTable is a table read from the database catalog.
+type dbTable struct {
+	Name        string
+	Columns     []dbColumn
+	Constraints []dbConstraint
+}
+
+// dbColumn is a column of a dbTable.
+type dbColumn struct {
+	Name    string
+	DBType  string // the column type as declared, e.g. "varchar(100)"
+	NotNull bool
+	Default string // the default expression as reported by the database, empty for none
+	Auto    bool   // the database generates the value: identity, serial or auto increment
+}
+
+// dbConstraint is a primary key, unique or foreign key constraint of a
+// dbTable, with its columns in key order.
+type dbConstraint struct {
+	Name      string
+	Kind      string // "PRIMARY KEY", "UNIQUE" or "FOREIGN KEY"
+	Columns   []string
+	RefTable  string
+	RefColumn string
+	OnDelete  string
+	OnUpdate  string
+}
+
+// EntityTemplateData holds the data passed to the entity template.
+type EntityTemplateData struct {
+	PackageName string
+	StructName  string
+	Receiver    string
+	TableName   string
+	Imports     []string
+	Fields      []EntityField
+}
+
+// EntityField is a struct field of an introspected entity.
+type EntityField struct {
+	GoName string
+	Type   string // the Go type, a pointer for nullable scalar columns
+	Tag    string // the xql tag, without quotes
+}
+
+// introspect reads the tables of the database at dsn, all of them or those
+// named, and writes an entity per table into the dir folder of the project,
+// then generates their field packages. Existing entity files are never
+// overwritten.
+func introspect(ctx context.Context, adapter, dsn, dir string, names []string) error {
+	project := internal.Current
+	if project == nil {
+		return fmt.Errorf("project context not initialized")
+	}
+	driver, ok := introspectDrivers[adapter]
+	if !ok {
+		return fmt.Errorf("unsupported adapter %q: use one of %s", adapter, strings.Join(lo.Keys(introspectDrivers), ", "))
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to open %s database: %w", adapter, err)
+	}
+	defer db.Close()
+	tables, err := readTables(ctx, db, adapter, names)
+	if err != nil {
+		return err
+	}
+	outDir := filepath.Join(project.Root, dir)
+	for _, t := range tables {
+		file := filepath.Join(outDir, entityFileName(t.Name))
+		if _, err := os.Stat(file); err == nil {
+			return fmt.Errorf("entity file %s already exists: remove it or leave table %s out", file, t.Name)
+		}
+	}
+	pkgPath := path.Join(project.Mod.Module.Mod.Path, filepath.ToSlash(dir))
+	metas, err := writeEntities(DiskWriter{}, tables, adapter, outDir, pkgPath)
+	if err != nil {
+		return err
+	}
+	return generateFieldsFromMeta(metas)
+}
+
+// readTables reads the tables of db from its catalog, all of them when names
+// is empty.
+func readTables(ctx context.Context, db *sql.DB, adapter string, names []string) ([]dbTable, error) {
+	var query string
+	switch adapter {
+	case "sqlite":
+		query = `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`
+	case "postgres":
+		query = `SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' ORDER BY table_name`
+	case "mysql":
+		query = `SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE' ORDER BY table_name`
+	default:
+		return nil, fmt.Errorf("unsupported adapter %q", adapter)
+	}
+	all, err := queryStrings(ctx, db, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	if len(names) > 0 {
+		if missing, _ := lo.Difference(names, all); len(missing) > 0 {
+			return nil, fmt.Errorf("tables not found: %s", strings.Join(missing, ", "))
+		}
+		all = lo.Filter(all, func(t string, _ int) bool { return slices.Contains(names, t) })
+	}
+	if len(all) == 0 {
+		return nil, errors.New("no tables found")
+	}
+	tables := make([]dbTable, 0, len(all))
+	for _, name := range all {
+		t := dbTable{Name: name}
+		if adapter == "sqlite" {
+			err = readSqliteTable(ctx, db, &t)
+		} else {
+			err = readSchemaTable(ctx, db, adapter, &t)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read table %s: %w", name, err)
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// readSqliteTable reads the columns and constraints of t from the sqlite
+// pragmas. An INTEGER primary key aliases the rowid and is generated.
+func readSqliteTable(ctx context.Context, db *sql.DB, t *dbTable) error {
+	rows, err := db.QueryContext(ctx, `SELECT name, type, "notnull", COALESCE(dflt_value, ''), pk FROM pragma_table_info(?) ORDER BY cid`, t.Name)
+	if err != nil {
+		return err
+	}
+	var pk []lo.Tuple2[int, string]
+	for rows.Next() {
+		var c dbColumn
+		var seq int
+		if err := rows.Scan(&c.Name, &c.DBType, &c.NotNull, &c.Default, &seq); err != nil {
+			rows.Close()
+			return err
+		}
+		if seq > 0 {
+			pk = append(pk, lo.T2(seq, c.Name))
+		}
+		t.Columns = append(t.Columns, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(pk) > 0 {
+		slices.SortFunc(pk, func(a, b lo.Tuple2[int, string]) int { return a.A - b.A })
+		t.Constraints = append(t.Constraints, dbConstraint{Name: t.Name + "_pkey", Kind: "PRIMARY KEY", Columns: lo.Map(pk, func(p lo.Tuple2[int, string], _ int) string { return p.B })})
+		if len(pk) == 1 {
+			for i := range t.Columns {
+				if t.Columns[i].Name == pk[0].B && strings.EqualFold(t.Columns[i].DBType, "integer") {
+					t.Columns[i].Auto = true
+				}
+			}
+		}
+	}
+
+	indexes, err := queryStrings(ctx, db, `SELECT name FROM pragma_index_list(?) WHERE "unique" = 1 AND origin <> 'pk' ORDER BY name`, t.Name)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		columns, err := queryStrings(ctx, db, `SELECT name FROM pragma_index_info(?) ORDER BY seqno`, index)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(index, "sqlite_autoindex_") {
+			// the index of an inline UNIQUE constraint has no name of its own
+			index = fmt.Sprintf("uk_%s_%s", t.Name, strings.Join(columns, "_"))
+		}
+		t.Constraints = append(t.Constraints, dbConstraint{Name: index, Kind: "UNIQUE", Columns: columns})
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT id, "table", "from", COALESCE("to", ''), on_delete, on_update FROM pragma_foreign_key_list(?) ORDER BY id, seq`, t.Name)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	fks := map[int]int{}
+	for rows.Next() {
+		var id int
+		var c dbConstraint
+		var column string
+		if err := rows.Scan(&id, &c.RefTable, &column, &c.RefColumn, &c.OnDelete, &c.OnUpdate); err != nil {
+			return err
+		}
+		if i, ok := fks[id]; ok {
+			t.Constraints[i].Columns = append(t.Constraints[i].Columns, column)
+			continue
+		}
+		c.Name, c.Kind, c.Columns = fmt.Sprintf("fk_%s_%d", t.Name, id), "FOREIGN KEY", []string{column}
+		fks[id] = len(t.Constraints)
+		t.Constraints = append(t.Constraints, c)
+	}
+	return rows.Err()
+}
+
+// readSchemaTable reads the columns and constraints of t from the
+// information_schema of postgres and mysql.
+func readSchemaTable(ctx context.Context, db *sql.DB, adapter string, t *dbTable) error {
+	var columns, constraints string
+	if adapter == "postgres" {
+		columns = `SELECT column_name, CASE WHEN data_type = 'USER-DEFINED' THEN udt_name ELSE data_type END,
+       COALESCE(character_maximum_length, 0), COALESCE(numeric_precision, 0), COALESCE(numeric_scale, 0),
+       is_nullable = 'NO', COALESCE(column_default, ''), is_identity = 'YES' OR COALESCE(column_default, '') LIKE 'nextval(%'
+FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 ORDER BY ordinal_position`
+		constraints = `SELECT tc.constraint_name, tc.constraint_type, kcu.column_name,
+       COALESCE(ccu.table_name, ''), COALESCE(ccu.column_name, ''), COALESCE(rc.delete_rule, ''), COALESCE(rc.update_rule, '')
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu
+  ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name AND kcu.table_name = tc.table_name
+LEFT JOIN information_schema.referential_constraints rc
+  ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+LEFT JOIN information_schema.constraint_column_usage ccu
+  ON tc.constraint_type = 'FOREIGN KEY' AND ccu.constraint_schema = tc.constraint_schema AND ccu.constraint_name = tc.constraint_name
+WHERE tc.table_schema = current_schema() AND tc.table_name = $1 AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY')
+ORDER BY tc.constraint_name, kcu.ordinal_position`
+	} else {
+		// column_type carries the length, precision and scale
+		columns = `SELECT column_name, column_type, 0, 0, 0, is_nullable = 'NO', COALESCE(column_default, ''), extra LIKE '%auto_increment%'
+FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? ORDER BY ordinal_position`
+		constraints = `SELECT tc.constraint_name, tc.constraint_type, kcu.column_name,
+       COALESCE(kcu.referenced_table_name, ''), COALESCE(kcu.referenced_column_name, ''), COALESCE(rc.delete_rule, ''), COALESCE(rc.update_rule, '')
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu
+  ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name AND kcu.table_name = tc.table_name
+LEFT JOIN information_schema.referential_constraints rc
+  ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name AND rc.table_name = tc.table_name
+WHERE tc.table_schema = DATABASE() AND tc.table_name = ? AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY')
+ORDER BY tc.constraint_name, kcu.ordinal_position`
+	}
+
+	rows, err := db.QueryContext(ctx, columns, t.Name)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var c dbColumn
+		var length, precision, scale int
+		if err := rows.Scan(&c.Name, &c.DBType, &length, &precision, &scale, &c.NotNull, &c.Default, &c.Auto); err != nil {
+			rows.Close()
+			return err
+		}
+		switch {
+		case c.DBType == "character varying" && length > 0:
+			c.DBType = fmt.Sprintf("varchar(%d)", length)
+		case c.DBType == "character" && length > 0:
+			c.DBType = fmt.Sprintf("char(%d)", length)
+		case c.DBType == "numeric" && precision > 0:
+			c.DBType = fmt.Sprintf("decimal(%d,%d)", precision, scale)
+		}
+		t.Columns = append(t.Columns, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = db.QueryContext(ctx, constraints, t.Name)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c dbConstraint
+		var column string
+		if err := rows.Scan(&c.Name, &c.Kind, &column, &c.RefTable, &c.RefColumn, &c.OnDelete, &c.OnUpdate); err != nil {
+			return err
+		}
+		if n := len(t.Constraints); n > 0 && t.Constraints[n-1].Name == c.Name {
+			t.Constraints[n-1].Columns = append(t.Constraints[n-1].Columns, column)
+			continue
+		}
+		c.Columns = []string{column}
+		t.Constraints = append(t.Constraints, c)
+	}
+	return rows.Err()
+}
+
+// queryStrings returns the first column of the rows of query.
+func queryStrings(ctx context.Context, db *sql.DB, query string, args ...any) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var values []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
+
+// writeEntities renders an entity file per table into dir and returns the
+// metadata of the entities, whose package is pkgPath.
+func writeEntities(w OutputWriter, tables []dbTable, adapter, dir, pkgPath string) ([]EntityMeta, error) {
+	tmpl, err := template.New("entity").Parse(entityTmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse entity template: %w", err)
+	}
+	if err := w.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory %s: %w", dir, err)
+	}
+	metas := make([]EntityMeta, 0, len(tables))
+	structs := map[string]string{}
+	for _, t := range tables {
+		meta, data := entityOf(t, adapter, path.Base(pkgPath))
+		if other, ok := structs[meta.StructName]; ok {
+			return nil, fmt.Errorf("tables %s and %s both map to entity %s", other, t.Name, meta.StructName)
+		}
+		structs[meta.StructName] = t.Name
+		meta.PkgPath = pkgPath
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to execute entity template for %s: %w", t.Name, err)
+		}
+		formatted, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to format entity for %s: %w", t.Name, err)
+		}
+		if err := w.WriteFile(filepath.Join(dir, entityFileName(t.Name)), formatted, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write entity for %s: %w", t.Name, err)
+		}
+		metas = append(metas, meta)
+	}
+	return metas, nil
+}
+
+// entityFileName returns the name of the file holding the entity of table.
+func entityFileName(table string) string {
+	return lo.SnakeCase(entityName(table)) + ".go"
+}
+
+// entityName returns the struct name of the entity of table: the singular of
+// the table name in PascalCase.
+func entityName(table string) string {
+	switch {
+	case strings.HasSuffix(table, "ies"):
+		table = strings.TrimSuffix(table, "ies") + "y"
+	case strings.HasSuffix(table, "sses"):
+		table = strings.TrimSuffix(table, "es")
+	case strings.HasSuffix(table, "s") && !strings.HasSuffix(table, "ss") && !strings.HasSuffix(table, "us"):
+		table = strings.TrimSuffix(table, "s")
+	}
+	return lo.PascalCase(table)
+}
+
+// fieldName returns the Go name of column, spelling a trailing id as ID.
+// Table is reserved for the generated table name constant.
+func fieldName(column string) string {
+	name := lo.PascalCase(column)
+	if strings.HasSuffix(name, "Id") {
+		name = strings.TrimSuffix(name, "Id") + "ID"
+	}
+	if name == "Table" {
+		name = "TableName"
+	}
+	return name
+}
+
+// pgCast matches the type cast postgres appends to default values.
+var pgCast = regexp.MustCompile(`^(.*)::[a-z ]+$`)
+
+// entityOf builds the entity of table t: its metadata, as parseFields would
+// read it from the generated source, and the template data of that source.
+func entityOf(t dbTable, adapter, pkgName string) (EntityMeta, EntityTemplateData) {
+	meta := EntityMeta{StructName: entityName(t.Name), TableName: t.Name}
+	data := EntityTemplateData{
+		PackageName: pkgName,
+		StructName:  meta.StructName,
+		Receiver:    strings.ToLower(meta.StructName[:1]),
+		TableName:   t.Name,
+	}
+	byColumn := func(kind string) map[string][]dbConstraint {
+		m := map[string][]dbConstraint{}
+		for _, c := range t.Constraints {
+			if c.Kind == kind {
+				for _, column := range c.Columns {
+					m[column] = append(m[column], c)
+				}
+			}
+		}
+		return m
+	}
+	pks, uniques, fks := byColumn("PRIMARY KEY"), byColumn("UNIQUE"), byColumn("FOREIGN KEY")
+	for _, c := range t.Columns {
+		goType, document := goTypeOf(c.DBType, adapter)
+		var tags []string
+		pk := len(pks[c.Name]) > 0 && len(pks[c.Name][0].Columns) == 1
+		if pk {
+			tags = append(tags, "pk")
+			if c.Auto {
+				tags = append(tags, "auto")
+			}
+		}
+		goName := fieldName(c.Name)
+		if lo.SnakeCase(goName) != c.Name {
+			tags = append(tags, "name:"+c.Name)
+		}
+		if document || !strings.EqualFold(c.DBType, sqlTypeFor(goType, adapter, driversJSON)) {
+			tags = append(tags, "type:"+c.DBType)
+		}
+		if c.NotNull && !pk {
+			tags = append(tags, "not null")
+		}
+		for _, u := range uniques[c.Name] {
+			tags = append(tags, lo.Ternary(len(u.Columns) == 1, "unique", "uniqueIndex:"+u.Name))
+		}
+		if len(pks[c.Name]) > 0 && !pk {
+			// composite keys are kept as a unique index
+			tags = append(tags, "uniqueIndex:"+pks[c.Name][0].Name)
+		}
+		if d := defaultOf(c, goType, adapter); d != "" {
+			tags = append(tags, "default:"+d)
+		}
+		if fk, ok := lo.Find(fks[c.Name], func(fk dbConstraint) bool { return len(fk.Columns) == 1 && fk.RefColumn != "" }); ok {
+			tags = append(tags, "fk:"+fk.RefTable+"."+fk.RefColumn+referentialAction("onDelete", fk.OnDelete)+referentialAction("onUpdate", fk.OnUpdate))
+		}
+
+		// nullable scalars are pointers, NULL blobs and documents are nil
+		pointer := !c.NotNull && !pk && !document && goType != "[]byte"
+		field := Field{GoName: goName, GoType: goType, Name: lo.SnakeCase(goName), IsNullable: pointer, IsPointer: pointer}
+		if document {
+			field.Document = "object"
+		}
+		tag := strings.Join(tags, ";")
+		parseDirectives(tag, &field)
+		meta.Fields = append(meta.Fields, field)
+		data.Fields = append(data.Fields, EntityField{GoName: goName, Type: lo.Ternary(pointer, "*", "") + goType, Tag: tag})
+		if goType == "time.Time" && !slices.Contains(data.Imports, "time") {
+			data.Imports = append(data.Imports, "time")
+		}
+	}
+	return meta, data
+}
+
+// goTypeOf returns the Go type of a column of dbType; JSON columns are
+// documents read into a map.
+func goTypeOf(dbType, adapter string) (string, bool) {
+	t := strings.ToLower(strings.TrimSpace(dbType))
+	base, _, _ := strings.Cut(t, "(")
+	base = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(base), "unsigned"))
+	switch {
+	case t == "tinyint(1)" || base == "bool" || base == "boolean":
+		return "bool", false
+	case base == "tinyint":
+		return "int8", false
+	case base == "smallint" || base == "int2":
+		return "int16", false
+	case base == "integer" && adapter == "sqlite":
+		// sqlite integers are 64-bit
+		return "int64", false
+	case base == "integer" || base == "int" || base == "int4" || base == "mediumint" || base == "serial":
+		return "int32", false
+	case base == "bigint" || base == "int8" || base == "bigserial":
+		return "int64", false
+	case base == "real" || base == "float4":
+		return "float32", false
+	case base == "double" || base == "double precision" || base == "float" || base == "float8" || base == "decimal" || base == "numeric":
+		return "float64", false
+	case base == "date" || base == "datetime" || strings.HasPrefix(base, "time"):
+		return "time.Time", false
+	case strings.HasSuffix(base, "blob") || base == "bytea" || strings.HasSuffix(base, "binary"):
+		return "[]byte", false
+	case isJSONType(base):
+		return "map[string]any", true
+	}
+	return "string", false
+}
+
+// defaultOf returns the value of the default directive of column c, empty
+// when there is none or it cannot be written in a struct tag. Current time
+// and uuid defaults use the now and uuid keywords.
+func defaultOf(c dbColumn, goType, adapter string) string {
+	d := strings.TrimSpace(c.Default)
+	if d == "" || c.Auto || strings.EqualFold(d, "NULL") {
+		return ""
+	}
+	if m := pgCast.FindStringSubmatch(d); m != nil {
+		d = m[1]
+	}
+	fn := strings.ToLower(strings.Trim(d, "()"))
+	switch {
+	case fn == "now" || strings.HasPrefix(fn, "current_timestamp"):
+		return "now"
+	case fn == "gen_random_uuid" || fn == "uuid":
+		return "uuid"
+	}
+	if adapter == "mysql" && goType == "string" && !strings.HasPrefix(d, "'") {
+		// information_schema reports mysql string defaults unquoted
+		d = "'" + strings.ReplaceAll(d, "'", "''") + "'"
+	}
+	if strings.ContainsAny(d, ";\"`") {
+		return ""
+	}
+	return d
+}
+
+// referentialAction returns the fk directive option of a referential
+// action, empty for the database default.
+func referentialAction(option, action string) string {
+	action = strings.ToUpper(strings.TrimSpace(action))
+	if action == "" || action == "NO ACTION" {
+		return ""
+	}
+	return "," + option + ":" + strings.ToLower(strings.ReplaceAll(action, " ", "_"))
+}
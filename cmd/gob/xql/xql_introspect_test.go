@@ -0,0 +1,133 @@
+package xql
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntrospect_Sqlite(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec(`
+CREATE TABLE accounts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email VARCHAR(100) NOT NULL UNIQUE,
+    balance DECIMAL(10,2) DEFAULT 0,
+    nick_name TEXT,
+    active BOOLEAN NOT NULL DEFAULT 1,
+    settings JSON,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE categories (
+    code TEXT PRIMARY KEY,
+    region TEXT NOT NULL,
+    name TEXT NOT NULL,
+    UNIQUE (region, name)
+);
+CREATE TABLE account_roles (
+    account_id INTEGER NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    role TEXT NOT NULL DEFAULT 'member',
+    PRIMARY KEY (account_id, role)
+);`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	tables, err := readTables(ctx, db, "sqlite", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"account_roles", "accounts", "categories"}, []string{tables[0].Name, tables[1].Name, tables[2].Name})
+	_, err = readTables(ctx, db, "sqlite", []string{"accounts", "missing"})
+	require.ErrorContains(t, err, "tables not found: missing")
+
+	w := NewMemoryWriter()
+	metas, err := writeEntities(w, tables, "sqlite", "/app/entity", "example.com/app/entity")
+	require.NoError(t, err)
+	require.Len(t, metas, 3)
+
+	require.Equal(t, `package entity
+
+import (
+	"time"
+)
+
+// Account maps the accounts table.
+type Account struct {
+	ID        int64    `+"`"+`xql:"pk;auto"`+"`"+`
+	Email     string   `+"`"+`xql:"type:VARCHAR(100);not null;unique"`+"`"+`
+	Balance   *float64 `+"`"+`xql:"type:DECIMAL(10,2);default:0"`+"`"+`
+	NickName  *string
+	Active    bool           `+"`"+`xql:"type:BOOLEAN;not null;default:1"`+"`"+`
+	Settings  map[string]any `+"`"+`xql:"type:JSON"`+"`"+`
+	CreatedAt *time.Time     `+"`"+`xql:"default:now"`+"`"+`
+}
+
+func (a Account) Table() string { return "accounts" }
+`, string(w.Files[filepath.Join("/app/entity", "account.go")]))
+
+	account := metas[1]
+	require.Equal(t, "Account", account.StructName)
+	require.Equal(t, "example.com/app/entity", account.PkgPath)
+	require.Equal(t, Field{GoName: "ID", GoType: "int64", Name: "id", IsPK: true, IsAuto: true}, account.Fields[0])
+	require.Equal(t, Field{GoName: "Balance", GoType: "float64", Name: "balance", DBType: "DECIMAL(10,2)", Default: "0", IsNullable: true, IsPointer: true}, account.Fields[2])
+	require.Equal(t, "object", account.Fields[5].Document)
+
+	roles := string(w.Files[filepath.Join("/app/entity", "account_role.go")])
+	require.Contains(t, roles, `AccountID int64  `+"`"+`xql:"not null;uniqueIndex:account_roles_pkey;fk:accounts.id,onDelete:cascade"`+"`")
+	require.Contains(t, roles, `Role      string `+"`"+`xql:"not null;uniqueIndex:account_roles_pkey;default:'member'"`+"`")
+	require.Equal(t, "CASCADE", metas[0].Fields[0].FKOnDelete)
+	require.Equal(t, []string{"account_roles_pkey"}, metas[0].Fields[1].UniqueGroups)
+
+	categories := string(w.Files[filepath.Join("/app/entity", "category.go")])
+	require.Contains(t, categories, "type Category struct {")
+	require.Contains(t, categories, `Code   string `+"`"+`xql:"pk"`+"`")
+	require.Contains(t, categories, `Region string `+"`"+`xql:"not null;uniqueIndex:uk_categories_region_name"`+"`")
+}
+
+func TestIntrospect_Names(t *testing.T) {
+	require.Equal(t, "Account", entityName("accounts"))
+	require.Equal(t, "Category", entityName("categories"))
+	require.Equal(t, "Address", entityName("addresses"))
+	require.Equal(t, "Status", entityName("status"))
+	require.Equal(t, "OrderItem", entityName("order_items"))
+	require.Equal(t, "order_item.go", entityFileName("order_items"))
+	require.Equal(t, "AccountID", fieldName("account_id"))
+	require.Equal(t, "TableName", fieldName("table"))
+}
+
+func TestIntrospect_Types(t *testing.T) {
+	tests := []struct {
+		dbType, adapter, goType string
+		document                bool
+	}{
+		{"integer", "sqlite", "int64", false},
+		{"integer", "postgres", "int32", false},
+		{"bigint(20) unsigned", "mysql", "int64", false},
+		{"tinyint(1)", "mysql", "bool", false},
+		{"tinyint(4)", "mysql", "int8", false},
+		{"double precision", "postgres", "float64", false},
+		{"decimal(10,2)", "mysql", "float64", false},
+		{"timestamp with time zone", "postgres", "time.Time", false},
+		{"bytea", "postgres", "[]byte", false},
+		{"jsonb", "postgres", "map[string]any", true},
+		{"varchar(20)", "mysql", "string", false},
+		{"uuid", "postgres", "string", false},
+	}
+	for _, tt := range tests {
+		goType, document := goTypeOf(tt.dbType, tt.adapter)
+		require.Equal(t, tt.goType, goType, tt.dbType)
+		require.Equal(t, tt.document, document, tt.dbType)
+	}
+
+	require.Equal(t, "'draft'", defaultOf(dbColumn{Default: "'draft'::character varying"}, "string", "postgres"))
+	require.Equal(t, "'draft'", defaultOf(dbColumn{Default: "draft"}, "string", "mysql"))
+	require.Equal(t, "now", defaultOf(dbColumn{Default: "CURRENT_TIMESTAMP(6)"}, "time.Time", "mysql"))
+	require.Equal(t, "uuid", defaultOf(dbColumn{Default: "gen_random_uuid()"}, "string", "postgres"))
+	require.Empty(t, defaultOf(dbColumn{Default: "nextval('accounts_id_seq'::regclass)", Auto: true}, "int64", "postgres"))
+	require.Equal(t, ",onDelete:set_null", referentialAction("onDelete", "SET NULL"))
+	require.Empty(t, referentialAction("onUpdate", "NO ACTION"))
+}
@@ -0,0 +1,473 @@
+package xql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kcmvp/xql/cmd/internal"
+	"github.com/samber/lo"
+)
+
+// snapshotFile is the name of the per-adapter schema snapshot kept next to
+// the migration files. It records the schema the last migration brought the
+// database to, and is the baseline the next migration is diffed against.
+const snapshotFile = "snapshot.json"
+
+// TableSnapshot is the persisted shape of one entity's table for an adapter.
+type TableSnapshot struct {
+	Table   string           `json:"table"`
+	Version string           `json:"version"`
+	View    string           `json:"view,omitempty"` // the query of a read model, whose Table is a view
+	Comment string           `json:"comment,omitempty"`
+	Columns []ColumnSnapshot `json:"columns"`
+	Indexes []Index          `json:"indexes,omitempty"`
+}
+
+// ColumnSnapshot is the persisted shape of one column, with the DB type
+// already resolved for the adapter.
+type ColumnSnapshot struct {
+	Name        string   `json:"name"`
+	DBType      string   `json:"dbType"`
+	IsPK        bool     `json:"isPK,omitempty"`
+	IsAuto      bool     `json:"isAuto,omitempty"`
+	IsNotNull   bool     `json:"isNotNull,omitempty"`
+	IsUnique    bool     `json:"isUnique,omitempty"`
+	IsIndexed   bool     `json:"isIndexed,omitempty"`
+	IndexMethod string   `json:"indexMethod,omitempty"`
+	IndexOrder  string   `json:"indexOrder,omitempty"`
+	SoftDelete  bool     `json:"softDelete,omitempty"`
+	References  string   `json:"references,omitempty"` // the target and actions of the foreign key, as rendered after REFERENCES
+	Comment     string   `json:"comment,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+// Snapshot maps entity struct names to their table snapshot.
+type Snapshot map[string]TableSnapshot
+
+// snapshotOf builds the snapshot of meta for the adapter.
+func snapshotOf(meta EntityMeta, adapter string) TableSnapshot {
+	fields := enrichFieldsForAdapter(meta.Fields, adapter)
+	return TableSnapshot{
+		Table:   meta.TableName,
+		Version: computeEntityVersion(meta),
+		View:    meta.ViewQuery,
+		Comment: meta.Comment,
+		Columns: lo.Map(fields, func(f Field, _ int) ColumnSnapshot {
+			return ColumnSnapshot{
+				Name:        f.Name,
+				DBType:      f.DBType,
+				IsPK:        f.IsPK,
+				IsAuto:      f.PKClause != "",
+				IsNotNull:   f.IsNotNull,
+				IsUnique:    f.IsUnique,
+				IsIndexed:   f.IsIndexed,
+				IndexMethod: f.IndexMethod,
+				IndexOrder:  f.IndexOrder,
+				SoftDelete:  f.IsSoftDelete,
+				References:  references(f),
+				Comment:     f.Comment,
+				Enum:        f.EnumValues,
+				Default:     f.Default,
+			}
+		}),
+		Indexes: meta.Indexes,
+	}
+}
+
+// sameSnapshot reports whether old and cur persist identically. The entity
+// version alone is not enough: it hashes the entity source, while the column
+// types also depend on the typeMapping of xql.yaml.
+func sameSnapshot(old, cur TableSnapshot) bool {
+	a, errA := json.Marshal(old)
+	b, errB := json.Marshal(cur)
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// migrate diffs every entity against the snapshot of each adapter and writes
+// a versioned migration file per adapter under {gen}/migrations/{adapter},
+// then updates the snapshot. Adapters without changes get no file.
+func migrate(ctx context.Context) error {
+	return migrateWithWriter(ctx, DiskWriter{}, os.ReadFile)
+}
+
+// migrateWithWriter is migrate with pluggable file access for tests.
+func migrateWithWriter(ctx context.Context, w OutputWriter, read func(string) ([]byte, error)) error {
+	project := internal.Current
+	if project == nil {
+		return fmt.Errorf("project context not initialized")
+	}
+	adapters, ok := ctx.Value(dbaAdapterKey).([]string)
+	if !ok || len(adapters) == 0 {
+		return fmt.Errorf("no database adapters are configured or detected")
+	}
+	metas, err := generateMeta(ctx)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
+	if err != nil {
+		return fmt.Errorf("failed to parse schema template: %w", err)
+	}
+	// views select from tables, so they are created after them
+	sort.SliceStable(metas, func(i, j int) bool { return metas[i].ViewQuery == "" && metas[j].ViewQuery != "" })
+	// tables of entities left out by a filter are kept, not dropped
+	partial := ctx.Value(entityFilterKey) != nil
+	now := time.Now()
+
+	for _, adapter := range adapters {
+		dir := filepath.Join(project.GenPath(), "migrations", adapter)
+		prev := Snapshot{}
+		if data, err := read(filepath.Join(dir, snapshotFile)); err == nil {
+			if err := json.Unmarshal(data, &prev); err != nil {
+				return fmt.Errorf("failed to parse snapshot for %s: %w", adapter, err)
+			}
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read snapshot for %s: %w", adapter, err)
+		}
+
+		next := Snapshot{}
+		if partial {
+			for k, v := range prev {
+				next[k] = v
+			}
+		}
+		var body bytes.Buffer
+		for _, meta := range metas {
+			cur := snapshotOf(meta, adapter)
+			next[meta.StructName] = cur
+			old, exists := prev[meta.StructName]
+			if exists && sameSnapshot(old, cur) {
+				continue
+			}
+			var stmts []string
+			if exists && old.View != "" {
+				// views hold no data: they are recreated
+				stmts = append(stmts, fmt.Sprintf("DROP VIEW IF EXISTS %s;", old.Table))
+			}
+			if cur.View != "" {
+				if exists && old.View == "" {
+					stmts = append(stmts, fmt.Sprintf("-- %s became a view: drop table %s by hand", cur.Table, old.Table))
+				}
+				stmts = append(stmts, createView(adapter, cur.Table, cur.View))
+			} else if exists && old.View == "" {
+				stmts = diffTable(old, cur, adapter)
+			} else {
+				var sb bytes.Buffer
+				data := SchemaTemplateData{Adapter: adapter, TableName: meta.TableName, Comment: meta.Comment, Fields: enrichFieldsForAdapter(meta.Fields, adapter), Indexes: meta.Indexes}
+				if err := tmpl.ExecuteTemplate(&sb, "table", data); err != nil {
+					return fmt.Errorf("failed to execute schema template for %s: %w", meta.StructName, err)
+				}
+				stmts = append(stmts, strings.TrimSpace(sb.String()))
+			}
+			if len(stmts) == 0 {
+				continue
+			}
+			fmt.Fprintf(&body, "\n-- %s (ver: %s -> %s)\n", cur.Table, lo.Ternary(exists, old.Version, "none"), cur.Version)
+			body.WriteString(strings.Join(stmts, "\n"))
+			body.WriteString("\n")
+		}
+		if !partial {
+			dropped := lo.Filter(lo.Keys(prev), func(name string, _ int) bool {
+				_, ok := next[name]
+				return !ok
+			})
+			sort.Strings(dropped)
+			for _, name := range dropped {
+				fmt.Fprintf(&body, "\n-- %s (ver: %s -> none)\nDROP %s IF EXISTS %s;\n", prev[name].Table, prev[name].Version, lo.Ternary(prev[name].View != "", "VIEW", "TABLE"), prev[name].Table)
+			}
+		}
+		if body.Len() == 0 {
+			continue
+		}
+
+		if err := w.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory %s: %w", dir, err)
+		}
+		version := now.UTC().Format("20060102150405")
+		header := fmt.Sprintf("-- Code generated by gob xql migrate. DO NOT EDIT.\n-- Generated at: %s (ver: %s)\n", now.Format("2006-01-02 15:04:05"), version)
+		outputPath := filepath.Join(dir, fmt.Sprintf("%s_migration.sql", version))
+		if err := w.WriteFile(outputPath, append([]byte(header), body.Bytes()...), 0644); err != nil {
+			return fmt.Errorf("failed to write migration for %s: %w", adapter, err)
+		}
+		snapshot, err := json.MarshalIndent(next, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode snapshot for %s: %w", adapter, err)
+		}
+		if err := w.WriteFile(filepath.Join(dir, snapshotFile), snapshot, 0644); err != nil {
+			return fmt.Errorf("failed to write snapshot for %s: %w", adapter, err)
+		}
+	}
+	return nil
+}
+
+// diffTable returns the statements migrating table old to cur for the
+// adapter, in the order: rename table, drop indexes and foreign keys, drop
+// columns, add columns, modify columns, create indexes and foreign keys. A
+// renamed column reads as a drop plus an add. Changes the adapter cannot
+// apply in place (primary keys, column and foreign key changes on sqlite,
+// defaults on mssql, dropping an inline UNIQUE) are emitted as
+// comments to be handled by hand.
+func diffTable(old, cur TableSnapshot, adapter string) []string {
+	var stmts []string
+	table := cur.Table
+	if old.Table != cur.Table {
+		stmts = append(stmts, renameTable(adapter, old.Table, cur.Table))
+		if nativeEnum(adapter) {
+			// enum types are named after their table
+			for _, o := range old.Columns {
+				if len(o.Enum) > 0 {
+					stmts = append(stmts, fmt.Sprintf("ALTER TYPE %s RENAME TO %s;", enumType(old.Table, o.Name), enumType(cur.Table, o.Name)))
+				}
+			}
+		}
+	}
+	var dropped, adds, modifies, creates []string
+	if old.Comment != cur.Comment {
+		modifies = append(modifies, commentOn(adapter, "TABLE", table, cur.Comment)...)
+	}
+	oldCols := lo.KeyBy(old.Columns, func(c ColumnSnapshot) string { return c.Name })
+	curCols := lo.KeyBy(cur.Columns, func(c ColumnSnapshot) string { return c.Name })
+
+	for _, o := range old.Columns {
+		c, ok := curCols[o.Name]
+		if o.IsIndexed && (!ok || !c.IsIndexed || indexChanged(o, c)) {
+			// indexes keep the name they were created with
+			stmts = append(stmts, dropIndex(adapter, table, fmt.Sprintf("idx_%s_%s", old.Table, o.Name)))
+		}
+		if o.References != "" && (!ok || c.References != o.References) {
+			// constraints keep the name they were created with
+			stmts = append(stmts, dropForeignKey(adapter, table, fmt.Sprintf("fk_%s_%s", old.Table, o.Name)))
+		}
+		if !ok {
+			dropped = append(dropped, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table, o.Name))
+		}
+	}
+	oldIdx := lo.KeyBy(old.Indexes, func(i Index) string { return i.Name })
+	curIdx := lo.KeyBy(cur.Indexes, func(i Index) string { return i.Name })
+	for _, o := range old.Indexes {
+		if c, ok := curIdx[o.Name]; !ok || !sameIndex(o, c) {
+			stmts = append(stmts, dropIndex(adapter, table, o.Name))
+		}
+	}
+	stmts = append(stmts, dropped...)
+	for _, c := range cur.Columns {
+		o, ok := oldCols[c.Name]
+		if !ok {
+			if len(c.Enum) > 0 && nativeEnum(adapter) {
+				adds = append(adds, createEnum(table, c.Name, c.Enum))
+			}
+			adds = append(adds, addColumn(adapter, table, columnDef(adapter, table, c)))
+			o = ColumnSnapshot{Name: c.Name, DBType: c.DBType, IsNotNull: c.IsNotNull, Default: c.Default, Comment: lo.Ternary(comments(adapter) == "inline", c.Comment, "")}
+		} else {
+			modifies = append(modifies, modifyColumn(adapter, table, o, c)...)
+			modifies = append(modifies, modifyEnum(adapter, table, o, c)...)
+		}
+		if o.Comment != c.Comment && comments(adapter) == "statement" {
+			modifies = append(modifies, commentOn(adapter, "COLUMN", table+"."+c.Name, c.Comment)...)
+		}
+		if o.IsPK != c.IsPK || o.IsAuto != c.IsAuto {
+			modifies = append(modifies, fmt.Sprintf("-- primary key of %s changed on %s: migrate it by hand", table, c.Name))
+		}
+		if c.IsUnique && !o.IsUnique {
+			creates = append(creates, createIndex(adapter, table, Index{Name: fmt.Sprintf("uk_%s_%s", table, c.Name), Unique: true, Columns: []string{c.Name}}))
+		} else if o.IsUnique && !c.IsUnique {
+			modifies = append(modifies, fmt.Sprintf("-- unique constraint on %s.%s removed: drop it by hand", table, c.Name))
+		}
+		if c.References != "" && c.References != o.References {
+			creates = append(creates, addForeignKey(adapter, table, c))
+		}
+		if c.IsIndexed && (!o.IsIndexed || indexChanged(o, c)) {
+			creates = append(creates, createIndex(adapter, table, fieldIndex(table, Field{Name: c.Name, IndexMethod: c.IndexMethod, IndexOrder: c.IndexOrder, IsSoftDelete: c.SoftDelete})))
+		}
+	}
+	for _, c := range cur.Indexes {
+		if o, ok := oldIdx[c.Name]; !ok || !sameIndex(o, c) {
+			creates = append(creates, createIndex(adapter, table, c))
+		}
+	}
+	return append(append(append(stmts, adds...), modifies...), creates...)
+}
+
+// indexChanged reports whether the single-column index of a column must be
+// recreated between its old and current snapshot.
+func indexChanged(o, c ColumnSnapshot) bool {
+	return o.IndexMethod != c.IndexMethod || o.IndexOrder != c.IndexOrder || o.SoftDelete != c.SoftDelete
+}
+
+// sameIndex reports whether a and b define the same index.
+func sameIndex(a, b Index) bool {
+	return a.Unique == b.Unique && a.Where == b.Where && slices.Equal(a.Columns, b.Columns)
+}
+
+// renameTable returns the statement renaming table old to cur.
+func renameTable(adapter, old, cur string) string {
+	if adapter == "mssql" {
+		return fmt.Sprintf("EXEC sp_rename '%s', '%s';", old, cur)
+	}
+	return fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", old, cur)
+}
+
+// addColumn returns the statement adding the column defined by def.
+func addColumn(adapter, table, def string) string {
+	switch adapter {
+	case "mssql":
+		return fmt.Sprintf("ALTER TABLE %s ADD %s;", table, def)
+	case "oracle":
+		return fmt.Sprintf("ALTER TABLE %s ADD (%s);", table, def)
+	default:
+		return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, def)
+	}
+}
+
+// columnDef renders a column for ADD COLUMN. Key and unique constraints are
+// left out, sqlite rejects them there; uniqueness is added as an index. The
+// default comes first, oracle takes it before the constraints.
+func columnDef(adapter, table string, c ColumnSnapshot) string {
+	def := c.Name + " " + c.DBType
+	if len(c.Enum) > 0 && nativeEnum(adapter) {
+		def = c.Name + " " + enumType(table, c.Name)
+	}
+	if c.Default != "" {
+		def += " DEFAULT " + c.Default
+	}
+	if c.IsNotNull {
+		def += " NOT NULL"
+	}
+	if c.Comment != "" && comments(adapter) == "inline" {
+		def += " COMMENT " + sqlString(c.Comment)
+	}
+	if len(c.Enum) > 0 && !nativeEnum(adapter) {
+		def += fmt.Sprintf(" CHECK (%s IN (%s))", c.Name, sqlList(c.Enum))
+	}
+	return def
+}
+
+// modifyEnum returns the statements changing the values of an enum column.
+// Only adding values to an existing enum type can be done in place.
+func modifyEnum(adapter, table string, o, c ColumnSnapshot) []string {
+	if slices.Equal(o.Enum, c.Enum) {
+		return nil
+	}
+	added, removed := lo.Difference(c.Enum, o.Enum)
+	if !nativeEnum(adapter) || len(o.Enum) == 0 || len(c.Enum) == 0 || len(removed) > 0 {
+		return []string{fmt.Sprintf("-- enum values of %s.%s changed to (%s): migrate them by hand", table, c.Name, sqlList(c.Enum))}
+	}
+	return lo.Map(added, func(v string, _ int) string {
+		return fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS %s;", enumType(table, c.Name), sqlList([]string{v}))
+	})
+}
+
+// modifyColumn returns the statements changing the type, nullability or
+// default of column o to c.
+func modifyColumn(adapter, table string, o, c ColumnSnapshot) []string {
+	typeChanged := !strings.EqualFold(o.DBType, c.DBType)
+	// inline comments are part of the column definition
+	commentChanged := o.Comment != c.Comment && comments(adapter) == "inline"
+	if !typeChanged && o.IsNotNull == c.IsNotNull && o.Default == c.Default && !commentChanged {
+		return nil
+	}
+	switch adapter {
+	case "postgres":
+		var stmts []string
+		alter := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", table, c.Name)
+		if typeChanged {
+			stmts = append(stmts, fmt.Sprintf("%s TYPE %s;", alter, c.DBType))
+		}
+		if o.IsNotNull != c.IsNotNull {
+			stmts = append(stmts, fmt.Sprintf("%s %s NOT NULL;", alter, lo.Ternary(c.IsNotNull, "SET", "DROP")))
+		}
+		if o.Default != c.Default {
+			stmts = append(stmts, lo.Ternary(c.Default == "", alter+" DROP DEFAULT;", fmt.Sprintf("%s SET DEFAULT %s;", alter, c.Default)))
+		}
+		return stmts
+	case "mysql":
+		// the CHECK of an enum is left to modifyEnum
+		c.Enum = nil
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", table, columnDef(adapter, table, c))}
+	case "mssql":
+		// defaults are named constraints of their own
+		var stmts []string
+		if typeChanged || o.IsNotNull != c.IsNotNull {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s;", table, c.Name, c.DBType, lo.Ternary(c.IsNotNull, "NOT NULL", "NULL")))
+		}
+		if o.Default != c.Default {
+			stmts = append(stmts, fmt.Sprintf("-- default of %s.%s changed to %s: replace its constraint by hand", table, c.Name, lo.Ternary(c.Default == "", "none", c.Default)))
+		}
+		return stmts
+	case "oracle":
+		// restating an unchanged nullability is an error
+		def := c.Name
+		if typeChanged {
+			def += " " + c.DBType
+		}
+		if o.Default != c.Default {
+			def += " DEFAULT " + lo.Ternary(c.Default == "", "NULL", c.Default)
+		}
+		if o.IsNotNull != c.IsNotNull {
+			def += lo.Ternary(c.IsNotNull, " NOT NULL", " NULL")
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY (%s);", table, def)}
+	default:
+		return []string{fmt.Sprintf("-- %s cannot alter column %s.%s to %s: rebuild the table", adapter, table, c.Name, columnDef(adapter, table, c))}
+	}
+}
+
+// commentOn returns the statement setting the comment of a table or column,
+// kind being "TABLE" or "COLUMN"; an empty comment removes it. Adapters
+// without comments get none.
+func commentOn(adapter, kind, name, comment string) []string {
+	switch comments(adapter) {
+	case "statement":
+		return []string{fmt.Sprintf("COMMENT ON %s %s IS %s;", kind, name, lo.Ternary(comment == "", "NULL", sqlString(comment)))}
+	case "inline":
+		if kind == "TABLE" {
+			return []string{fmt.Sprintf("ALTER TABLE %s COMMENT = %s;", name, sqlString(comment))}
+		}
+	}
+	return nil
+}
+
+// dropForeignKey returns the statements dropping a foreign key constraint.
+// sqlite cannot alter the constraints of a table, which is left to a rebuild.
+func dropForeignKey(adapter, table, name string) string {
+	switch adapter {
+	case "postgres", "mssql":
+		return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", table, name)
+	case "oracle":
+		return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", table, name)
+	case "mysql":
+		return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;", table, name)
+	default:
+		return fmt.Sprintf("-- %s cannot drop foreign key %s of %s: rebuild the table", adapter, name, table)
+	}
+}
+
+// addForeignKey returns the statement adding the foreign key of column c.
+func addForeignKey(adapter, table string, c ColumnSnapshot) string {
+	if adapter != "sqlite" {
+		return fmt.Sprintf("ALTER TABLE %s ADD %s;", table, foreignKey(table, c.Name, c.References))
+	}
+	return fmt.Sprintf("-- %s cannot add foreign key on %s.%s referencing %s: rebuild the table", adapter, table, c.Name, c.References)
+}
+
+// dropIndex returns the adapter's DROP INDEX statement.
+func dropIndex(adapter, table, index string) string {
+	switch adapter {
+	case "mysql":
+		return fmt.Sprintf("DROP INDEX %s ON %s;", index, table)
+	case "mssql":
+		return fmt.Sprintf("DROP INDEX IF EXISTS %s ON %s;", index, table)
+	}
+	return fmt.Sprintf("DROP INDEX IF EXISTS %s;", index)
+}
@@ -0,0 +1,277 @@
+package xql
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTable(t *testing.T) {
+	old := TableSnapshot{
+		Table:   "accounts",
+		Version: "v1",
+		Columns: []ColumnSnapshot{
+			{Name: "id", DBType: "BIGINT", IsPK: true},
+			{Name: "email", DBType: "TEXT", IsIndexed: true},
+			{Name: "nick_name", DBType: "varchar(100)", IsNotNull: true, Default: "'anonymous'"},
+			{Name: "legacy", DBType: "TEXT", IsIndexed: true},
+		},
+	}
+	cur := TableSnapshot{
+		Table:   "accounts",
+		Version: "v2",
+		Columns: []ColumnSnapshot{
+			{Name: "id", DBType: "BIGINT", IsPK: true},
+			{Name: "email", DBType: "TEXT", IsUnique: true},
+			{Name: "nick_name", DBType: "varchar(200)", Default: "'guest'"},
+			{Name: "region", DBType: "TEXT", IsNotNull: true, Default: "'eu'", IsIndexed: true},
+		},
+	}
+	tests := []struct {
+		adapter string
+		want    []string
+	}{
+		{
+			adapter: "postgres",
+			want: []string{
+				"DROP INDEX IF EXISTS idx_accounts_email;",
+				"DROP INDEX IF EXISTS idx_accounts_legacy;",
+				"ALTER TABLE accounts DROP COLUMN legacy;",
+				"ALTER TABLE accounts ADD COLUMN region TEXT DEFAULT 'eu' NOT NULL;",
+				"ALTER TABLE accounts ALTER COLUMN nick_name TYPE varchar(200);",
+				"ALTER TABLE accounts ALTER COLUMN nick_name DROP NOT NULL;",
+				"ALTER TABLE accounts ALTER COLUMN nick_name SET DEFAULT 'guest';",
+				"CREATE UNIQUE INDEX IF NOT EXISTS uk_accounts_email ON accounts (email);",
+				"CREATE INDEX IF NOT EXISTS idx_accounts_region ON accounts (region);",
+			},
+		},
+		{
+			adapter: "mysql",
+			want: []string{
+				"DROP INDEX idx_accounts_email ON accounts;",
+				"DROP INDEX idx_accounts_legacy ON accounts;",
+				"ALTER TABLE accounts DROP COLUMN legacy;",
+				"ALTER TABLE accounts ADD COLUMN region TEXT DEFAULT 'eu' NOT NULL;",
+				"ALTER TABLE accounts MODIFY COLUMN nick_name varchar(200) DEFAULT 'guest';",
+				"CREATE UNIQUE INDEX IF NOT EXISTS uk_accounts_email ON accounts (email);",
+				"CREATE INDEX IF NOT EXISTS idx_accounts_region ON accounts (region);",
+			},
+		},
+		{
+			adapter: "sqlite",
+			want: []string{
+				"DROP INDEX IF EXISTS idx_accounts_email;",
+				"DROP INDEX IF EXISTS idx_accounts_legacy;",
+				"ALTER TABLE accounts DROP COLUMN legacy;",
+				"ALTER TABLE accounts ADD COLUMN region TEXT DEFAULT 'eu' NOT NULL;",
+				"-- sqlite cannot alter column accounts.nick_name to nick_name varchar(200) DEFAULT 'guest': rebuild the table",
+				"CREATE UNIQUE INDEX IF NOT EXISTS uk_accounts_email ON accounts (email);",
+				"CREATE INDEX IF NOT EXISTS idx_accounts_region ON accounts (region);",
+			},
+		},
+		{
+			adapter: "mssql",
+			want: []string{
+				"DROP INDEX IF EXISTS idx_accounts_email ON accounts;",
+				"DROP INDEX IF EXISTS idx_accounts_legacy ON accounts;",
+				"ALTER TABLE accounts DROP COLUMN legacy;",
+				"ALTER TABLE accounts ADD region TEXT DEFAULT 'eu' NOT NULL;",
+				"ALTER TABLE accounts ALTER COLUMN nick_name varchar(200) NULL;",
+				"-- default of accounts.nick_name changed to 'guest': replace its constraint by hand",
+				"IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'uk_accounts_email' AND object_id = OBJECT_ID(N'accounts'))\nCREATE UNIQUE INDEX uk_accounts_email ON accounts (email);",
+				"IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'idx_accounts_region' AND object_id = OBJECT_ID(N'accounts'))\nCREATE INDEX idx_accounts_region ON accounts (region);",
+			},
+		},
+		{
+			adapter: "oracle",
+			want: []string{
+				"DROP INDEX IF EXISTS idx_accounts_email;",
+				"DROP INDEX IF EXISTS idx_accounts_legacy;",
+				"ALTER TABLE accounts DROP COLUMN legacy;",
+				"ALTER TABLE accounts ADD (region TEXT DEFAULT 'eu' NOT NULL);",
+				"ALTER TABLE accounts MODIFY (nick_name varchar(200) DEFAULT 'guest' NULL);",
+				"CREATE UNIQUE INDEX IF NOT EXISTS uk_accounts_email ON accounts (email);",
+				"CREATE INDEX IF NOT EXISTS idx_accounts_region ON accounts (region);",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.adapter, func(t *testing.T) {
+			require.Equal(t, tt.want, diffTable(old, cur, tt.adapter))
+		})
+	}
+}
+
+func TestDiffTable_Rename(t *testing.T) {
+	old := TableSnapshot{Table: "account", Columns: []ColumnSnapshot{
+		{Name: "id", DBType: "BIGINT", IsPK: true},
+		{Name: "email", DBType: "TEXT", IsIndexed: true, IsUnique: true},
+	}}
+	cur := TableSnapshot{Table: "accounts", Columns: []ColumnSnapshot{
+		{Name: "id", DBType: "BIGINT"},
+		{Name: "email", DBType: "TEXT"},
+	}}
+	require.Equal(t, []string{
+		"ALTER TABLE account RENAME TO accounts;",
+		"DROP INDEX IF EXISTS idx_account_email;",
+		"-- primary key of accounts changed on id: migrate it by hand",
+		"-- unique constraint on accounts.email removed: drop it by hand",
+	}, diffTable(old, cur, "postgres"))
+}
+
+func TestDiffTable_Indexes(t *testing.T) {
+	cols := []ColumnSnapshot{{Name: "email", DBType: "TEXT"}, {Name: "region", DBType: "TEXT"}, {Name: "nick", DBType: "TEXT"}}
+	old := TableSnapshot{Table: "accounts", Columns: cols, Indexes: []Index{
+		{Name: "acct_email_region", Unique: true, Columns: []string{"email", "region"}},
+		{Name: "acct_nick", Columns: []string{"nick"}},
+	}}
+	cur := TableSnapshot{Table: "accounts", Columns: cols, Indexes: []Index{
+		{Name: "acct_email_region", Unique: true, Columns: []string{"region", "email"}},
+		{Name: "acct_region_nick", Columns: []string{"region", "nick"}},
+	}}
+	require.Equal(t, []string{
+		"DROP INDEX acct_email_region ON accounts;",
+		"DROP INDEX acct_nick ON accounts;",
+		"CREATE UNIQUE INDEX IF NOT EXISTS acct_email_region ON accounts (region, email);",
+		"CREATE INDEX IF NOT EXISTS acct_region_nick ON accounts (region, nick);",
+	}, diffTable(old, cur, "mysql"))
+}
+
+func TestDiffTable_Enum(t *testing.T) {
+	old := TableSnapshot{Table: "tasks", Columns: []ColumnSnapshot{
+		{Name: "status", DBType: "TEXT", Enum: []string{"active", "inactive"}},
+	}}
+	cur := TableSnapshot{Table: "tasks", Columns: []ColumnSnapshot{
+		{Name: "status", DBType: "TEXT", Enum: []string{"active", "inactive", "pending"}},
+		{Name: "level", DBType: "TEXT", Enum: []string{"low", "high"}},
+	}}
+	require.Equal(t, []string{
+		"DO $$ BEGIN CREATE TYPE tasks_level AS ENUM ('low', 'high'); EXCEPTION WHEN duplicate_object THEN NULL; END $$;",
+		"ALTER TABLE tasks ADD COLUMN level tasks_level;",
+		"ALTER TYPE tasks_status ADD VALUE IF NOT EXISTS 'pending';",
+	}, diffTable(old, cur, "postgres"))
+	require.Equal(t, []string{
+		"ALTER TABLE tasks ADD COLUMN level TEXT CHECK (level IN ('low', 'high'));",
+		"-- enum values of tasks.status changed to ('active', 'inactive', 'pending'): migrate them by hand",
+	}, diffTable(old, cur, "sqlite"))
+}
+
+func TestDiffTable_SoftDelete(t *testing.T) {
+	old := TableSnapshot{Table: "posts", Columns: []ColumnSnapshot{
+		{Name: "deleted_at", DBType: "TEXT", IsIndexed: true},
+	}}
+	cur := TableSnapshot{Table: "posts", Columns: []ColumnSnapshot{
+		{Name: "deleted_at", DBType: "TEXT", IsIndexed: true, SoftDelete: true},
+	}}
+	require.Equal(t, []string{
+		"DROP INDEX IF EXISTS idx_posts_deleted_at;",
+		"CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON posts (deleted_at) WHERE deleted_at IS NULL;",
+	}, diffTable(old, cur, "sqlite"))
+	require.Empty(t, diffTable(cur, cur, "sqlite"))
+}
+
+func TestDiffTable_ForeignKey(t *testing.T) {
+	old := TableSnapshot{Table: "orders", Columns: []ColumnSnapshot{
+		{Name: "account_id", DBType: "BIGINT", References: "accounts (id)"},
+		{Name: "parent_id", DBType: "BIGINT", References: "orders (id)"},
+	}}
+	cur := TableSnapshot{Table: "orders", Columns: []ColumnSnapshot{
+		{Name: "account_id", DBType: "BIGINT", References: "accounts (id) ON DELETE CASCADE"},
+		{Name: "user_id", DBType: "BIGINT", References: "users (id)"},
+	}}
+	require.Equal(t, []string{
+		"ALTER TABLE orders DROP FOREIGN KEY fk_orders_account_id;",
+		"ALTER TABLE orders DROP FOREIGN KEY fk_orders_parent_id;",
+		"ALTER TABLE orders DROP COLUMN parent_id;",
+		"ALTER TABLE orders ADD COLUMN user_id BIGINT;",
+		"ALTER TABLE orders ADD CONSTRAINT fk_orders_account_id FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE;",
+		"ALTER TABLE orders ADD CONSTRAINT fk_orders_user_id FOREIGN KEY (user_id) REFERENCES users (id);",
+	}, diffTable(old, cur, "mysql"))
+	require.Equal(t, []string{
+		"-- sqlite cannot drop foreign key fk_orders_account_id of orders: rebuild the table",
+		"-- sqlite cannot drop foreign key fk_orders_parent_id of orders: rebuild the table",
+		"ALTER TABLE orders DROP COLUMN parent_id;",
+		"ALTER TABLE orders ADD COLUMN user_id BIGINT;",
+		"-- sqlite cannot add foreign key on orders.account_id referencing accounts (id) ON DELETE CASCADE: rebuild the table",
+		"-- sqlite cannot add foreign key on orders.user_id referencing users (id): rebuild the table",
+	}, diffTable(old, cur, "sqlite"))
+	require.Empty(t, diffTable(cur, cur, "postgres"))
+}
+
+func TestDiffTable_Comment(t *testing.T) {
+	old := TableSnapshot{Table: "accounts", Comment: "Accounts.", Columns: []ColumnSnapshot{
+		{Name: "email", DBType: "TEXT", Comment: "Mail."},
+		{Name: "nick", DBType: "TEXT", Comment: "Nickname."},
+	}}
+	cur := TableSnapshot{Table: "accounts", Columns: []ColumnSnapshot{
+		{Name: "email", DBType: "TEXT", Comment: "Receipt address."},
+		{Name: "nick", DBType: "TEXT", Comment: "Nickname."},
+		{Name: "region", DBType: "TEXT", Comment: "Billing region."},
+	}}
+	require.Equal(t, []string{
+		"ALTER TABLE accounts ADD COLUMN region TEXT;",
+		"COMMENT ON TABLE accounts IS NULL;",
+		"COMMENT ON COLUMN accounts.email IS 'Receipt address.';",
+		"COMMENT ON COLUMN accounts.region IS 'Billing region.';",
+	}, diffTable(old, cur, "postgres"))
+	require.Equal(t, []string{
+		"ALTER TABLE accounts ADD COLUMN region TEXT COMMENT 'Billing region.';",
+		"ALTER TABLE accounts COMMENT = '';",
+		"ALTER TABLE accounts MODIFY COLUMN email TEXT COMMENT 'Receipt address.';",
+	}, diffTable(old, cur, "mysql"))
+	require.Equal(t, []string{"ALTER TABLE accounts ADD COLUMN region TEXT;"}, diffTable(old, cur, "sqlite"))
+}
+
+func TestCreateView(t *testing.T) {
+	require.Equal(t, "CREATE OR REPLACE VIEW totals AS\nSELECT 1 AS total;", createView("postgres", "totals", "SELECT 1 AS total;\n"))
+	require.Equal(t, "CREATE VIEW IF NOT EXISTS totals AS\nSELECT 1 AS total;", createView("sqlite", "totals", "SELECT 1 AS total"))
+}
+
+func TestSchemaTemplate_Table(t *testing.T) {
+	tmpl, err := template.New("schema").Funcs(schemaFuncs).Parse(schemaTmpl)
+	require.NoError(t, err)
+	data := SchemaTemplateData{TableName: "accounts", Fields: []Field{
+		{Name: "id", DBType: "BIGINT", IsPK: true},
+		{Name: "email", DBType: "TEXT", IsUnique: true, IsIndexed: true},
+		{Name: "region", DBType: "TEXT"},
+	}, Indexes: []Index{{Name: "acct_email_region", Unique: true, Columns: []string{"email", "region"}}}}
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.ExecuteTemplate(&buf, "table", data))
+	require.Equal(t, `CREATE TABLE IF NOT EXISTS accounts (
+    id BIGINT PRIMARY KEY,
+    email TEXT UNIQUE,
+    region TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_accounts_email ON accounts (email);
+CREATE UNIQUE INDEX IF NOT EXISTS acct_email_region ON accounts (email, region);`, buf.String())
+
+	data.Adapter = "mssql"
+	buf.Reset()
+	require.NoError(t, tmpl.ExecuteTemplate(&buf, "table", data))
+	require.Contains(t, buf.String(), "IF OBJECT_ID(N'accounts', N'U') IS NULL\nCREATE TABLE accounts (\n")
+	require.NotContains(t, buf.String(), "IF NOT EXISTS idx")
+}
+
+func TestMigrate_TypeMappingChange(t *testing.T) {
+	meta := EntityMeta{StructName: "Account", TableName: "accounts", Fields: []Field{
+		{GoName: "ID", GoType: "int64", Name: "id", IsPK: true},
+		{GoName: "Email", GoType: "string", Name: "email"},
+	}}
+	// the previous snapshot is read back from snapshot.json
+	data, err := json.Marshal(snapshotOf(meta, "postgres"))
+	require.NoError(t, err)
+	var old TableSnapshot
+	require.NoError(t, json.Unmarshal(data, &old))
+	require.True(t, sameSnapshot(old, snapshotOf(meta, "postgres")))
+
+	// only xql.yaml changes: the entity version stays, the column type does not
+	typeMappings = map[string]map[string]string{"postgres": {"string": "VARCHAR(255)"}}
+	defer func() { typeMappings = nil }()
+	cur := snapshotOf(meta, "postgres")
+	require.Equal(t, old.Version, cur.Version)
+	require.False(t, sameSnapshot(old, cur))
+	require.Equal(t, []string{"ALTER TABLE accounts ALTER COLUMN email TYPE VARCHAR(255);"}, diffTable(old, cur, "postgres"))
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kcmvp/xql/validator"
 	"github.com/samber/mo"
 	"github.com/tidwall/match"
 
@@ -20,7 +21,35 @@ import (
 type charSet int
 
 type Validator[T FieldType] func(v T) error
-type ValidateFunc[T FieldType] func() (string, Validator[T])
+
+// ValidateFunc builds a named Validator. Like validator.ValidateFunc, it is
+// normally invoked without arguments; passing a non-nil *Rule additionally
+// fills in the validator's description, see Describe. Build one with Custom,
+// or with validator.Named to also describe parameters.
+type ValidateFunc[T FieldType] func(rule ...*Rule) (string, Validator[T])
+
+// Rule describes a validator by its name and parameters. It is the
+// validator.Rule of the view layer, so constraints declared on persistent
+// fields are exported to JSON Schema and OpenAPI like view validators.
+type Rule = validator.Rule
+
+// Describe returns the Rule of vf.
+func Describe[T FieldType](vf ValidateFunc[T]) Rule {
+	var r Rule
+	vf(&r)
+	return r
+}
+
+// Custom builds a constraint named name from fn, for project-specific
+// checks. It follows validator.Custom: name must not be empty nor taken by a
+// validator of that package, and Describe returns a rule without parameters.
+func Custom[T FieldType](name string, fn func(T) error) ValidateFunc[T] {
+	vf := validator.Custom(name, fn)
+	return func(rule ...*Rule) (string, Validator[T]) {
+		name, v := vf(rule...)
+		return name, Validator[T](v)
+	}
+}
 
 const (
 	LowerCaseChar charSet = iota
@@ -84,8 +113,8 @@ func (set charSet) value() (chars string, name string) {
 
 // MinLength validates that a string's length is at least the specified minimum.
 func MinLength(min int) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "min_length", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "min_length", "min", min), func(str string) error {
 			return lo.Ternary(len(str) < min, fmt.Errorf("%w %d ", ErrLengthMin, min), nil)
 		}
 	}
@@ -93,8 +122,8 @@ func MinLength(min int) ValidateFunc[string] {
 
 // MaxLength validates that a string's length is at most the specified maximum.
 func MaxLength(max int) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "max_length", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "max_length", "max", max), func(str string) error {
 			return lo.Ternary(len(str) > max, fmt.Errorf("%w %d ", ErrLengthMax, max), nil)
 		}
 	}
@@ -102,8 +131,8 @@ func MaxLength(max int) ValidateFunc[string] {
 
 // ExactLength validates that a string's length is exactly the specified length.
 func ExactLength(length int) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "exact_length", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "exact_length", "length", length), func(str string) error {
 			return lo.Ternary(len(str) != length, fmt.Errorf("%w %d characters", ErrLengthExact, length), nil)
 		}
 	}
@@ -112,8 +141,8 @@ func ExactLength(length int) ValidateFunc[string] {
 
 // LengthBetween validates that a string's length is within a given range (inclusive).
 func LengthBetween(min, max int) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "length_between", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "length_between", "min", min, "max", max), func(str string) error {
 			length := len(str)
 			return lo.Ternary(length < min || length > max, fmt.Errorf("%w %d and %d characters", ErrLengthBetween, min, max), nil)
 		}
@@ -122,8 +151,8 @@ func LengthBetween(min, max int) ValidateFunc[string] {
 
 // CharSetOnly validates that a string only contains characters from the specified character sets.
 func CharSetOnly(charSets ...charSet) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "only_contains", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "only_contains", "charsets", charSets), func(str string) error {
 			var allChars strings.Builder
 			var names []string
 			for _, set := range charSets {
@@ -143,8 +172,8 @@ func CharSetOnly(charSets ...charSet) ValidateFunc[string] {
 
 // CharSetAny validates that a string contains at least one character from any of the specified character sets.
 func CharSetAny(charSets ...charSet) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "contains_any", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "contains_any", "charsets", charSets), func(str string) error {
 			var allChars strings.Builder
 			var names []string
 			for _, set := range charSets {
@@ -159,8 +188,8 @@ func CharSetAny(charSets ...charSet) ValidateFunc[string] {
 
 // CharSetAll validates that a string contains at least one character from each of the specified character sets.
 func CharSetAll(charSets ...charSet) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "contains_all", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "contains_all", "charsets", charSets), func(str string) error {
 			for _, set := range charSets {
 				chars, name := set.value()
 				if !strings.ContainsAny(chars, str) {
@@ -175,8 +204,8 @@ func CharSetAll(charSets ...charSet) ValidateFunc[string] {
 
 // CharSetNo validates that a string does not contain any characters from the specified character sets.
 func CharSetNo(charSets ...charSet) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "not_contains", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "not_contains", "charsets", charSets), func(str string) error {
 			for _, set := range charSets {
 				chars, name := set.value()
 				if strings.ContainsAny(str, chars) {
@@ -196,8 +225,8 @@ func CharSetNo(charSets ...charSet) ValidateFunc[string] {
 // Example: Match("foo*") will match "foobar", "foo", etc.
 func Match(pattern string) ValidateFunc[string] {
 	lo.Assertf(match.IsPattern(pattern), "invalid pattern `%s`: `?` stands for one character, `*` stands for any number of characters", pattern)
-	return func() (string, Validator[string]) {
-		return "match", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "match", "pattern", pattern), func(str string) error {
 			return lo.Ternary(!match.Match(str, pattern), fmt.Errorf("%w %s", ErrNotMatch, pattern), nil)
 		}
 	}
@@ -205,8 +234,8 @@ func Match(pattern string) ValidateFunc[string] {
 
 // Email validates that a string is a valid email address.
 func Email() ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "email", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "email"), func(str string) error {
 			return lo.Ternary(mo.TupleToResult[*mail.Address](mail.ParseAddress(str)).IsError(), fmt.Errorf("%w:%s", ErrNotValidEmail, str), nil)
 		}
 	}
@@ -214,8 +243,8 @@ func Email() ValidateFunc[string] {
 
 // URL validates that a string is a valid URL.
 func URL() ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "url", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return validator.Named(rule, "url"), func(str string) error {
 			rs := mo.TupleToResult[*url.URL](url.Parse(str))
 			errRs := rs.IsError() || rs.MustGet().Scheme == "" || rs.MustGet().Host == ""
 			return lo.Ternary(errRs, fmt.Errorf("%w: %s", ErrNotValidURL, str), nil)
@@ -228,8 +257,8 @@ func URL() ValidateFunc[string] {
 // OneOf validates that a value is one of the allowed values.
 // This works for any comparable type in FieldType (string, bool, all numbers).
 func OneOf[T FieldType](allowed ...T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "one_of", func(val T) error {
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return validator.Named(rule, "one_of", "values", allowed), func(val T) error {
 			return lo.Ternary(!lo.Contains(allowed, val), fmt.Errorf("%w:%v", ErrNotOneOf, allowed), nil)
 		}
 	}
@@ -237,8 +266,8 @@ func OneOf[T FieldType](allowed ...T) ValidateFunc[T] {
 
 // Gt validates that a value is greater than the specified minimum.
 func Gt[T Number | time.Time](min T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "gt", func(val T) error {
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return validator.Named(rule, "gt", "min", min), func(val T) error {
 			return lo.Ternary(!isGreaterThan(val, min), fmt.Errorf("%w %v", ErrMustGt, min), nil)
 		}
 	}
@@ -246,8 +275,8 @@ func Gt[T Number | time.Time](min T) ValidateFunc[T] {
 
 // Gte validates that a value is greater than or equal to the specified minimum.
 func Gte[T Number | time.Time](min T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "gte", func(val T) error {
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return validator.Named(rule, "gte", "min", min), func(val T) error {
 			return lo.Ternary(isLessThan(val, min), fmt.Errorf("%w %v", ErrMustGte, min), nil)
 		}
 	}
@@ -255,8 +284,8 @@ func Gte[T Number | time.Time](min T) ValidateFunc[T] {
 
 // Lt validates that a value is less than the specified maximum.
 func Lt[T Number | time.Time](max T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "lt", func(val T) error {
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return validator.Named(rule, "lt", "max", max), func(val T) error {
 			return lo.Ternary(!isLessThan(val, max), fmt.Errorf("%w %v", ErrMustLt, max), nil)
 		}
 	}
@@ -264,8 +293,8 @@ func Lt[T Number | time.Time](max T) ValidateFunc[T] {
 
 // Lte validates that a value is less than or equal to the specified maximum.
 func Lte[T Number | time.Time](max T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "lte", func(val T) error {
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return validator.Named(rule, "lte", "max", max), func(val T) error {
 			return lo.Ternary(isGreaterThan(val, max), fmt.Errorf("%w %v", ErrMustLte, max), nil)
 		}
 	}
@@ -273,8 +302,8 @@ func Lte[T Number | time.Time](max T) ValidateFunc[T] {
 
 // Between validates that a value is within a given range (inclusive of min and max).
 func Between[T Number | time.Time](min, max T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "between", func(val T) error {
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return validator.Named(rule, "between", "min", min, "max", max), func(val T) error {
 			return lo.Ternary(isLessThan(val, min) || isGreaterThan(val, max), fmt.Errorf("%w %v and %v", ErrMustBetween, min, max), nil)
 		}
 	}
@@ -284,8 +313,8 @@ func Between[T Number | time.Time](min, max T) ValidateFunc[T] {
 
 // BeTrue validates that a boolean value is true.
 func BeTrue() ValidateFunc[bool] {
-	return func() (string, Validator[bool]) {
-		return "be_true", func(b bool) error {
+	return func(rule ...*Rule) (string, Validator[bool]) {
+		return validator.Named(rule, "be_true"), func(b bool) error {
 			return lo.Ternary(!b, ErrMustBeTrue, nil)
 		}
 	}
@@ -293,8 +322,8 @@ func BeTrue() ValidateFunc[bool] {
 
 // BeFalse validates that a boolean value is false.
 func BeFalse() ValidateFunc[bool] {
-	return func() (string, Validator[bool]) {
-		return "be_false", func(b bool) error {
+	return func(rule ...*Rule) (string, Validator[bool]) {
+		return validator.Named(rule, "be_false"), func(b bool) error {
 			return lo.Ternary(b, ErrMustBeFalse, nil)
 		}
 	}
@@ -383,8 +412,8 @@ var DefaultTimeLayouts = []string{time.RFC3339Nano, time.RFC3339, "2006-01-02T15
 //   - Counts digits from integer and fractional parts; total digits must be <= precision
 //     and fractional digits must be <= scale.
 func DecimalString(precision, scale int) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		name := fmt.Sprintf("decimal(%d,%d)", precision, scale)
+	return func(rule ...*Rule) (string, Validator[string]) {
+		name := validator.Named(rule, fmt.Sprintf("decimal(%d,%d)", precision, scale), "precision", precision, "scale", scale)
 		return name, func(s string) error {
 			s = strings.TrimSpace(s)
 			if s == "" {
@@ -428,8 +457,8 @@ func DecimalString(precision, scale int) ValidateFunc[string] {
 // fractional part has at most 'scale' decimal places. For floats we check fractional
 // places by scaling and ensuring the scaled value is an integer within a small epsilon.
 func Decimal[T float32 | float64](precision, scale int) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		name := fmt.Sprintf("decimal(%d,%d)", precision, scale)
+	return func(rule ...*Rule) (string, Validator[T]) {
+		name := validator.Named(rule, fmt.Sprintf("decimal(%d,%d)", precision, scale), "precision", precision, "scale", scale)
 		return name, func(v T) error {
 			vf := float64(v)
 			if math.IsNaN(vf) || math.IsInf(vf, 0) {
@@ -1,8 +1,11 @@
 package xql
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -206,3 +209,34 @@ func TestDecimalValidator_Invalid(t *testing.T) {
 		require.Error(t, fn(s), "should reject %s", s)
 	}
 }
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		want Rule
+	}{
+		{"min length", Describe(MinLength(3)), Rule{Name: "min_length", Params: map[string]any{"min": 3}}},
+		{"between", Describe(Between(1, 9)), Rule{Name: "between", Params: map[string]any{"min": 1, "max": 9}}},
+		{"one of", Describe(OneOf("a", "b")), Rule{Name: "one_of", Params: map[string]any{"values": []string{"a", "b"}}}},
+		{"email", Describe(Email()), Rule{Name: "email", Params: map[string]any{}}},
+		{"decimal", Describe(Decimal[float64](10, 2)), Rule{Name: "decimal(10,2)", Params: map[string]any{"precision": 10, "scale": 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.rule)
+		})
+	}
+}
+
+func TestCustom(t *testing.T) {
+	noSpaces := Custom("no_spaces", func(s string) error {
+		return lo.Ternary(strings.Contains(s, " "), errors.New("must not contain spaces"), nil)
+	})
+	name, v := noSpaces()
+	require.Equal(t, "no_spaces", name)
+	require.Error(t, v("a b"))
+	require.NoError(t, v("ab"))
+	require.Equal(t, Rule{Name: "no_spaces", Params: map[string]any{}}, Describe(noSpaces))
+	require.Panics(t, func() { Custom[string]("email", func(string) error { return nil }) })
+}
@@ -4,3 +4,10 @@ package entity
 type Entity interface {
 	Table() string
 }
+
+// ReadModel is an Entity backed by a database view instead of a table:
+// Table returns the view name and View the SELECT statement defining it.
+type ReadModel interface {
+	Entity
+	View() string
+}
@@ -3,6 +3,7 @@ package xql
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kcmvp/xql/entity"
@@ -77,10 +78,15 @@ type FieldType interface {
 // PersistentField is the internal, immutable implementation of Field.
 // Instances are produced using `NewField`.
 type PersistentField[E FieldType] struct {
-	table  string
-	column string
-	view   string
-	vfs    []ValidateFunc[E]
+	table      string
+	column     string
+	view       string
+	vfs        []ValidateFunc[E]
+	nullable   bool
+	softDelete bool
+	// autoCreateTime and autoUpdateTime mark timestamps the sqlx builders fill in.
+	autoCreateTime bool
+	autoUpdateTime bool
 }
 
 func (f *PersistentField[E]) Scope() string {
@@ -118,6 +124,114 @@ func (f *PersistentField[E]) Constraints() []ValidateFunc[E] {
 	return cp
 }
 
+// Nullable returns a copy of the field marked as a nullable column. The
+// generator emits it for pointer fields of the entity (e.g., *string).
+func (f *PersistentField[E]) Nullable() *PersistentField[E] {
+	cp := *f
+	cp.nullable = true
+	return &cp
+}
+
+// IsNullable reports whether the column accepts NULL, see Nullable.
+func (f *PersistentField[E]) IsNullable() bool {
+	return f != nil && f.nullable
+}
+
+// Column roles a table has at most one field for, see roleField.
+const (
+	roleSoftDelete     = "softdelete"
+	roleAutoCreateTime = "autocreatetime"
+	roleAutoUpdateTime = "autoupdatetime"
+)
+
+// roleKey identifies the field playing a role in a table.
+type roleKey struct {
+	role  string
+	table string
+}
+
+// roles maps a role and a table to the Field registered for it by
+// SoftDelete, AutoCreateTime or AutoUpdateTime.
+var roles sync.Map
+
+// roleField returns the field registered for role in table.
+func roleField(role string, table string) (Field, bool) {
+	f, ok := roles.Load(roleKey{role: role, table: table})
+	if !ok {
+		return nil, false
+	}
+	return f.(Field), true
+}
+
+// SoftDelete returns a copy of the field marked as the soft-delete column of
+// its table, a nullable timestamp set when a row is deleted, and registers it
+// for SoftDeleteField. The generator emits it for fields declared with the
+// softdelete directive and for DeletedAt timestamps.
+func (f *PersistentField[E]) SoftDelete() *PersistentField[E] {
+	cp := *f
+	cp.softDelete = true
+	roles.Store(roleKey{role: roleSoftDelete, table: cp.table}, Field(&cp))
+	return &cp
+}
+
+// IsSoftDelete reports whether the field is the soft-delete column of its
+// table, see SoftDelete.
+func (f *PersistentField[E]) IsSoftDelete() bool {
+	return f != nil && f.softDelete
+}
+
+// SoftDeleteField returns the soft-delete column registered for table, so
+// query builders can discover it without knowing the entity's fields: the
+// sqlx NotDeleted scope and SoftDelete builder read it.
+func SoftDeleteField(table string) (Field, bool) {
+	return roleField(roleSoftDelete, table)
+}
+
+// AutoCreateTime returns a copy of the field marked as the creation time of
+// its rows, and registers it for AutoCreateTimeField: the sqlx insert
+// builder sets it to the current time when no value is given. The generator
+// emits it for fields declared with the autoCreateTime directive.
+func (f *PersistentField[E]) AutoCreateTime() *PersistentField[E] {
+	cp := *f
+	cp.autoCreateTime = true
+	roles.Store(roleKey{role: roleAutoCreateTime, table: cp.table}, Field(&cp))
+	return &cp
+}
+
+// IsAutoCreateTime reports whether the field is the creation time of its
+// rows, see AutoCreateTime.
+func (f *PersistentField[E]) IsAutoCreateTime() bool {
+	return f != nil && f.autoCreateTime
+}
+
+// AutoCreateTimeField returns the creation time column registered for table.
+func AutoCreateTimeField(table string) (Field, bool) {
+	return roleField(roleAutoCreateTime, table)
+}
+
+// AutoUpdateTime returns a copy of the field marked as the last update time
+// of its rows, and registers it for AutoUpdateTimeField: the sqlx insert and
+// update builders set it to the current time when no value is given. The
+// generator emits it for fields declared with the autoUpdateTime directive.
+func (f *PersistentField[E]) AutoUpdateTime() *PersistentField[E] {
+	cp := *f
+	cp.autoUpdateTime = true
+	roles.Store(roleKey{role: roleAutoUpdateTime, table: cp.table}, Field(&cp))
+	return &cp
+}
+
+// IsAutoUpdateTime reports whether the field is the last update time of its
+// rows, see AutoUpdateTime.
+func (f *PersistentField[E]) IsAutoUpdateTime() bool {
+	return f != nil && f.autoUpdateTime
+}
+
+// AutoUpdateTimeField returns the last update time column registered for
+// table.
+func AutoUpdateTimeField(table string) (Field, bool) {
+	return roleField(roleAutoUpdateTime, table)
+}
+
 // NewField creates a Field for entity type E with Go type hint T.
 //
 // Parameters:
@@ -146,3 +260,109 @@ func NewField[E entity.Entity, T FieldType](column string, view string, vfs ...V
 		vfs:    vfs,
 	}
 }
+
+// columnField holds the identity of the fields whose Go type is not a
+// FieldType, see BlobField and DocumentField.
+type columnField struct {
+	table    string
+	column   string
+	view     string
+	nullable bool
+}
+
+// newColumnField resolves the table of E and checks column and view like
+// NewField.
+func newColumnField[E entity.Entity](column string, view string) columnField {
+	f := NewField[E, string](column, view)
+	return columnField{table: f.table, column: f.column, view: f.view}
+}
+
+func (f *columnField) Scope() string {
+	return f.table
+}
+
+// QualifiedName returns "table.column.view", like PersistentField.
+func (f *columnField) QualifiedName() string {
+	return fmt.Sprintf("%s.%s.%s", f.table, f.column, f.view)
+}
+
+func (f *columnField) View() string {
+	return f.view
+}
+
+func (f *columnField) seal(sealer) {}
+
+// IsNullable reports whether the column accepts NULL.
+func (f *columnField) IsNullable() bool {
+	return f != nil && f.nullable
+}
+
+// BlobField is the Field of a BYTEA or BLOB column, generated for []byte
+// entity fields. It carries no validators: the view package checks the
+// payload with BytesField limits instead. Instances are produced using
+// `NewBlobField`.
+type BlobField struct {
+	columnField
+}
+
+var _ Field = (*BlobField)(nil)
+
+// Nullable returns a copy of the field marked as a nullable column.
+func (f *BlobField) Nullable() *BlobField {
+	cp := *f
+	cp.nullable = true
+	return &cp
+}
+
+// NewBlobField creates the BlobField of entity type E. column and view
+// follow the rules of NewField.
+func NewBlobField[E entity.Entity](column string, view string) *BlobField {
+	return &BlobField{columnField: newColumnField[E](column, view)}
+}
+
+// DocumentField is the Field of a JSON or JSONB column, generated for
+// struct, map and slice entity fields declared with `type:json` or
+// `type:jsonb`. The view package accepts the document as raw JSON and the
+// sqlx package serializes Go values to JSON on write. Instances are produced
+// using `NewDocumentField`.
+type DocumentField struct {
+	columnField
+	shape string
+}
+
+var _ Field = (*DocumentField)(nil)
+
+// Nullable returns a copy of the field marked as a nullable column.
+func (f *DocumentField) Nullable() *DocumentField {
+	cp := *f
+	cp.nullable = true
+	return &cp
+}
+
+// Object returns a copy of the field whose documents are JSON objects, as
+// for struct and map fields.
+func (f *DocumentField) Object() *DocumentField {
+	cp := *f
+	cp.shape = "object"
+	return &cp
+}
+
+// Array returns a copy of the field whose documents are JSON arrays, as for
+// slice fields.
+func (f *DocumentField) Array() *DocumentField {
+	cp := *f
+	cp.shape = "array"
+	return &cp
+}
+
+// Shape returns the JSON type of the documents, "object" or "array", or an
+// empty string when any JSON value is accepted.
+func (f *DocumentField) Shape() string {
+	return f.shape
+}
+
+// NewDocumentField creates the DocumentField of entity type E. column and
+// view follow the rules of NewField.
+func NewDocumentField[E entity.Entity](column string, view string) *DocumentField {
+	return &DocumentField{columnField: newColumnField[E](column, view)}
+}
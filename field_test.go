@@ -2,6 +2,7 @@ package xql
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -50,3 +51,66 @@ func TestConstraints_ReturnsCopyAndNames(t *testing.T) {
 	c2 := f.Constraints()
 	require.Len(t, c2, 1)
 }
+
+// Nullable should return a marked copy and leave the original field untouched.
+func TestNullable(t *testing.T) {
+	f := NewField[dotEntity, string]("col", "V")
+	n := f.Nullable()
+	require.True(t, n.IsNullable())
+	require.False(t, f.IsNullable())
+	require.Equal(t, f.QualifiedName(), n.QualifiedName())
+}
+
+func TestNewBlobField(t *testing.T) {
+	f := NewBlobField[schemaTableEntity]("avatar", "Avatar")
+	require.Equal(t, "schema.table.avatar.Avatar", f.QualifiedName())
+	require.Equal(t, "schema.table", f.Scope())
+	require.Equal(t, "Avatar", f.View())
+	require.True(t, f.Nullable().IsNullable())
+	require.False(t, f.IsNullable())
+	require.Panics(t, func() { _ = NewBlobField[dotEntity]("ava.tar", "Avatar") })
+}
+
+func TestNewDocumentField(t *testing.T) {
+	f := NewDocumentField[schemaTableEntity]("settings", "Settings")
+	require.Equal(t, "schema.table.settings.Settings", f.QualifiedName())
+	require.Empty(t, f.Shape())
+	require.Equal(t, "object", f.Object().Shape())
+	n := f.Array().Nullable()
+	require.Equal(t, "array", n.Shape())
+	require.True(t, n.IsNullable())
+	require.False(t, f.IsNullable())
+}
+
+// SoftDelete should mark a copy and register it for its table.
+func TestSoftDelete(t *testing.T) {
+	_, ok := SoftDeleteField("schema.table")
+	require.False(t, ok)
+	f := NewField[schemaTableEntity, time.Time]("deleted_at", "DeletedAt").Nullable()
+	sd := f.SoftDelete()
+	require.True(t, sd.IsSoftDelete())
+	require.True(t, sd.IsNullable())
+	require.False(t, f.IsSoftDelete())
+	got, ok := SoftDeleteField("schema.table")
+	require.True(t, ok)
+	require.Equal(t, "schema.table.deleted_at.DeletedAt", got.QualifiedName())
+}
+
+// AutoCreateTime and AutoUpdateTime should mark copies and register them for their table.
+func TestAutoTime(t *testing.T) {
+	created := NewField[dotEntity, time.Time]("created_at", "CreatedAt")
+	updated := NewField[dotEntity, time.Time]("updated_at", "UpdatedAt")
+	_, ok := AutoCreateTimeField("t")
+	require.False(t, ok)
+	require.True(t, created.AutoCreateTime().IsAutoCreateTime())
+	require.True(t, updated.AutoUpdateTime().IsAutoUpdateTime())
+	require.False(t, created.IsAutoCreateTime())
+	got, ok := AutoCreateTimeField("t")
+	require.True(t, ok)
+	require.Equal(t, created.QualifiedName(), got.QualifiedName())
+	got, ok = AutoUpdateTimeField("t")
+	require.True(t, ok)
+	require.Equal(t, updated.QualifiedName(), got.QualifiedName())
+	_, ok = SoftDeleteField("t")
+	require.False(t, ok)
+}
@@ -52,6 +52,18 @@ type ValueObject interface {
 
 type Data map[string]any
 
+// null is the type of Null.
+type null struct{}
+
+// MarshalJSON encodes Null as JSON null.
+func (null) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+// Null marks a field explicitly set to JSON null, as opposed to an absent
+// field. Getters report it as absent; use IsNull to tell the two apart.
+var Null any = null{}
+
 var _ ValueObject = (*Data)(nil)
 
 func (vo Data) Get(s string) mo.Option[any] {
@@ -92,28 +104,43 @@ var _ ValueObject = (*Data)(nil)
 // seal is an empty method to satisfy the sealed ValueObject interface.
 func (vo Data) seal() {}
 
+// IsNull reports whether the field was explicitly set to null, see Null.
+func (vo Data) IsNull(name string) bool {
+	val, ok := lookup(vo, name)
+	return ok && val == Null
+}
+
 // Get is a generic helper to retrieve a value and assert its type.
-// It returns an Option, which will be empty if the key was not present.
-// It panics if the key exists but the type is incorrect. This function
+// It returns an Option, which will be empty if the key was not present or
+// is Null. It panics if the key exists but the type is incorrect. This function
 // supports dot notation for embedded objects and array indexing (e.g., "field.0.nestedField").
 func Get[T any](data Data, name string) mo.Option[T] {
+	val, ok := lookup(data, name)
+	if !ok || val == Null {
+		return mo.None[T]()
+	}
+	typedValue, ok := val.(T)
+	lo.Assertf(ok, "xql: field '%s' has wrong type: expected %T, got %T", name, *new(T), val)
+	return mo.Some(typedValue)
+}
+
+// lookup returns the raw value stored under the dotted path name.
+func lookup(data Data, name string) (any, bool) {
 	if val, ok := data[name]; ok {
-		typedValue, ok := val.(T)
-		lo.Assertf(ok, "xql: field '%s' has wrong type: expected %T, got %T", name, *new(T), val)
-		return mo.Some(typedValue)
+		return val, true
 	}
 	parts := strings.Split(name, ".")
 	var currentValue any = data
 	for _, part := range parts {
-		if currentValue == nil {
-			return mo.None[T]()
+		if currentValue == nil || currentValue == Null {
+			return nil, false
 		}
 		// If it's a map (plain Data), look up the key first to avoid delegating
 		// to ValueObject which may call back into this Get function and cause recursion.
 		if voMap, ok := currentValue.(Data); ok {
 			nextValue, exists := voMap[part]
 			if !exists {
-				return mo.None[T]()
+				return nil, false
 			}
 			currentValue = nextValue
 			continue
@@ -121,9 +148,13 @@ func Get[T any](data Data, name string) mo.Option[T] {
 		// If the current value implements ValueObject (but is not plain Data),
 		// delegate the lookup to its Get method for proper hierarchical traversal.
 		if voIface, ok := currentValue.(ValueObject); ok {
+			if n, ok := voIface.(interface{ IsNull(string) bool }); ok && n.IsNull(part) {
+				currentValue = Null
+				continue
+			}
 			opt := voIface.Get(part)
 			if !opt.IsPresent() {
-				return mo.None[T]()
+				return nil, false
 			}
 			currentValue = opt.MustGet()
 			continue
@@ -138,12 +169,9 @@ func Get[T any](data Data, name string) mo.Option[T] {
 			continue
 		}
 		// If we are here, we are trying to traverse into a primitive from a non-final path segment.
-		return mo.None[T]()
+		return nil, false
 	}
-
-	typedValue, ok := currentValue.(T)
-	lo.Assertf(ok, "xql: field '%s' has wrong type: expected %T, got %T", name, *new(T), currentValue)
-	return mo.Some(typedValue)
+	return currentValue, true
 }
 
 // String returns an Option containing the string value for the given name.
@@ -1,5 +1,5 @@
 // Code generated by gob xql schema. DO NOT EDIT.
-// Generated at: 2026-01-05 11:49:38 (ver: 754218eb7f)
+// Generated at: 2026-10-16 23:24:19 (ver: 8824d78787)
 
 package account
 
@@ -22,6 +22,20 @@ var (
 	UpdatedBy = xql.NewField[Account, string]("updated_by", "UpdatedBy")
 )
 
+// Table and the Col constants are the names of the table and the columns of Account.
+const (
+	Table        = "accounts"
+	ColID        = "id"
+	ColEmail     = "email"
+	ColNickname  = "nick_name"
+	ColCategory  = "category"
+	ColBalance   = "balance"
+	ColCreatedAt = "created_at"
+	ColUpdatedAt = "updated_at"
+	ColCreatedBy = "created_by"
+	ColUpdatedBy = "updated_by"
+)
+
 // All returns all field definitions for Account in a stable order.
 func All() []xql.Field {
 	return []xql.Field{
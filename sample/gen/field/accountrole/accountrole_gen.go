@@ -1,5 +1,5 @@
 // Code generated by gob xql schema. DO NOT EDIT.
-// Generated at: 2026-01-05 11:49:38 (ver: aa64d1d521)
+// Generated at: 2026-10-16 23:24:19 (ver: e1ff6b128a)
 
 package accountrole
 
@@ -20,6 +20,18 @@ var (
 	UpdatedBy = xql.NewField[AccountRole, string]("updated_by", "UpdatedBy")
 )
 
+// Table and the Col constants are the names of the table and the columns of AccountRole.
+const (
+	Table        = "account_roles"
+	ColID        = "id"
+	ColAccountID = "account_id"
+	ColRoleID    = "role_id"
+	ColCreatedAt = "created_at"
+	ColUpdatedAt = "updated_at"
+	ColCreatedBy = "created_by"
+	ColUpdatedBy = "updated_by"
+)
+
 // All returns all field definitions for AccountRole in a stable order.
 func All() []xql.Field {
 	return []xql.Field{
@@ -1,5 +1,5 @@
 // Code generated by gob xql schema. DO NOT EDIT.
-// Generated at: 2026-01-05 11:49:38 (ver: 5d1cd5cdc5)
+// Generated at: 2026-10-16 23:24:19 (ver: 20c1bac9ae)
 
 package order
 
@@ -20,6 +20,18 @@ var (
 	UpdatedBy = xql.NewField[Order, string]("updated_by", "UpdatedBy")
 )
 
+// Table and the Col constants are the names of the table and the columns of Order.
+const (
+	Table        = "orders"
+	ColID        = "id"
+	ColAccountID = "account_id"
+	ColAmount    = "amount"
+	ColCreatedAt = "created_at"
+	ColUpdatedAt = "updated_at"
+	ColCreatedBy = "created_by"
+	ColUpdatedBy = "updated_by"
+)
+
 // All returns all field definitions for Order in a stable order.
 func All() []xql.Field {
 	return []xql.Field{
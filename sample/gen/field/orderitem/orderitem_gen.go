@@ -1,5 +1,5 @@
 // Code generated by gob xql schema. DO NOT EDIT.
-// Generated at: 2026-01-05 11:49:38 (ver: e158f795f2)
+// Generated at: 2026-10-16 23:24:19 (ver: 779fd4ff84)
 
 package orderitem
 
@@ -22,6 +22,20 @@ var (
 	UpdatedBy = xql.NewField[OrderItem, string]("updated_by", "UpdatedBy")
 )
 
+// Table and the Col constants are the names of the table and the columns of OrderItem.
+const (
+	Table        = "order_items"
+	ColID        = "id"
+	ColOrderID   = "order_id"
+	ColProductID = "product_id"
+	ColQuantity  = "quantity"
+	ColUnitPrice = "unit_price"
+	ColCreatedAt = "created_at"
+	ColUpdatedAt = "updated_at"
+	ColCreatedBy = "created_by"
+	ColUpdatedBy = "updated_by"
+)
+
 // All returns all field definitions for OrderItem in a stable order.
 func All() []xql.Field {
 	return []xql.Field{
@@ -1,5 +1,5 @@
 // Code generated by gob xql schema. DO NOT EDIT.
-// Generated at: 2026-01-05 11:49:38 (ver: 6141e9d37c)
+// Generated at: 2026-10-16 23:24:19 (ver: e5264078a6)
 
 package product
 
@@ -21,6 +21,19 @@ var (
 	UpdatedBy = xql.NewField[Product, string]("updated_by", "UpdatedBy")
 )
 
+// Table and the Col constants are the names of the table and the columns of Product.
+const (
+	Table        = "products"
+	ColID        = "id"
+	ColSKU       = "sku"
+	ColName      = "name"
+	ColPrice     = "price"
+	ColCreatedAt = "created_at"
+	ColUpdatedAt = "updated_at"
+	ColCreatedBy = "created_by"
+	ColUpdatedBy = "updated_by"
+)
+
 // All returns all field definitions for Product in a stable order.
 func All() []xql.Field {
 	return []xql.Field{
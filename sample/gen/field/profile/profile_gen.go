@@ -1,5 +1,5 @@
 // Code generated by gob xql schema. DO NOT EDIT.
-// Generated at: 2026-01-05 11:49:38 (ver: da5357b415)
+// Generated at: 2026-10-16 23:24:19 (ver: b6730a7fe9)
 
 package profile
 
@@ -21,6 +21,19 @@ var (
 	UpdatedBy = xql.NewField[Profile, string]("updated_by", "UpdatedBy")
 )
 
+// Table and the Col constants are the names of the table and the columns of Profile.
+const (
+	Table        = "profiles"
+	ColID        = "id"
+	ColAccountID = "account_id"
+	ColBio       = "bio"
+	ColBirthday  = "birthday"
+	ColCreatedAt = "created_at"
+	ColUpdatedAt = "updated_at"
+	ColCreatedBy = "created_by"
+	ColUpdatedBy = "updated_by"
+)
+
 // All returns all field definitions for Profile in a stable order.
 func All() []xql.Field {
 	return []xql.Field{
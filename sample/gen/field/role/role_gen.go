@@ -1,5 +1,5 @@
 // Code generated by gob xql schema. DO NOT EDIT.
-// Generated at: 2026-01-05 11:49:38 (ver: 85a09a3445)
+// Generated at: 2026-10-16 23:24:19 (ver: 1337738138)
 
 package role
 
@@ -20,6 +20,18 @@ var (
 	UpdatedBy = xql.NewField[Role, string]("updated_by", "UpdatedBy")
 )
 
+// Table and the Col constants are the names of the table and the columns of Role.
+const (
+	Table        = "roles"
+	ColID        = "id"
+	ColKey       = "key"
+	ColName      = "name"
+	ColCreatedAt = "created_at"
+	ColUpdatedAt = "updated_at"
+	ColCreatedBy = "created_by"
+	ColUpdatedBy = "updated_by"
+)
+
 // All returns all field definitions for Role in a stable order.
 func All() []xql.Field {
 	return []xql.Field{
@@ -1,5 +1,5 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: aa64d1d521)
+-- Generated at: 2026-10-16 23:24:19 (ver: e1ff6b128a)
 
 CREATE TABLE IF NOT EXISTS account_roles (
     id BIGINT PRIMARY KEY,
@@ -9,4 +9,4 @@ CREATE TABLE IF NOT EXISTS account_roles (
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='AccountRole is a join table for Account <-> Role.';
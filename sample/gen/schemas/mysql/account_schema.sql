@@ -1,15 +1,15 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: 754218eb7f)
+-- Generated at: 2026-10-16 23:24:19 (ver: 8824d78787)
 
 CREATE TABLE IF NOT EXISTS accounts (
     id BIGINT PRIMARY KEY,
     email TEXT UNIQUE,
-    nick_name varchar(100) NOT NULL UNIQUE DEFAULT 'anonymous',
+    nick_name varchar(100) DEFAULT 'anonymous' NOT NULL UNIQUE,
     category integer DEFAULT 0,
     balance DOUBLE,
     created_at DATETIME,
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='Account represents a user account in the system.';
 CREATE INDEX IF NOT EXISTS idx_accounts_email ON accounts (email);
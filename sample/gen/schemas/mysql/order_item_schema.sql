@@ -1,5 +1,5 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: e158f795f2)
+-- Generated at: 2026-10-16 23:24:19 (ver: 779fd4ff84)
 
 CREATE TABLE IF NOT EXISTS order_items (
     id BIGINT PRIMARY KEY,
@@ -11,4 +11,4 @@ CREATE TABLE IF NOT EXISTS order_items (
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='OrderItem represents a line item of an Order (1:N).';
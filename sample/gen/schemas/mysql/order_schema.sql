@@ -1,5 +1,5 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: 5d1cd5cdc5)
+-- Generated at: 2026-10-16 23:24:19 (ver: 20c1bac9ae)
 
 CREATE TABLE IF NOT EXISTS orders (
     id BIGINT PRIMARY KEY,
@@ -9,4 +9,4 @@ CREATE TABLE IF NOT EXISTS orders (
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='Order represents a customer order.';
@@ -1,5 +1,5 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: da5357b415)
+-- Generated at: 2026-10-16 23:24:19 (ver: b6730a7fe9)
 
 CREATE TABLE IF NOT EXISTS profiles (
     id BIGINT PRIMARY KEY,
@@ -10,4 +10,4 @@ CREATE TABLE IF NOT EXISTS profiles (
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='Profile represents a 1:1 extension of Account.';
@@ -1,5 +1,5 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: 6141e9d37c)
+-- Generated at: 2026-10-16 23:24:19 (ver: e5264078a6)
 
 CREATE TABLE IF NOT EXISTS products (
     id BIGINT PRIMARY KEY,
@@ -11,4 +11,5 @@ CREATE TABLE IF NOT EXISTS products (
     created_by TEXT,
     updated_by TEXT
 );
+COMMENT ON TABLE products IS 'Product represents a product.';
 CREATE INDEX IF NOT EXISTS idx_products_sku ON products (sku);
@@ -1,5 +1,5 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: da5357b415)
+-- Generated at: 2026-10-16 23:24:19 (ver: b6730a7fe9)
 
 CREATE TABLE IF NOT EXISTS profiles (
     id BIGINT PRIMARY KEY,
@@ -11,3 +11,4 @@ CREATE TABLE IF NOT EXISTS profiles (
     created_by TEXT,
     updated_by TEXT
 );
+COMMENT ON TABLE profiles IS 'Profile represents a 1:1 extension of Account.';
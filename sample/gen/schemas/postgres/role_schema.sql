@@ -1,5 +1,5 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: 85a09a3445)
+-- Generated at: 2026-10-16 23:24:19 (ver: 1337738138)
 
 CREATE TABLE IF NOT EXISTS roles (
     id BIGINT PRIMARY KEY,
@@ -10,4 +10,5 @@ CREATE TABLE IF NOT EXISTS roles (
     created_by TEXT,
     updated_by TEXT
 );
+COMMENT ON TABLE roles IS 'Role represents an authorization role.';
 CREATE INDEX IF NOT EXISTS idx_roles_key ON roles (key);
@@ -1,5 +1,5 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: aa64d1d521)
+-- Generated at: 2026-10-16 23:24:19 (ver: e1ff6b128a)
 
 CREATE TABLE IF NOT EXISTS account_roles (
     id INTEGER PRIMARY KEY,
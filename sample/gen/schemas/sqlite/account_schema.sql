@@ -1,10 +1,10 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: 754218eb7f)
+-- Generated at: 2026-10-16 23:24:19 (ver: 8824d78787)
 
 CREATE TABLE IF NOT EXISTS accounts (
     id INTEGER PRIMARY KEY,
     email TEXT UNIQUE,
-    nick_name varchar(100) NOT NULL UNIQUE DEFAULT 'anonymous',
+    nick_name varchar(100) DEFAULT 'anonymous' NOT NULL UNIQUE,
     category integer DEFAULT 0,
     balance REAL,
     created_at DATETIME,
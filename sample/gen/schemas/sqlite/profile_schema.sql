@@ -1,5 +1,5 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: da5357b415)
+-- Generated at: 2026-10-16 23:24:19 (ver: b6730a7fe9)
 
 CREATE TABLE IF NOT EXISTS profiles (
     id INTEGER PRIMARY KEY,
@@ -1,5 +1,5 @@
 -- Code generated by dvo xql. DO NOT EDIT.
--- Generated at: 2026-01-05 11:49:38 (ver: 85a09a3445)
+-- Generated at: 2026-10-16 23:24:19 (ver: 1337738138)
 
 CREATE TABLE IF NOT EXISTS roles (
     id INTEGER PRIMARY KEY,
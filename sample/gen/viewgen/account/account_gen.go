@@ -0,0 +1,39 @@
+// Code generated by gob xql schema. DO NOT EDIT.
+// Generated at: 2026-10-16 23:24:19 (ver: 8824d78787)
+
+package account
+
+import (
+	field "github.com/kcmvp/xql/sample/gen/field/account"
+	"github.com/kcmvp/xql/view"
+)
+
+// CreateSchema validates the input creating Account rows: the columns the
+// database requires are required, the key and the columns that are nullable or
+// have a default are optional.
+var CreateSchema = view.WithXQLFields(
+	field.ID,
+	field.Email,
+	field.Nickname,
+	field.Category,
+	field.Balance,
+	field.CreatedAt,
+	field.UpdatedAt,
+	field.CreatedBy,
+	field.UpdatedBy,
+).Partial()
+
+// UpdateSchema validates the input updating Account rows: the key is required
+// and the other fields are optional.
+var UpdateSchema = view.WithXQLFields(
+	field.ID,
+).Extend(view.WithXQLFields(
+	field.Email,
+	field.Nickname,
+	field.Category,
+	field.Balance,
+	field.CreatedAt,
+	field.UpdatedAt,
+	field.CreatedBy,
+	field.UpdatedBy,
+).Partial())
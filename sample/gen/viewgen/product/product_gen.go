@@ -0,0 +1,37 @@
+// Code generated by gob xql schema. DO NOT EDIT.
+// Generated at: 2026-10-16 23:24:19 (ver: e5264078a6)
+
+package product
+
+import (
+	field "github.com/kcmvp/xql/sample/gen/field/product"
+	"github.com/kcmvp/xql/view"
+)
+
+// CreateSchema validates the input creating Product rows: the columns the
+// database requires are required, the key and the columns that are nullable or
+// have a default are optional.
+var CreateSchema = view.WithXQLFields(
+	field.ID,
+	field.SKU,
+	field.Name,
+	field.Price,
+	field.CreatedAt,
+	field.UpdatedAt,
+	field.CreatedBy,
+	field.UpdatedBy,
+).Partial()
+
+// UpdateSchema validates the input updating Product rows: the key is required
+// and the other fields are optional.
+var UpdateSchema = view.WithXQLFields(
+	field.ID,
+).Extend(view.WithXQLFields(
+	field.SKU,
+	field.Name,
+	field.Price,
+	field.CreatedAt,
+	field.UpdatedAt,
+	field.CreatedBy,
+	field.UpdatedBy,
+).Partial())
@@ -0,0 +1,47 @@
+package sqlx
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// AffectedRowsError reports that a statement guarded by MustAffect or
+// AffectAtLeast changed an unexpected number of rows. The statement has
+// already been applied when this error is returned; run the executor inside
+// a transaction when the change must be undone.
+type AffectedRowsError struct {
+	Expected int64
+	Actual   int64
+	// AtLeast is true when Expected is a lower bound (AffectAtLeast).
+	AtLeast bool
+}
+
+func (e *AffectedRowsError) Error() string {
+	if e.AtLeast {
+		return fmt.Sprintf("expected at least %d affected rows, got %d", e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("expected %d affected rows, got %d", e.Expected, e.Actual)
+}
+
+// affectCheck is the affected-rows post-condition carried by Update and
+// Delete executors. The zero value performs no check.
+type affectCheck struct {
+	enabled bool
+	n       int64
+	atLeast bool
+}
+
+// verify returns an *AffectedRowsError when res does not satisfy the check.
+func (a affectCheck) verify(res sql.Result) error {
+	if !a.enabled {
+		return nil
+	}
+	actual, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if actual == a.n || (a.atLeast && actual > a.n) {
+		return nil
+	}
+	return &AffectedRowsError{Expected: a.n, Actual: actual, AtLeast: a.atLeast}
+}
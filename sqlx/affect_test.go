@@ -0,0 +1,77 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/order"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+type rowsResult int64
+
+func (r rowsResult) LastInsertId() (int64, error) { return 0, nil }
+func (r rowsResult) RowsAffected() (int64, error) { return int64(r), nil }
+
+func TestAffectCheck(t *testing.T) {
+	cases := []struct {
+		name    string
+		check   affectCheck
+		actual  int64
+		wantErr string
+	}{
+		{"disabled", affectCheck{}, 7, ""},
+		{"exact match", affectCheck{enabled: true, n: 1}, 1, ""},
+		{"exact mismatch", affectCheck{enabled: true, n: 1}, 0, "expected 1 affected rows, got 0"},
+		{"exact above", affectCheck{enabled: true, n: 1}, 2, "expected 1 affected rows, got 2"},
+		{"at least equal", affectCheck{enabled: true, n: 2, atLeast: true}, 2, ""},
+		{"at least above", affectCheck{enabled: true, n: 2, atLeast: true}, 5, ""},
+		{"at least below", affectCheck{enabled: true, n: 2, atLeast: true}, 1, "expected at least 2 affected rows, got 1"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.check.verify(rowsResult(c.actual))
+			if c.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, c.wantErr)
+		})
+	}
+}
+
+func TestAffect_Delete(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("CREATE TABLE orders (id INTEGER PRIMARY KEY, amount REAL)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO orders (id, amount) VALUES (1, 1), (2, 2), (3, 3)")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = Delete[Order](Eq(order.ID, int64(1))).MustAffect(1).Execute(ctx, db)
+	require.NoError(t, err)
+
+	res, err := Delete[Order](Eq(order.ID, int64(1))).MustAffect(1).Execute(ctx, db)
+	var rowsErr *AffectedRowsError
+	require.True(t, errors.As(err, &rowsErr))
+	require.Equal(t, int64(1), rowsErr.Expected)
+	require.Equal(t, int64(0), rowsErr.Actual)
+	require.NotNil(t, res.MustRight())
+
+	_, err = Delete[Order](Gt(order.Amount, 0.0)).AffectAtLeast(3).Execute(ctx, db)
+	require.True(t, errors.As(err, &rowsErr))
+	require.True(t, rowsErr.AtLeast)
+	require.Equal(t, int64(2), rowsErr.Actual)
+
+	// error executors keep their error
+	_, err = Update[Order](nil, nil)(nil).MustAffect(1).Execute(ctx, db)
+	require.ErrorIs(t, err, ErrEmptySchema)
+}
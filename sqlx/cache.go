@@ -0,0 +1,119 @@
+package sqlx
+
+import (
+	"database/sql"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores SELECT results keyed by the datasource, the rendered SQL and its
+// arguments.
+//
+// Entries are tagged with the table they were read from so writers can drop
+// stale results with Invalidate. Implementations must be safe for concurrent
+// use; NewMemoryCache provides the in-process default and other stores
+// (Redis, memcached, ...) can be plugged in by implementing this interface.
+type Cache interface {
+	// Get returns the cached rows for key if present and not expired. The
+	// rows must not share state with the stored entry, so a caller mutating
+	// them cannot affect later readers.
+	Get(key string) ([]ValueObject, bool)
+	// Set stores a copy of rows for key, tagged with table, for the given ttl.
+	Set(key string, table string, rows []ValueObject, ttl time.Duration)
+	// Invalidate drops every entry tagged with one of the given tables.
+	Invalidate(tables ...string)
+}
+
+// DefaultCache is the in-memory cache used by QueryExecutor.Cached when no
+// cache is supplied.
+var DefaultCache Cache = NewMemoryCache()
+
+type cacheEntry struct {
+	table   string
+	rows    []ValueObject
+	expires time.Time
+}
+
+// memoryCache is a map-backed Cache. Expired entries are evicted lazily on Get.
+type memoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+	now     func() time.Time
+}
+
+// NewMemoryCache returns an empty in-memory Cache.
+func NewMemoryCache() Cache {
+	return &memoryCache{entries: map[string]cacheEntry{}, now: time.Now}
+}
+
+func (c *memoryCache) Get(key string) ([]ValueObject, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+		return nil, false
+	}
+	return copyRows(e.rows), true
+}
+
+func (c *memoryCache) Set(key string, table string, rows []ValueObject, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{table: table, rows: copyRows(rows), expires: c.now().Add(ttl)}
+}
+
+func (c *memoryCache) Invalidate(tables ...string) {
+	drop := make(map[string]struct{}, len(tables))
+	for _, t := range tables {
+		drop[t] = struct{}{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if _, ok := drop[e.table]; ok {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// copyRows returns rows with every value object cloned, so cached entries and
+// the slices handed to callers never alias each other.
+func copyRows(rows []ValueObject) []ValueObject {
+	if rows == nil {
+		return nil
+	}
+	out := make([]ValueObject, len(rows))
+	for i, row := range rows {
+		if vo, ok := row.(valueObject); ok {
+			out[i] = valueObject{Data: maps.Clone(vo.Data)}
+			continue
+		}
+		out[i] = row
+	}
+	return out
+}
+
+// cacheKey derives a stable key from the datasource, the SQL text and its
+// arguments. The datasource is identified by its handle so the same query
+// against two databases (for example two tenants) never shares an entry.
+func cacheKey(ds *sql.DB, query string, args []any) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%p\x00", ds))
+	b.WriteString(query)
+	for _, a := range args {
+		b.WriteString(fmt.Sprintf("\x00%T=%v", a, a))
+	}
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/order"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache_TTLAndInvalidate(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := &memoryCache{entries: map[string]cacheEntry{}, now: func() time.Time { return now }}
+	rows := []ValueObject{TupleValueObject()}
+
+	c.Set("k1", "orders", rows, time.Minute)
+	c.Set("k2", "accounts", rows, time.Minute)
+	c.Set("k3", "orders", rows, 0)
+
+	got, ok := c.Get("k1")
+	require.True(t, ok)
+	require.Len(t, got, 1)
+	_, ok = c.Get("k3")
+	require.False(t, ok, "zero ttl must not be cached")
+
+	c.Invalidate("orders")
+	_, ok = c.Get("k1")
+	require.False(t, ok)
+	_, ok = c.Get("k2")
+	require.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = c.Get("k2")
+	require.False(t, ok, "entry must expire after ttl")
+}
+
+func TestCacheKey(t *testing.T) {
+	db1, db2 := &sql.DB{}, &sql.DB{}
+	require.Equal(t, cacheKey(db1, "q", []any{1}), cacheKey(db1, "q", []any{1}))
+	require.NotEqual(t, cacheKey(db1, "q", []any{1}), cacheKey(db1, "q", []any{"1"}))
+	require.NotEqual(t, cacheKey(db1, "q", []any{1}), cacheKey(db1, "q2", []any{1}))
+	require.NotEqual(t, cacheKey(db1, "q", []any{1}), cacheKey(db2, "q", []any{1}))
+}
+
+func TestMemoryCache_CopiesRows(t *testing.T) {
+	c := NewMemoryCache()
+	amount := order.Amount.QualifiedName()
+	rows := []ValueObject{TupleValueObject(Tuple(*order.Amount, 10.0))}
+	c.Set("k", "orders", rows, time.Minute)
+
+	rows[0].Add("extra", 1)
+	got, ok := c.Get("k")
+	require.True(t, ok)
+	require.Equal(t, []string{amount}, got[0].Fields(), "mutating the stored slice must not leak into the cache")
+
+	got[0].Add("extra", 1)
+	got[0].Update(amount, 99.0)
+	again, ok := c.Get("k")
+	require.True(t, ok)
+	require.Equal(t, []string{amount}, again[0].Fields(), "mutating a cached hit must not leak to later readers")
+	require.Equal(t, 10.0, again[0].MstFloat64(amount))
+}
+
+func TestQuery_Cached(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec("CREATE TABLE orders (id INTEGER PRIMARY KEY, amount REAL)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO orders (id, amount) VALUES (1, 10)")
+	require.NoError(t, err)
+
+	cache := NewMemoryCache()
+	exec := Query[Order](Schema{order.ID, order.Amount})(Gt(order.Amount, 0.0)).Cached(cache, time.Minute)
+	count := func() int {
+		res, err := exec.Execute(context.Background(), db)
+		require.NoError(t, err)
+		return len(res.MustLeft())
+	}
+	require.Equal(t, 1, count())
+
+	_, err = db.Exec("INSERT INTO orders (id, amount) VALUES (2, 20)")
+	require.NoError(t, err)
+	require.Equal(t, 1, count(), "second call must be served from cache")
+
+	cache.Invalidate(Order{}.Table())
+	require.Equal(t, 2, count())
+}
+
+func TestQuery_CachedPerDatasource(t *testing.T) {
+	open := func(amount float64) *sql.DB {
+		db, err := sql.Open("sqlite3", "file::memory:")
+		require.NoError(t, err)
+		db.SetMaxOpenConns(1)
+		_, err = db.Exec("CREATE TABLE orders (id INTEGER PRIMARY KEY, amount REAL)")
+		require.NoError(t, err)
+		_, err = db.Exec("INSERT INTO orders (id, amount) VALUES (1, ?)", amount)
+		require.NoError(t, err)
+		return db
+	}
+	tenantA, tenantB := open(10), open(20)
+	defer func() { _ = tenantA.Close() }()
+	defer func() { _ = tenantB.Close() }()
+
+	exec := Query[Order](Schema{order.ID, order.Amount})(Gt(order.Amount, 0.0)).Cached(NewMemoryCache(), time.Minute)
+	amount := func(db *sql.DB) float64 {
+		res, err := exec.Execute(context.Background(), db)
+		require.NoError(t, err)
+		rows := res.MustLeft()
+		require.Len(t, rows, 1)
+		return rows[0].MstFloat64(order.Amount.QualifiedName())
+	}
+	require.Equal(t, 10.0, amount(tenantA))
+	require.Equal(t, 20.0, amount(tenantB), "a second datasource must not be served the first one's rows")
+	require.Equal(t, 10.0, amount(tenantA))
+}
@@ -0,0 +1,47 @@
+package sqlx
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+)
+
+// Dialect identifies the SQL flavour used when a statement needs
+// database-specific rendering (e.g. DELETE ... LIMIT). The values match the
+// adapter keys used by the generator (`drivers.json`).
+//
+// Most builders render portable SQL and ignore the dialect. Executors infer
+// the dialect from the driver backing the *sql.DB passed to Execute; `sql()`
+// renders with the zero value, which follows MySQL syntax.
+type Dialect string
+
+const (
+	MySQL    Dialect = "mysql"
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// dialectOf infers the dialect from the driver registered for ds. Unknown
+// drivers (or a nil ds) yield the zero Dialect.
+func dialectOf(ds *sql.DB) Dialect {
+	if ds == nil {
+		return ""
+	}
+	return dialectOfDriver(reflect.TypeOf(ds.Driver()).String())
+}
+
+// dialectOfDriver maps a driver type name such as "*mysql.MySQLDriver" or
+// "*pq.Driver" to a Dialect.
+func dialectOfDriver(name string) Dialect {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "mysql"):
+		return MySQL
+	case strings.HasPrefix(name, "*pq."), strings.Contains(name, "pgx"), strings.Contains(name, "postgres"):
+		return Postgres
+	case strings.Contains(name, "sqlite"):
+		return SQLite
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,56 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/order"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialectOfDriver(t *testing.T) {
+	cases := []struct {
+		driver string
+		want   Dialect
+	}{
+		{"*mysql.MySQLDriver", MySQL},
+		{"*pq.Driver", Postgres},
+		{"*stdlib.Driver(pgx)", Postgres},
+		{"*sqlite3.SQLiteDriver", SQLite},
+		{"*sqlite.Driver", SQLite},
+		{"*mssql.Driver", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.driver, func(t *testing.T) {
+			require.Equal(t, c.want, dialectOfDriver(c.driver))
+		})
+	}
+}
+
+func TestDeleteLimit_SQLite(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+	require.Equal(t, SQLite, dialectOf(db))
+
+	_, err = db.Exec("CREATE TABLE orders (id INTEGER PRIMARY KEY, amount REAL)")
+	require.NoError(t, err)
+	for i := 1; i <= 5; i++ {
+		_, err = db.Exec("INSERT INTO orders (id, amount) VALUES (?, ?)", i, float64(i))
+		require.NoError(t, err)
+	}
+
+	res, err := Delete[Order](Lt(order.Amount, 10.0)).Limit(2).Execute(context.Background(), db)
+	require.NoError(t, err)
+	n, err := res.MustRight().RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	var left int
+	require.NoError(t, db.QueryRow("SELECT COUNT(1) FROM orders").Scan(&left))
+	require.Equal(t, 3, left)
+}
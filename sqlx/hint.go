@@ -0,0 +1,71 @@
+package sqlx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// IndexHint guides the planner towards (or away from) specific indexes. It is
+// rendered right after the table name in the FROM clause.
+//
+// Hints are dialect-gated: MySQL (and the zero Dialect) render them verbatim,
+// SQLite renders a single-index UseIndex/ForceIndex as `INDEXED BY`, and
+// Postgres, which has no hint syntax, drops them. A hint is therefore a
+// performance nudge, never a semantic change.
+type IndexHint struct {
+	kind    string
+	indexes []string
+}
+
+// UseIndex renders `USE INDEX (idx, ...)`.
+func UseIndex(indexes ...string) IndexHint {
+	return newIndexHint("USE", indexes)
+}
+
+// ForceIndex renders `FORCE INDEX (idx, ...)`.
+func ForceIndex(indexes ...string) IndexHint {
+	return newIndexHint("FORCE", indexes)
+}
+
+// IgnoreIndex renders `IGNORE INDEX (idx, ...)`.
+func IgnoreIndex(indexes ...string) IndexHint {
+	return newIndexHint("IGNORE", indexes)
+}
+
+func newIndexHint(kind string, indexes []string) IndexHint {
+	lo.Assertf(len(indexes) > 0, "sqlx: %s INDEX hint requires at least one index", kind)
+	for _, idx := range indexes {
+		lo.Assertf(idx != "" && !strings.ContainsAny(idx, " ,;()'\""), "sqlx: invalid index name %q", idx)
+	}
+	return IndexHint{kind: kind, indexes: indexes}
+}
+
+// render returns the hint fragment for dialect, or "" when unsupported.
+func (h IndexHint) render(dialect Dialect) string {
+	switch dialect {
+	case Postgres:
+		return ""
+	case SQLite:
+		if h.kind == "IGNORE" || len(h.indexes) != 1 {
+			return ""
+		}
+		return "INDEXED BY " + h.indexes[0]
+	default:
+		return fmt.Sprintf("%s INDEX (%s)", h.kind, strings.Join(h.indexes, ", "))
+	}
+}
+
+// indexHintClause joins the rendered hints supported by dialect.
+func indexHintClause(hints []IndexHint, dialect Dialect) string {
+	parts := lo.FilterMap(hints, func(h IndexHint, _ int) (string, bool) {
+		r := h.render(dialect)
+		return r, r != ""
+	})
+	if dialect == SQLite && len(parts) > 1 {
+		// SQLite accepts a single INDEXED BY clause.
+		parts = parts[:1]
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,45 @@
+package sqlx
+
+import (
+	"testing"
+
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/order"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexHint_Render(t *testing.T) {
+	cases := []struct {
+		name    string
+		hints   []IndexHint
+		dialect Dialect
+		expect  string
+	}{
+		{"MySQL_Use", []IndexHint{UseIndex("idx_orders_account")}, MySQL, "USE INDEX (idx_orders_account)"},
+		{"MySQL_ForceIgnore", []IndexHint{ForceIndex("a", "b"), IgnoreIndex("c")}, MySQL, "FORCE INDEX (a, b) IGNORE INDEX (c)"},
+		{"Default_Use", []IndexHint{UseIndex("a")}, "", "USE INDEX (a)"},
+		{"SQLite_Use", []IndexHint{UseIndex("a")}, SQLite, "INDEXED BY a"},
+		{"SQLite_Ignore", []IndexHint{IgnoreIndex("a")}, SQLite, ""},
+		{"SQLite_Multiple", []IndexHint{UseIndex("a", "b")}, SQLite, ""},
+		{"Postgres", []IndexHint{UseIndex("a")}, Postgres, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expect, indexHintClause(c.hints, c.dialect))
+		})
+	}
+
+	require.Panics(t, func() { UseIndex() })
+	require.Panics(t, func() { UseIndex("a; DROP TABLE orders") })
+}
+
+func TestQuery_Hint(t *testing.T) {
+	exec := Query[Order](Schema{order.ID})(Eq(order.AccountID, 1)).Hint(UseIndex("idx_orders_account"))
+	q, err := exec.sql()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT orders.id AS orders__id FROM orders USE INDEX (idx_orders_account) WHERE orders.account_id = ?", q)
+
+	pg, _, err := exec.(queryExec[Order]).build(Postgres)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT orders.id AS orders__id FROM orders WHERE orders.account_id = ?", pg)
+}
@@ -0,0 +1,184 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kcmvp/xql"
+	"github.com/kcmvp/xql/entity"
+	"github.com/kcmvp/xql/internal"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// now returns the time the builders set AutoCreateTime and AutoUpdateTime
+// columns to; tests replace it.
+var now = time.Now
+
+// InsertExecutor is the Executor returned by Insert.
+type InsertExecutor interface {
+	Executor
+	Timeout(d time.Duration) InsertExecutor
+}
+
+// Insert builds a single-row INSERT for T from values keyed by qualified
+// names ("table.column" or "table.column.view"), such as the FlatMap of a
+// validated view ValueObject:
+//
+//	exec := Insert[Account](MapValueObject(vo.FlatMap()), account.Email, account.Nickname)
+//	_, err := exec.Execute(ctx, db)
+//
+// Every key must belong to T's table. required lists the NOT NULL columns
+// without a database default; the statement fails with ErrMissingColumn
+// when one of them has no value or a null value.
+func Insert[T entity.Entity](values ValueObject, required ...xql.Field) InsertExecutor {
+	if err := validateSyntax[T](required...); err != nil {
+		return errorExecutorInsert{errorExecutorNonSelect{err: err}}
+	}
+	return insertExec[T]{values: values, required: required}
+}
+
+type insertExec[T entity.Entity] struct {
+	values   ValueObject
+	required []xql.Field
+	timeout  time.Duration
+}
+
+// Timeout returns a copy of the executor whose statement is bounded by d.
+func (i insertExec[T]) Timeout(d time.Duration) InsertExecutor {
+	i.timeout = d
+	return i
+}
+
+func (i insertExec[T]) Execute(ctx context.Context, ds *sql.DB) (mo.Either[[]ValueObject, sql.Result], error) {
+	if ds == nil {
+		return mo.Right[[]ValueObject, sql.Result](nil), ErrMissingDB
+	}
+	q, args, err := insertSQL[T](i.values, i.required)
+	if err != nil {
+		return mo.Right[[]ValueObject, sql.Result](nil), err
+	}
+	res, err := execStatement(ctx, ds, i.timeout, q, args)
+	return mo.Right[[]ValueObject, sql.Result](res), err
+}
+
+func (i insertExec[T]) sql() (string, error) {
+	q, _, err := insertSQL[T](i.values, i.required)
+	return q, err
+}
+
+// errorExecutorInsert is the InsertExecutor counterpart of errorExecutorNonSelect.
+type errorExecutorInsert struct{ errorExecutorNonSelect }
+
+func (e errorExecutorInsert) Timeout(time.Duration) InsertExecutor { return e }
+
+// insertSQL builds an INSERT statement from the qualified keys of values,
+// with columns in a stable order.
+func insertSQL[T entity.Entity](values ValueObject, required []xql.Field) (string, []any, error) {
+	if values == nil {
+		return "", nil, ErrNoValues
+	}
+	var ent T
+	table := ent.Table()
+	if strings.TrimSpace(table) == "" {
+		return "", nil, ErrEmptyTable
+	}
+	keys := values.Fields()
+	slices.Sort(keys)
+	present := map[string]bool{}
+	columns := make([]string, 0, len(keys))
+	args := make([]any, 0, len(keys))
+	for _, k := range keys {
+		q := dbQualifiedNameFromQName(k)
+		i := strings.LastIndex(q, ".")
+		if i < 0 {
+			return "", nil, fmt.Errorf("unqualified value key %q is not allowed in this context; use a fully-qualified key 'table.column'", k)
+		}
+		if q[:i] != table {
+			return "", nil, fmt.Errorf("%w: field %q belongs to table %q, expected %q", ErrCrossTableField, k, q[:i], table)
+		}
+		if _, dup := present[q]; dup {
+			return "", nil, fmt.Errorf("column %q is set more than once", q)
+		}
+		v, err := jsonArg(rawValue(values, k))
+		if err != nil {
+			return "", nil, fmt.Errorf("column %q: %w", q, err)
+		}
+		present[q] = v != nil
+		columns = append(columns, q[i+1:])
+		args = append(args, v)
+	}
+	// timestamps maintained by the builders are set unless given
+	for _, lookup := range []func(string) (xql.Field, bool){xql.AutoCreateTimeField, xql.AutoUpdateTimeField} {
+		if f, ok := lookup(table); ok {
+			q := dbQualifiedNameFromQName(f.QualifiedName())
+			if _, given := present[q]; !given {
+				present[q] = true
+				columns = append(columns, q[strings.LastIndex(q, ".")+1:])
+				args = append(args, now())
+			}
+		}
+	}
+	for _, f := range required {
+		if q := dbQualifiedNameFromQName(f.QualifiedName()); !present[q] {
+			return "", nil, fmt.Errorf("%w: %s", ErrMissingColumn, q)
+		}
+	}
+	if len(columns) == 0 {
+		return "", nil, ErrNoValues
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), placeholders), args, nil
+}
+
+// rawValue returns the value stored under key, nil for a null value.
+func rawValue(values ValueObject, key string) any {
+	if vo, ok := values.(valueObject); ok {
+		if v, ok := vo.Data[key]; ok {
+			return lo.Ternary(v == internal.Null, nil, v)
+		}
+	}
+	return values.Get(key).OrEmpty()
+}
+
+// jsonArg serializes the document bound for a json or jsonb column: maps,
+// slices and structs are marshaled to JSON text and json.RawMessage values,
+// such as view.DocumentField produces, are passed as text. Pointers are
+// dereferenced, a nil pointer binding NULL. Other values, []byte, time.Time
+// and driver.Valuer implementations are returned as is.
+func jsonArg(v any) (any, error) {
+	switch v := v.(type) {
+	case nil, []byte, time.Time, driver.Valuer:
+		return v, nil
+	case json.RawMessage:
+		return string(v), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		// nullable columns are bound from pointers
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return jsonArg(rv.Elem().Interface())
+	case reflect.Map, reflect.Struct:
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return v, nil
+		}
+	default:
+		return v, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal json document: %w", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,152 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kcmvp/xql"
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/account"
+	"github.com/kcmvp/xql/sample/gen/field/order"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   ValueObject
+		wantSQL  string
+		wantArgs []any
+		wantErr  error
+		errText  string
+	}{
+		{
+			name:     "qualified keys with view",
+			values:   MapValueObject(FlatMap{"accounts.email.Email": "a@b.c", "accounts.nick_name.Nickname": "bob"}),
+			wantSQL:  "INSERT INTO accounts (email, nick_name) VALUES (?, ?)",
+			wantArgs: []any{"a@b.c", "bob"},
+		},
+		{
+			name:     "null value",
+			values:   MapValueObject(FlatMap{"accounts.email": "a@b.c", "accounts.balance": nil}),
+			wantSQL:  "INSERT INTO accounts (balance, email) VALUES (?, ?)",
+			wantArgs: []any{nil, "a@b.c"},
+		},
+		{
+			name:     "json documents",
+			values:   MapValueObject(FlatMap{"accounts.email": json.RawMessage(`{"a":1}`), "accounts.nick_name": map[string]any{"tags": []string{"x"}}}),
+			wantSQL:  "INSERT INTO accounts (email, nick_name) VALUES (?, ?)",
+			wantArgs: []any{`{"a":1}`, `{"tags":["x"]}`},
+		},
+		{
+			name:     "pointers",
+			values:   MapValueObject(FlatMap{"accounts.email": lo.ToPtr("a@b.c"), "accounts.nick_name": (*string)(nil)}),
+			wantSQL:  "INSERT INTO accounts (email, nick_name) VALUES (?, ?)",
+			wantArgs: []any{"a@b.c", nil},
+		},
+		{
+			name:    "unmarshalable document",
+			values:  MapValueObject(FlatMap{"accounts.email": map[string]any{"f": func() {}}}),
+			errText: `column "accounts.email": marshal json document: json: unsupported type: func()`,
+		},
+		{
+			name:    "cross table",
+			values:  MapValueObject(FlatMap{"orders.amount": 1.0}),
+			wantErr: ErrCrossTableField,
+		},
+		{
+			name:    "duplicate column",
+			values:  MapValueObject(FlatMap{"accounts.email.Email": "a", "accounts.email.Mail": "b"}),
+			errText: `column "accounts.email" is set more than once`,
+		},
+		{
+			name:    "nil values",
+			wantErr: ErrNoValues,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q, args, err := insertSQL[Account](tc.values, nil)
+			switch {
+			case tc.wantErr != nil:
+				require.ErrorIs(t, err, tc.wantErr)
+			case tc.errText != "":
+				require.EqualError(t, err, tc.errText)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tc.wantSQL, q)
+				require.Equal(t, tc.wantArgs, args)
+			}
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec("CREATE TABLE accounts (id INTEGER PRIMARY KEY, email TEXT NOT NULL, nick_name TEXT NOT NULL, balance REAL)")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	values := MapValueObject(FlatMap{"accounts.email.Email": "a@b.c", "accounts.nick_name.Nickname": "bob"})
+	res, err := Insert[Account](values, account.Email, account.Nickname).Execute(ctx, db)
+	require.NoError(t, err)
+	n, err := res.MustRight().RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	_, err = Insert[Account](MapValueObject(FlatMap{"accounts.email.Email": "a@b.c"}), account.Email, account.Nickname).Execute(ctx, db)
+	require.ErrorIs(t, err, ErrMissingColumn)
+
+	_, err = Insert[Account](values, order.Amount).Timeout(0).Execute(ctx, db)
+	require.ErrorIs(t, err, ErrCrossTableField)
+
+	_, err = Insert[Account](values).Execute(ctx, nil)
+	require.ErrorIs(t, err, ErrMissingDB)
+}
+
+type article struct{}
+
+func (article) Table() string { return "articles" }
+
+func TestAutoTime(t *testing.T) {
+	title := xql.NewField[article, string]("title", "Title")
+	created := xql.NewField[article, time.Time]("created_at", "CreatedAt").AutoCreateTime()
+	updated := xql.NewField[article, time.Time]("updated_at", "UpdatedAt").AutoUpdateTime()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer func(fn func() time.Time) { now = fn }(now)
+	now = func() time.Time { return at }
+
+	q, args, err := insertSQL[article](MapValueObject(FlatMap{title.QualifiedName(): "a"}), nil)
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO articles (title, created_at, updated_at) VALUES (?, ?, ?)", q)
+	require.Equal(t, []any{"a", at, at}, args)
+
+	given := at.Add(-time.Hour)
+	q, args, err = insertSQL[article](MapValueObject(FlatMap{title.QualifiedName(): "a", created.QualifiedName(): given}), nil)
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO articles (created_at, title, updated_at) VALUES (?, ?, ?)", q)
+	require.Equal(t, []any{given, "a", at}, args)
+
+	where := Eq(title, "a")
+	q, args, err = updateSQLFromValues[article](MapValueObject(FlatMap{title.QualifiedName(): "b"}), where)
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE articles SET articles.title = ?, articles.updated_at = ? WHERE articles.title = ?", q)
+	require.Equal(t, []any{"b", at, "a"}, args)
+
+	q, args, err = updateSQL[article](Schema{title, updated}, MapValueObject(FlatMap{title.QualifiedName(): "b", updated.QualifiedName(): given}), where)
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE articles SET articles.title = ?, articles.updated_at = ? WHERE articles.title = ?", q)
+	require.Equal(t, []any{"b", given, "a"}, args)
+
+	// the update time alone does not make an update
+	_, _, err = updateSQL[article](Schema{title}, MapValueObject(FlatMap{updated.QualifiedName(): given}), where)
+	require.ErrorIs(t, err, ErrNoValues)
+}
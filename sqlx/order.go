@@ -0,0 +1,79 @@
+package sqlx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kcmvp/xql"
+	"github.com/samber/lo"
+)
+
+type nullsOrder int
+
+const (
+	nullsDefault nullsOrder = iota
+	nullsFirst
+	nullsLast
+)
+
+// Sort is a single ORDER BY term produced by Asc or Desc.
+//
+// NullsFirst/NullsLast pin the position of NULL values so ordering is
+// deterministic across databases: Postgres and SQLite render the standard
+// `NULLS FIRST|LAST` modifier, MySQL (which lacks it) is emulated with a
+// leading `ISNULL(col)` sort key.
+type Sort struct {
+	field xql.Field
+	desc  bool
+	nulls nullsOrder
+}
+
+// Asc sorts by field in ascending order.
+func Asc(field xql.Field) Sort {
+	return Sort{field: field}
+}
+
+// Desc sorts by field in descending order.
+func Desc(field xql.Field) Sort {
+	return Sort{field: field, desc: true}
+}
+
+// NullsFirst places NULL values before non-NULL values.
+func (s Sort) NullsFirst() Sort {
+	s.nulls = nullsFirst
+	return s
+}
+
+// NullsLast places NULL values after non-NULL values.
+func (s Sort) NullsLast() Sort {
+	s.nulls = nullsLast
+	return s
+}
+
+// render returns the ORDER BY fragment for this term in the given dialect.
+func (s Sort) render(dialect Dialect) string {
+	col := dbQualifiedNameFromQName(s.field.QualifiedName())
+	term := fmt.Sprintf("%s %s", col, lo.Ternary(s.desc, "DESC", "ASC"))
+	if s.nulls == nullsDefault {
+		return term
+	}
+	switch dialect {
+	case Postgres, SQLite:
+		return fmt.Sprintf("%s NULLS %s", term, lo.Ternary(s.nulls == nullsFirst, "FIRST", "LAST"))
+	default:
+		// ISNULL(col) is 1 for NULL rows: DESC puts them first, ASC last.
+		return fmt.Sprintf("ISNULL(%s) %s, %s", col, lo.Ternary(s.nulls == nullsFirst, "DESC", "ASC"), term)
+	}
+}
+
+// orderByClause renders " ORDER BY ..." for sorts, or "" when there are none.
+func orderByClause(sorts []Sort, dialect Dialect) string {
+	if len(sorts) == 0 {
+		return ""
+	}
+	terms := make([]string, 0, len(sorts))
+	for _, s := range sorts {
+		terms = append(terms, s.render(dialect))
+	}
+	return " ORDER BY " + strings.Join(terms, ", ")
+}
@@ -0,0 +1,51 @@
+package sqlx
+
+import (
+	"testing"
+
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/account"
+	"github.com/kcmvp/xql/sample/gen/field/order"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSort_Render(t *testing.T) {
+	cases := []struct {
+		name    string
+		sort    Sort
+		dialect Dialect
+		expect  string
+	}{
+		{"Asc", Asc(order.Amount), Postgres, "orders.amount ASC"},
+		{"Desc", Desc(order.Amount), MySQL, "orders.amount DESC"},
+		{"Postgres_NullsFirst", Asc(order.Amount).NullsFirst(), Postgres, "orders.amount ASC NULLS FIRST"},
+		{"Postgres_NullsLast", Desc(order.Amount).NullsLast(), Postgres, "orders.amount DESC NULLS LAST"},
+		{"SQLite_NullsLast", Asc(order.Amount).NullsLast(), SQLite, "orders.amount ASC NULLS LAST"},
+		{"MySQL_NullsFirst", Asc(order.Amount).NullsFirst(), MySQL, "ISNULL(orders.amount) DESC, orders.amount ASC"},
+		{"MySQL_NullsLast", Desc(order.Amount).NullsLast(), MySQL, "ISNULL(orders.amount) ASC, orders.amount DESC"},
+		{"Default_NullsLast", Desc(order.Amount).NullsLast(), "", "ISNULL(orders.amount) ASC, orders.amount DESC"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expect, c.sort.render(c.dialect))
+		})
+	}
+}
+
+func TestQuery_OrderBy(t *testing.T) {
+	exec := Query[Order](Schema{order.ID, order.Amount})(Gt(order.Amount, 1.0)).
+		OrderBy(Desc(order.Amount).NullsLast(), Asc(order.ID))
+	q, err := exec.sql()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT orders.id AS orders__id, orders.amount AS orders__amount FROM orders WHERE orders.amount > ? "+
+		"ORDER BY ISNULL(orders.amount) ASC, orders.amount DESC, orders.id ASC", q)
+
+	pg, _, err := exec.(queryExec[Order]).build(Postgres)
+	require.NoError(t, err)
+	require.Contains(t, pg, "ORDER BY orders.amount DESC NULLS LAST, orders.id ASC")
+
+	t.Run("CrossTableField_should_error", func(t *testing.T) {
+		_, err := Query[Order](Schema{order.ID})(nil).OrderBy(Asc(account.Email)).sql()
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,22 @@
+package sqlx
+
+import "fmt"
+
+// Page selects one page of a query result. Pages are numbered from 1; a
+// Number below 1 reads as the first page and a non-positive Size disables
+// paging. Order the query as well, so pages do not overlap:
+//
+//	exec := Query[Order](schema)(where).OrderBy(Asc(order.ID)).Paginate(Page{Number: 3, Size: 20})
+type Page struct {
+	Number int
+	Size   int
+}
+
+// clause renders " LIMIT n OFFSET m" for the page, or "" when paging is
+// disabled. The values are integers and rendered inline on every dialect.
+func (p Page) clause() string {
+	if p.Size <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", p.Size, max(p.Number-1, 0)*p.Size)
+}
@@ -0,0 +1,29 @@
+package sqlx
+
+import (
+	"testing"
+
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/order"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_Paginate(t *testing.T) {
+	cases := []struct {
+		name   string
+		page   Page
+		expect string
+	}{
+		{"FirstPage", Page{Number: 1, Size: 20}, " ORDER BY orders.id ASC LIMIT 20 OFFSET 0"},
+		{"ThirdPage", Page{Number: 3, Size: 20}, " ORDER BY orders.id ASC LIMIT 20 OFFSET 40"},
+		{"ZeroNumber", Page{Size: 5}, " ORDER BY orders.id ASC LIMIT 5 OFFSET 0"},
+		{"NoSize", Page{Number: 2}, " ORDER BY orders.id ASC"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := Query[Order](Schema{order.ID})(nil).OrderBy(Asc(order.ID)).Paginate(c.page).sql()
+			require.NoError(t, err)
+			require.Equal(t, "SELECT orders.id AS orders__id FROM orders"+c.expect, q)
+		})
+	}
+}
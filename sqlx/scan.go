@@ -0,0 +1,65 @@
+package sqlx
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kcmvp/xql"
+)
+
+// Scan reads the value of field from a row returned by a query executor and
+// converts it to V the way database/sql converts scanned columns, so an
+// int64 column reads into an int32 and TEXT into a string. NULL, or a field
+// missing from the row, gives the zero value of V: nil for pointers. Struct,
+// map and slice values other than []byte and time.Time are decoded from
+// their json document:
+//
+//	rows := res.MustLeft()
+//	email, err := Scan[string](rows[0], account.Email)
+func Scan[V any](row ValueObject, field xql.Field) (V, error) {
+	var v V
+	raw, ok := row.Get(field.QualifiedName()).Get()
+	if !ok {
+		return v, nil
+	}
+	if isDocument(reflect.TypeFor[V]()) {
+		var doc []byte
+		switch raw := raw.(type) {
+		case string:
+			doc = []byte(raw)
+		case []byte:
+			doc = raw
+		default:
+			return v, fmt.Errorf("scan %s: json document stored as %T", field.QualifiedName(), raw)
+		}
+		if err := json.Unmarshal(doc, &v); err != nil {
+			return v, fmt.Errorf("scan %s: %w", field.QualifiedName(), err)
+		}
+		return v, nil
+	}
+	var n sql.Null[V]
+	if err := n.Scan(raw); err != nil {
+		return v, fmt.Errorf("scan %s: %w", field.QualifiedName(), err)
+	}
+	return n.V, nil
+}
+
+// isDocument reports whether t, or the type it points to, is stored as a
+// json document.
+func isDocument(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Struct:
+		return t != reflect.TypeFor[time.Time]()
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
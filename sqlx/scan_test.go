@@ -0,0 +1,62 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kcmvp/xql"
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/account"
+	"github.com/kcmvp/xql/sample/gen/field/order"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec("CREATE TABLE orders (id INTEGER PRIMARY KEY, account_id INTEGER, amount REAL)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO orders (id, account_id, amount) VALUES (1, NULL, 10.5)")
+	require.NoError(t, err)
+
+	res, err := Query[Order](Schema{order.ID, order.AccountID, order.Amount})(nil).Execute(context.Background(), db)
+	require.NoError(t, err)
+	row := res.MustLeft()[0]
+
+	id, err := Scan[int32](row, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), id)
+	amount, err := Scan[float64](row, order.Amount)
+	require.NoError(t, err)
+	require.Equal(t, 10.5, amount)
+	accountID, err := Scan[*int64](row, order.AccountID)
+	require.NoError(t, err)
+	require.Nil(t, accountID)
+	zero, err := Scan[int64](row, order.AccountID)
+	require.NoError(t, err)
+	require.Zero(t, zero)
+	missing, err := Scan[string](row, order.CreatedBy)
+	require.NoError(t, err)
+	require.Empty(t, missing)
+	_, err = Scan[bool](row, order.Amount)
+	require.ErrorContains(t, err, "scan orders.amount")
+
+	t.Run("json documents", func(t *testing.T) {
+		settings := xql.NewDocumentField[Account]("settings", "Settings").Object()
+		row := MapValueObject(FlatMap{settings.QualifiedName(): `{"theme":"dark"}`, account.Email.QualifiedName(): []byte(`["a"]`)})
+		doc, err := Scan[map[string]string](row, settings)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"theme": "dark"}, doc)
+		ptr, err := Scan[*struct{ Theme string }](row, settings)
+		require.NoError(t, err)
+		require.Equal(t, "dark", ptr.Theme)
+		list, err := Scan[[]string](row, account.Email)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, list)
+		_, err = Scan[[]int](row, settings)
+		require.ErrorContains(t, err, "scan accounts.settings")
+	})
+}
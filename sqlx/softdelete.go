@@ -0,0 +1,59 @@
+package sqlx
+
+import (
+	"fmt"
+
+	"github.com/kcmvp/xql"
+	"github.com/kcmvp/xql/entity"
+)
+
+// softDeleteField returns the soft-delete column of T, see xql.SoftDelete.
+func softDeleteField[T entity.Entity]() (xql.Field, bool) {
+	var ent T
+	return xql.SoftDeleteField(ent.Table())
+}
+
+// NotDeleted scopes where to the rows of T that are not soft-deleted, by
+// adding "deleted_at IS NULL" for the column registered with xql.SoftDelete.
+// where is returned as is when T has no soft-delete column; a nil where
+// selects every live row.
+//
+//	exec := Query[Post](schema)(NotDeleted[Post](Eq(post.AuthorID, id)))
+func NotDeleted[T entity.Entity](where Where) Where {
+	f, ok := softDeleteField[T]()
+	if !ok {
+		return where
+	}
+	live := whereFunc{
+		f: func() (string, []any) {
+			return fmt.Sprintf("%s IS NULL", dbQualifiedNameFromQName(f.QualifiedName())), nil
+		},
+		flds: []xql.Field{f},
+	}
+	if where == nil {
+		return live
+	}
+	return and(where, live)
+}
+
+// SoftDelete builds an UPDATE that marks the live rows of T matching where
+// as deleted, setting their soft-delete column to the time the executor is
+// built. Rows already deleted keep their deletion time. It fails with
+// ErrNoSoftDelete when T has no soft-delete column, and like Delete requires
+// a non-empty where.
+//
+//	exec := SoftDelete[Post](Eq(post.ID, id)).MustAffect(1)
+func SoftDelete[T entity.Entity](where Where) UpdateExecutor {
+	f, ok := softDeleteField[T]()
+	if !ok {
+		var ent T
+		return errorExecutorUpdate{errorExecutorNonSelect{err: fmt.Errorf("%w: %s", ErrNoSoftDelete, ent.Table())}}
+	}
+	if where == nil {
+		return errorExecutorUpdate{errorExecutorNonSelect{err: ErrMissingWhere}}
+	}
+	if clause, _ := where.Build(); clause == "" {
+		return errorExecutorUpdate{errorExecutorNonSelect{err: ErrMissingWhere}}
+	}
+	return Update[T](Schema{f}, MapValueObject(FlatMap{f.QualifiedName(): now()}))(NotDeleted[T](where))
+}
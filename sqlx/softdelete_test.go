@@ -0,0 +1,61 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kcmvp/xql"
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/order"
+	"github.com/stretchr/testify/require"
+)
+
+type post struct{}
+
+func (post) Table() string { return "posts" }
+
+func TestSoftDelete(t *testing.T) {
+	id := xql.NewField[post, int64]("id", "ID")
+	deleted := xql.NewField[post, time.Time]("deleted_at", "DeletedAt").Nullable().SoftDelete()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer func(fn func() time.Time) { now = fn }(now)
+	now = func() time.Time { return at }
+
+	clause, args := NotDeleted[post](Eq(id, int64(1))).Build()
+	require.Equal(t, "(posts.id = ? AND posts.deleted_at IS NULL)", clause)
+	require.Equal(t, []any{int64(1)}, args)
+	clause, _ = NotDeleted[post](nil).Build()
+	require.Equal(t, "posts.deleted_at IS NULL", clause)
+	// entities without a soft-delete column are left unscoped
+	clause, _ = NotDeleted[Order](Gt(order.Amount, 0.0)).Build()
+	require.Equal(t, "orders.amount > ?", clause)
+
+	q, err := SoftDelete[post](Eq(id, int64(1))).sql()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE posts SET posts.deleted_at = ? WHERE (posts.id = ? AND posts.deleted_at IS NULL)", q)
+
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec("CREATE TABLE posts (id INTEGER PRIMARY KEY, deleted_at TIMESTAMP)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO posts (id, deleted_at) VALUES (1, ?), (2, NULL)", at)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	res, err := Query[post](Schema{id, deleted})(NotDeleted[post](nil)).Execute(ctx, db)
+	require.NoError(t, err)
+	rows := res.MustLeft()
+	require.Len(t, rows, 1)
+	require.Equal(t, int64(2), rows[0].MstInt64(id.QualifiedName()))
+
+	_, err = SoftDelete[post](nil).Execute(ctx, db)
+	require.ErrorIs(t, err, ErrMissingWhere)
+	_, err = SoftDelete[post](And()).Execute(ctx, db)
+	require.ErrorIs(t, err, ErrMissingWhere)
+	_, err = SoftDelete[Order](Eq(order.ID, int64(1))).Execute(ctx, db)
+	require.ErrorIs(t, err, ErrNoSoftDelete)
+}
@@ -3,8 +3,9 @@ package sqlx
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"errors"
 	"strings"
+	"time"
 
 	"github.com/kcmvp/xql"
 	"github.com/kcmvp/xql/entity"
@@ -48,6 +49,32 @@ type Where interface {
 
 type Schema []xql.Field
 
+// Sentinel errors returned by the builders and executors. Callers should
+// branch with errors.Is; the messages may carry extra context.
+var (
+	// ErrMissingWhere is returned when UPDATE/DELETE is built without a
+	// non-empty where clause.
+	ErrMissingWhere = errors.New("where is required")
+	// ErrEmptySchema is returned when a builder requiring a projection or SET
+	// list receives a nil or empty Schema.
+	ErrEmptySchema = errors.New("schema is required and must contain at least one field")
+	// ErrCrossTableField is returned when a field referenced by a schema or
+	// where clause belongs to a table other than the entity being queried.
+	ErrCrossTableField = errors.New("field belongs to another table")
+	// ErrEmptyTable is returned when the entity's Table() is blank.
+	ErrEmptyTable = errors.New("entity table is empty")
+	// ErrNoValues is returned when an UPDATE resolves no column values.
+	ErrNoValues = errors.New("no fields to update")
+	// ErrMissingDB is returned when Execute is called with a nil *sql.DB.
+	ErrMissingDB = errors.New("db is required")
+	// ErrMissingColumn is returned when an INSERT lacks a value for a column
+	// declared required.
+	ErrMissingColumn = errors.New("required column is missing")
+	// ErrNoSoftDelete is returned by SoftDelete when the entity has no
+	// soft-delete column.
+	ErrNoSoftDelete = errors.New("entity has no soft-delete column")
+)
+
 // --- Where DSL helpers (public) ---
 
 // And combines multiple Where conditions with the AND operator.
@@ -121,6 +148,23 @@ func In(field xql.Field, values ...any) Where {
 //
 // Note: `sql()` is kept pure and only returns the generated SQL string and
 // an error. It is primarily useful for testing and inspection.
+//
+// Statement timeouts: the single-table executors expose `Timeout(d)`, which
+// bounds one statement without touching pool-wide settings. Execute wraps
+// ctx with a d deadline; on Postgres it also runs the statement in a short
+// transaction that issues `SET LOCAL statement_timeout`, so the server stops
+// the work as well. A non-positive d disables the timeout.
+//
+//	exec := Query[Account](schema)(where).Timeout(2 * time.Second)
+//
+// Affected rows: Update and Delete executors expose `MustAffect(n)` and
+// `AffectAtLeast(n)`. When the statement changes an unexpected number of
+// rows, Execute still returns the sql.Result together with an
+// *AffectedRowsError:
+//
+//	_, err := Update[Account](schema, values)(Eq(account.ID, id)).MustAffect(1).Execute(ctx, db)
+//	var rowsErr *AffectedRowsError
+//	if errors.As(err, &rowsErr) { /* not found */ }
 type Executor interface {
 	Execute(ctx context.Context, ds *sql.DB) (mo.Either[[]ValueObject, sql.Result], error)
 	// sql generates the SQL string only (pure). Arguments are produced by lower-level helpers
@@ -128,20 +172,37 @@ type Executor interface {
 	sql() (string, error)
 }
 
+// QueryExecutor is the Executor returned by Query. OrderBy appends an
+// ORDER BY clause built from the given sort terms, in order. Cached serves
+// results from cache (DefaultCache when nil) for ttl; use Cache.Invalidate
+// with the entity table after writes to drop stale entries. Hint attaches
+// index hints rendered after the table name on dialects that support them.
+// Paginate limits the result to one Page.
+//
+// Timeout bounds the statement (see "Statement timeouts" above).
+type QueryExecutor interface {
+	Executor
+	OrderBy(sorts ...Sort) QueryExecutor
+	Paginate(page Page) QueryExecutor
+	Cached(cache Cache, ttl time.Duration) QueryExecutor
+	Hint(hints ...IndexHint) QueryExecutor
+	Timeout(d time.Duration) QueryExecutor
+}
+
 // Query builds a single-table SELECT query.
 //
 // Usage example:
 //
 //	// build executor
-//	// exec := Query[Account](schema)(Eq(field, value))
+//	// exec := Query[Account](schema)(Eq(field, value)).OrderBy(Desc(account.CreatedAt))
 //	// run
 //	// resEither, err := exec.Execute(ctx, db)
 //	// check left/right and handle accordingly
-func Query[T entity.Entity](schema Schema) func(where Where) Executor {
-	return func(where Where) Executor {
+func Query[T entity.Entity](schema Schema) func(where Where) QueryExecutor {
+	return func(where Where) QueryExecutor {
 		// basic sanity checks
 		if schema == nil || len(schema) == 0 {
-			return errorExecutorSelect{err: fmt.Errorf("schema is required and must contain at least one field")}
+			return errorExecutorSelect{err: ErrEmptySchema}
 		}
 		// collect where fields (may be nil)
 		var wfields []xql.Field
@@ -157,13 +218,29 @@ func Query[T entity.Entity](schema Schema) func(where Where) Executor {
 	}
 }
 
+// DeleteExecutor is the Executor returned by Delete. Limit bounds the number
+// of rows removed by a single statement, which keeps incremental purge jobs
+// from holding long locks:
+//
+//	// MySQL:    DELETE FROM orders WHERE ... LIMIT 500
+//	// Postgres: DELETE FROM orders WHERE ctid IN (SELECT ctid FROM orders WHERE ... LIMIT 500)
+//	// SQLite:   DELETE FROM orders WHERE rowid IN (SELECT rowid FROM orders WHERE ... LIMIT 500)
+//	exec := Delete[Order](Lt(order.CreatedAt, cutoff)).Limit(500)
+type DeleteExecutor interface {
+	Executor
+	Limit(n int) DeleteExecutor
+	Timeout(d time.Duration) DeleteExecutor
+	MustAffect(n int64) DeleteExecutor
+	AffectAtLeast(n int64) DeleteExecutor
+}
+
 // Delete builds a single-table DELETE query.
 //
 // Note: per design, callers should provide a non-empty where clause; the
 // implementation enforces this at builder time (deleteSQL returns error if
 // where is empty). We now validate referenced fields in Where early so callers
 // get immediate, clear errors when using fields from the wrong entity.
-func Delete[T entity.Entity](where Where) Executor {
+func Delete[T entity.Entity](where Where) DeleteExecutor {
 	// early validate where fields (if any)
 	if where != nil {
 		if err := validateSyntax[T](where.fields()...); err != nil {
@@ -173,26 +250,34 @@ func Delete[T entity.Entity](where Where) Executor {
 	return deleteExec[T]{where: where}
 }
 
+// UpdateExecutor is the Executor returned by Update.
+type UpdateExecutor interface {
+	Executor
+	Timeout(d time.Duration) UpdateExecutor
+	MustAffect(n int64) UpdateExecutor
+	AffectAtLeast(n int64) UpdateExecutor
+}
+
 // Update builds a single-table UPDATE query.
 //
 // New design: public Update requires the caller to provide the persistence
 // schema explicitly. The Update helper will generate SQL using the provided
 // Schema and an optional ValueObject of values to apply.
-func Update[T entity.Entity](schema Schema, values ValueObject) func(where Where) Executor {
-	return func(where Where) Executor {
+func Update[T entity.Entity](schema Schema, values ValueObject) func(where Where) UpdateExecutor {
+	return func(where Where) UpdateExecutor {
 		// schema must be provided now
 		if schema == nil || len(schema) == 0 {
-			return errorExecutorNonSelect{err: fmt.Errorf("schema is required and must contain at least one field")}
+			return errorExecutorUpdate{errorExecutorNonSelect{err: ErrEmptySchema}}
 		}
 		// validate schema fields belong to T
 		if err := validateSyntax[T](schema...); err != nil {
-			return errorExecutorNonSelect{err: err}
+			return errorExecutorUpdate{errorExecutorNonSelect{err: err}}
 		}
 
 		// validate where fields against T
 		if where != nil {
 			if err := validateSyntax[T](where.fields()...); err != nil {
-				return errorExecutorNonSelect{err: err}
+				return errorExecutorUpdate{errorExecutorNonSelect{err: err}}
 			}
 		}
 		return updateExec[T]{schema: schema, values: values, where: where}
@@ -272,24 +357,46 @@ func TupleValueObject(pairs ...Pair) ValueObject {
 }
 
 type updateExec[T entity.Entity] struct {
-	schema Schema
-	values ValueObject
-	where  Where
+	schema  Schema
+	values  ValueObject
+	where   Where
+	timeout time.Duration
+	affect  affectCheck
+}
+
+// Timeout returns a copy of the executor whose statement is bounded by d.
+func (u updateExec[T]) Timeout(d time.Duration) UpdateExecutor {
+	u.timeout = d
+	return u
+}
+
+// MustAffect returns a copy of the executor that fails with an
+// *AffectedRowsError unless exactly n rows are updated.
+func (u updateExec[T]) MustAffect(n int64) UpdateExecutor {
+	u.affect = affectCheck{enabled: true, n: n}
+	return u
+}
+
+// AffectAtLeast returns a copy of the executor that fails with an
+// *AffectedRowsError when fewer than n rows are updated.
+func (u updateExec[T]) AffectAtLeast(n int64) UpdateExecutor {
+	u.affect = affectCheck{enabled: true, n: n, atLeast: true}
+	return u
 }
 
 func (u updateExec[T]) Execute(ctx context.Context, ds *sql.DB) (mo.Either[[]ValueObject, sql.Result], error) {
 	if ds == nil {
-		return mo.Right[[]ValueObject, sql.Result](nil), fmt.Errorf("db is required")
+		return mo.Right[[]ValueObject, sql.Result](nil), ErrMissingDB
 	}
 	q, args, err := updateSQL[T](u.schema, u.values, u.where)
 	if err != nil {
 		return mo.Right[[]ValueObject, sql.Result](nil), err
 	}
-	res, err := ds.ExecContext(ctx, q, args...)
+	res, err := execStatement(ctx, ds, u.timeout, q, args)
 	if err != nil {
 		return mo.Right[[]ValueObject, sql.Result](nil), err
 	}
-	return mo.Right[[]ValueObject, sql.Result](res), nil
+	return mo.Right[[]ValueObject, sql.Result](res), u.affect.verify(res)
 }
 
 func (u updateExec[T]) sql() (string, error) {
@@ -307,7 +414,7 @@ type updateJoinExec[T entity.Entity] struct {
 
 func (u updateJoinExec[T]) Execute(ctx context.Context, ds *sql.DB) (mo.Either[[]ValueObject, sql.Result], error) {
 	if ds == nil {
-		return mo.Right[[]ValueObject, sql.Result](nil), fmt.Errorf("db is required")
+		return mo.Right[[]ValueObject, sql.Result](nil), ErrMissingDB
 	}
 	// build a Where representing the EXISTS(...) predicate (applies joinstmt and inner where)
 	existsWhere, err := buildExistsWhere(u.joinstmt, u.where)
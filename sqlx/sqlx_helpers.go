@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/kcmvp/xql"
 	"github.com/kcmvp/xql/entity"
+	"github.com/kcmvp/xql/internal"
+	"github.com/samber/lo"
 	"github.com/samber/mo"
 )
 
@@ -142,17 +146,23 @@ func inWhere(field xql.Field, values ...any) Where {
 }
 
 func selectSQL[T entity.Entity](schema *Schema, where Where) (string, []any, error) {
+	return selectFromSQL[T](schema, where, "")
+}
+
+// selectFromSQL is selectSQL with an optional fragment (e.g. an index hint)
+// rendered right after the table name in the FROM clause.
+func selectFromSQL[T entity.Entity](schema *Schema, where Where, tableSuffix string) (string, []any, error) {
 	if schema == nil {
-		return "", nil, fmt.Errorf("schema is required")
+		return "", nil, ErrEmptySchema
 	}
 	if len(*schema) == 0 {
-		return "", nil, fmt.Errorf("schema has no fields")
+		return "", nil, ErrEmptySchema
 	}
 
 	var ent T
 	table := ent.Table()
 	if strings.TrimSpace(table) == "" {
-		return "", nil, fmt.Errorf("entity table is empty")
+		return "", nil, ErrEmptyTable
 	}
 
 	cols := make([]string, 0, len(*schema))
@@ -169,6 +179,9 @@ func selectSQL[T entity.Entity](schema *Schema, where Where) (string, []any, err
 	}
 
 	sqlStr := fmt.Sprintf("SELECT %s FROM %s", strings.Join(cols, ", "), table)
+	if tableSuffix != "" {
+		sqlStr += " " + tableSuffix
+	}
 	if where == nil {
 		return sqlStr, nil, nil
 	}
@@ -181,20 +194,20 @@ func selectSQL[T entity.Entity](schema *Schema, where Where) (string, []any, err
 
 func updateSQL[T entity.Entity](schema Schema, g ValueObject, where Where) (string, []any, error) {
 	if schema == nil || len(schema) == 0 {
-		return "", nil, fmt.Errorf("schema is required")
+		return "", nil, ErrEmptySchema
 	}
 	if where == nil {
-		return "", nil, fmt.Errorf("where is required")
+		return "", nil, ErrMissingWhere
 	}
 	whereClause, whereArgs := where.Build()
 	if whereClause == "" {
-		return "", nil, fmt.Errorf("where is required")
+		return "", nil, ErrMissingWhere
 	}
 
 	var ent T
 	table := ent.Table()
 	if strings.TrimSpace(table) == "" {
-		return "", nil, fmt.Errorf("entity table is empty")
+		return "", nil, ErrEmptyTable
 	}
 
 	sets := make([]string, 0, len(schema))
@@ -251,13 +264,18 @@ func updateSQL[T entity.Entity](schema Schema, g ValueObject, where Where) (stri
 				continue
 			}
 
+			arg, err := jsonArg(vOpt.MustGet())
+			if err != nil {
+				return "", nil, fmt.Errorf("column %q: %w", q, err)
+			}
 			sets = append(sets, fmt.Sprintf("%s = ?", q))
-			args = append(args, vOpt.MustGet())
+			args = append(args, arg)
 		}
 
 		if len(sets) == 0 {
-			return "", nil, fmt.Errorf("no fields to update")
+			return "", nil, ErrNoValues
 		}
+		sets, args = withAutoUpdateTime(table, sets, args)
 	}
 
 	sql := fmt.Sprintf("UPDATE %s SET %s WHERE %s", table, strings.Join(sets, ", "), whereClause)
@@ -267,6 +285,20 @@ func updateSQL[T entity.Entity](schema Schema, g ValueObject, where Where) (stri
 	return sql, args, nil
 }
 
+// withAutoUpdateTime sets the AutoUpdateTime column registered for table to
+// the current time, unless sets already assigns it.
+func withAutoUpdateTime(table string, sets []string, args []any) ([]string, []any) {
+	f, ok := xql.AutoUpdateTimeField(table)
+	if !ok {
+		return sets, args
+	}
+	set := fmt.Sprintf("%s = ?", dbQualifiedNameFromQName(f.QualifiedName()))
+	if slices.Contains(sets, set) {
+		return sets, args
+	}
+	return append(sets, set), append(args, now())
+}
+
 // updateSQLFromValues builds an UPDATE statement using the provided ValueObject.
 // Behavior:
 //   - The ValueObject's Fields() are used as the list of fields to update.
@@ -276,11 +308,11 @@ func updateSQL[T entity.Entity](schema Schema, g ValueObject, where Where) (stri
 //     snake_case columns on T's table.
 func updateSQLFromValues[T entity.Entity](setter ValueObject, where Where) (string, []any, error) {
 	if where == nil {
-		return "", nil, fmt.Errorf("where is required")
+		return "", nil, ErrMissingWhere
 	}
 	whereClause, whereArgs := where.Build()
 	if whereClause == "" {
-		return "", nil, fmt.Errorf("where is required")
+		return "", nil, ErrMissingWhere
 	}
 	if setter == nil {
 		return "", nil, fmt.Errorf("values is required")
@@ -289,7 +321,7 @@ func updateSQLFromValues[T entity.Entity](setter ValueObject, where Where) (stri
 	var ent T
 	table := ent.Table()
 	if strings.TrimSpace(table) == "" {
-		return "", nil, fmt.Errorf("entity table is empty")
+		return "", nil, ErrEmptyTable
 	}
 
 	sets := make([]string, 0)
@@ -313,7 +345,7 @@ func updateSQLFromValues[T entity.Entity](setter ValueObject, where Where) (stri
 			// table part may contain dots (schema.table), so join all but the last element
 			tablePart := strings.Join(parts[:len(parts)-1], ".")
 			if tablePart != table {
-				return "", nil, fmt.Errorf("field %q belongs to table %q, expected %q", k, tablePart, table)
+				return "", nil, fmt.Errorf("%w: field %q belongs to table %q, expected %q", ErrCrossTableField, k, tablePart, table)
 			}
 		} else {
 			// Strict enforcement: unqualified keys are not allowed here. Callers that
@@ -322,13 +354,18 @@ func updateSQLFromValues[T entity.Entity](setter ValueObject, where Where) (stri
 			return "", nil, fmt.Errorf("unqualified value key %q is not allowed in this context; provide a persistence schema via Update(schema, ...) or use a fully-qualified key 'table.column'", k)
 		}
 
+		arg, err := jsonArg(vOpt.MustGet())
+		if err != nil {
+			return "", nil, fmt.Errorf("column %q: %w", q, err)
+		}
 		sets = append(sets, fmt.Sprintf("%s = ?", q))
-		args = append(args, vOpt.MustGet())
+		args = append(args, arg)
 	}
 
 	if len(sets) == 0 {
-		return "", nil, fmt.Errorf("no fields to update")
+		return "", nil, ErrNoValues
 	}
+	sets, args = withAutoUpdateTime(table, sets, args)
 
 	sql := fmt.Sprintf("UPDATE %s SET %s WHERE %s", table, strings.Join(sets, ", "), whereClause)
 	if len(whereArgs) > 0 {
@@ -339,11 +376,11 @@ func updateSQLFromValues[T entity.Entity](setter ValueObject, where Where) (stri
 
 func deleteSQL[T entity.Entity](where Where) (string, []any, error) {
 	if where == nil {
-		return "", nil, fmt.Errorf("where is required")
+		return "", nil, ErrMissingWhere
 	}
 	clause, args := where.Build()
 	if clause == "" {
-		return "", nil, fmt.Errorf("where is required")
+		return "", nil, ErrMissingWhere
 	}
 
 	var ent T
@@ -351,9 +388,31 @@ func deleteSQL[T entity.Entity](where Where) (string, []any, error) {
 	return fmt.Sprintf("DELETE FROM %s WHERE %s", table, clause), args, nil
 }
 
+// deleteLimitSQL builds a DELETE bounded to at most limit rows. MySQL (and the
+// zero Dialect) support `DELETE ... LIMIT n` natively; Postgres and SQLite
+// have no such clause so the limit is applied to a ctid/rowid subquery.
+// A limit <= 0 renders a plain DELETE.
+func deleteLimitSQL[T entity.Entity](where Where, limit int, dialect Dialect) (string, []any, error) {
+	q, args, err := deleteSQL[T](where)
+	if err != nil || limit <= 0 {
+		return q, args, err
+	}
+	var ent T
+	table := ent.Table()
+	clause, _ := where.Build()
+	switch dialect {
+	case Postgres:
+		return fmt.Sprintf("DELETE FROM %s WHERE ctid IN (SELECT ctid FROM %s WHERE %s LIMIT %d)", table, table, clause, limit), args, nil
+	case SQLite:
+		return fmt.Sprintf("DELETE FROM %s WHERE rowid IN (SELECT rowid FROM %s WHERE %s LIMIT %d)", table, table, clause, limit), args, nil
+	default:
+		return fmt.Sprintf("%s LIMIT %d", q, limit), args, nil
+	}
+}
+
 func buildSelectWithJoin(schema Schema, joinstmt string, where Where) (string, []any, error) {
 	if schema == nil || len(schema) == 0 {
-		return "", nil, fmt.Errorf("schema is required and must contain at least one field")
+		return "", nil, ErrEmptySchema
 	}
 	// derive base table from first schema field's QualifiedName
 	first := schema[0]
@@ -475,13 +534,13 @@ func buildExistsWhere(joinstmt string, where Where) (Where, error) {
 // rowsToValueObjects maps query results to meta.ValueObject using the schema order.
 // Mapping policy:
 // - Fields are schema field Name() (provider name).
-// - Values are scanned as driver values.
+// - Values are scanned as driver values; NULL is stored as internal.Null.
 func rowsToValueObjects(rows *sql.Rows, schema Schema) ([]ValueObject, error) {
 	if rows == nil {
 		return nil, fmt.Errorf("rows is required")
 	}
 	if len(schema) == 0 {
-		return nil, fmt.Errorf("schema has no fields")
+		return nil, ErrEmptySchema
 	}
 
 	// We always project columns in the same order as schema in selectSQL.
@@ -500,7 +559,8 @@ func rowsToValueObjects(rows *sql.Rows, schema Schema) ([]ValueObject, error) {
 
 		m := make(map[string]any, n)
 		for i, f := range schema {
-			m[f.QualifiedName()] = vals[i]
+			// a NULL column reads as absent; Data.IsNull tells it from a missing one
+			m[f.QualifiedName()] = lo.Ternary(vals[i] == nil, internal.Null, vals[i])
 		}
 		out = append(out, valueObject{Data: m})
 	}
@@ -515,33 +575,91 @@ func rowsToValueObjects(rows *sql.Rows, schema Schema) ([]ValueObject, error) {
 // -----------------------------
 
 type queryExec[T entity.Entity] struct {
-	schema Schema
-	where  Where
+	schema  Schema
+	where   Where
+	sorts   []Sort
+	hints   []IndexHint
+	page    Page
+	cache   Cache
+	ttl     time.Duration
+	timeout time.Duration
+}
+
+// Timeout returns a copy of the executor whose statement is bounded by d.
+func (q queryExec[T]) Timeout(d time.Duration) QueryExecutor {
+	q.timeout = d
+	return q
+}
+
+// Hint returns a copy of the executor carrying the given index hints.
+func (q queryExec[T]) Hint(hints ...IndexHint) QueryExecutor {
+	q.hints = append(append([]IndexHint(nil), q.hints...), hints...)
+	return q
+}
+
+// Cached returns a copy of the executor whose results are cached for ttl.
+func (q queryExec[T]) Cached(cache Cache, ttl time.Duration) QueryExecutor {
+	q.cache = lo.Ternary(cache == nil, DefaultCache, cache)
+	q.ttl = ttl
+	return q
+}
+
+// Paginate returns a copy of the executor limited to page.
+func (q queryExec[T]) Paginate(page Page) QueryExecutor {
+	q.page = page
+	return q
+}
+
+// OrderBy returns a copy of the executor ordered by the given sort terms.
+// Sort fields must belong to T.
+func (q queryExec[T]) OrderBy(sorts ...Sort) QueryExecutor {
+	fields := make([]xql.Field, 0, len(sorts))
+	for _, s := range sorts {
+		fields = append(fields, s.field)
+	}
+	if err := validateSyntax[T](fields...); err != nil {
+		return errorExecutorSelect{err: err}
+	}
+	q.sorts = append(append([]Sort(nil), q.sorts...), sorts...)
+	return q
+}
+
+// build renders the SELECT for the given dialect, including ORDER BY.
+func (q queryExec[T]) build(dialect Dialect) (string, []any, error) {
+	query, args, err := selectFromSQL[T](&q.schema, q.where, indexHintClause(q.hints, dialect))
+	if err != nil {
+		return "", nil, err
+	}
+	return query + orderByClause(q.sorts, dialect) + q.page.clause(), args, nil
 }
 
 func (q queryExec[T]) Execute(ctx context.Context, ds *sql.DB) (mo.Either[[]ValueObject, sql.Result], error) {
 	if ds == nil {
-		return mo.Left[[]ValueObject, sql.Result](nil), fmt.Errorf("db is required")
+		return mo.Left[[]ValueObject, sql.Result](nil), ErrMissingDB
 	}
-	query, qargs, err := selectSQL[T](&q.schema, q.where)
+	query, qargs, err := q.build(dialectOf(ds))
 	if err != nil {
 		return mo.Left[[]ValueObject, sql.Result](nil), err
 	}
-	rows, err := ds.QueryContext(ctx, query, qargs...)
-	if err != nil {
-		return mo.Left[[]ValueObject, sql.Result](nil), err
+	key := cacheKey(ds, query, qargs)
+	if q.cache != nil {
+		if cached, ok := q.cache.Get(key); ok {
+			return mo.Left[[]ValueObject, sql.Result](cached), nil
+		}
 	}
-	defer func() { _ = rows.Close() }()
-
-	res, err := rowsToValueObjects(rows, q.schema)
+	res, err := queryRows(ctx, ds, q.timeout, q.schema, query, qargs)
 	if err != nil {
 		return mo.Left[[]ValueObject, sql.Result](nil), err
 	}
+	if q.cache != nil {
+		var ent T
+		q.cache.Set(key, ent.Table(), res, q.ttl)
+	}
 	return mo.Left[[]ValueObject, sql.Result](res), nil
 }
 
 func (q queryExec[T]) sql() (string, error) {
-	qstr, _, err := selectSQL[T](&q.schema, q.where)
+	qstr, _, err := q.build("")
 	return qstr, err
 }
 
@@ -550,26 +668,58 @@ func (q queryExec[T]) sql() (string, error) {
 // -----------------------------
 
 type deleteExec[T entity.Entity] struct {
-	where Where
+	where   Where
+	limit   int
+	timeout time.Duration
+	affect  affectCheck
+}
+
+// MustAffect returns a copy of the executor that fails with an
+// *AffectedRowsError unless exactly n rows are deleted.
+func (d deleteExec[T]) MustAffect(n int64) DeleteExecutor {
+	d.affect = affectCheck{enabled: true, n: n}
+	return d
+}
+
+// AffectAtLeast returns a copy of the executor that fails with an
+// *AffectedRowsError when fewer than n rows are deleted.
+func (d deleteExec[T]) AffectAtLeast(n int64) DeleteExecutor {
+	d.affect = affectCheck{enabled: true, n: n, atLeast: true}
+	return d
+}
+
+// Timeout returns a copy of the executor whose statement is bounded by d.
+func (d deleteExec[T]) Timeout(timeout time.Duration) DeleteExecutor {
+	d.timeout = timeout
+	return d
+}
+
+// Limit returns a copy of the executor that deletes at most n rows.
+func (d deleteExec[T]) Limit(n int) DeleteExecutor {
+	if n <= 0 {
+		return errorExecutorNonSelect{err: fmt.Errorf("limit must be greater than 0, got %d", n)}
+	}
+	d.limit = n
+	return d
 }
 
 func (d deleteExec[T]) Execute(ctx context.Context, ds *sql.DB) (mo.Either[[]ValueObject, sql.Result], error) {
 	if ds == nil {
-		return mo.Right[[]ValueObject, sql.Result](nil), fmt.Errorf("db is required")
+		return mo.Right[[]ValueObject, sql.Result](nil), ErrMissingDB
 	}
-	query, qargs, err := deleteSQL[T](d.where)
+	query, qargs, err := deleteLimitSQL[T](d.where, d.limit, dialectOf(ds))
 	if err != nil {
 		return mo.Right[[]ValueObject, sql.Result](nil), err
 	}
-	result, err := ds.ExecContext(ctx, query, qargs...)
+	result, err := execStatement(ctx, ds, d.timeout, query, qargs)
 	if err != nil {
 		return mo.Right[[]ValueObject, sql.Result](nil), err
 	}
-	return mo.Right[[]ValueObject, sql.Result](result), nil
+	return mo.Right[[]ValueObject, sql.Result](result), d.affect.verify(result)
 }
 
 func (d deleteExec[T]) sql() (string, error) {
-	dstr, _, err := deleteSQL[T](d.where)
+	dstr, _, err := deleteLimitSQL[T](d.where, d.limit, "")
 	return dstr, err
 }
 
@@ -591,7 +741,7 @@ type joinQueryExec struct {
 
 func (j joinQueryExec) Execute(ctx context.Context, ds *sql.DB) (mo.Either[[]ValueObject, sql.Result], error) {
 	if ds == nil {
-		return mo.Left[[]ValueObject, sql.Result](nil), fmt.Errorf("db is required")
+		return mo.Left[[]ValueObject, sql.Result](nil), ErrMissingDB
 	}
 	q, args, err := buildSelectWithJoin(j.schema, j.joinstmt, j.where)
 	if err != nil {
@@ -623,7 +773,7 @@ type joinDeleteExec struct {
 
 func (j joinDeleteExec) Execute(ctx context.Context, ds *sql.DB) (mo.Either[[]ValueObject, sql.Result], error) {
 	if ds == nil {
-		return mo.Right[[]ValueObject, sql.Result](nil), fmt.Errorf("db is required")
+		return mo.Right[[]ValueObject, sql.Result](nil), ErrMissingDB
 	}
 	q, args, err := buildDeleteWithJoin(j.baseTable, j.joinstmt, j.where)
 	if err != nil {
@@ -649,7 +799,7 @@ func validateSyntax[T entity.Entity](fields ...xql.Field) error {
 	var ent T
 	expected := ent.Table()
 	if strings.TrimSpace(expected) == "" {
-		return fmt.Errorf("entity table is empty")
+		return ErrEmptyTable
 	}
 	for _, f := range fields {
 		if f == nil {
@@ -662,7 +812,7 @@ func validateSyntax[T entity.Entity](fields ...xql.Field) error {
 		}
 		tablePart := strings.Join(parts[:len(parts)-1], ".")
 		if tablePart != expected {
-			return fmt.Errorf("field %q belongs to table %q, expected %q", f.QualifiedName(), tablePart, expected)
+			return fmt.Errorf("%w: field %q belongs to table %q, expected %q", ErrCrossTableField, f.QualifiedName(), tablePart, expected)
 		}
 	}
 	return nil
@@ -679,6 +829,16 @@ func (e errorExecutorSelect) Execute(ctx context.Context, ds *sql.DB) (mo.Either
 
 func (e errorExecutorSelect) sql() (string, error) { return "", e.err }
 
+func (e errorExecutorSelect) OrderBy(...Sort) QueryExecutor { return e }
+
+func (e errorExecutorSelect) Paginate(Page) QueryExecutor { return e }
+
+func (e errorExecutorSelect) Cached(Cache, time.Duration) QueryExecutor { return e }
+
+func (e errorExecutorSelect) Hint(...IndexHint) QueryExecutor { return e }
+
+func (e errorExecutorSelect) Timeout(time.Duration) QueryExecutor { return e }
+
 type errorExecutorNonSelect struct{ err error }
 
 func (e errorExecutorNonSelect) Execute(ctx context.Context, ds *sql.DB) (mo.Either[[]ValueObject, sql.Result], error) {
@@ -686,3 +846,20 @@ func (e errorExecutorNonSelect) Execute(ctx context.Context, ds *sql.DB) (mo.Eit
 }
 
 func (e errorExecutorNonSelect) sql() (string, error) { return "", e.err }
+
+func (e errorExecutorNonSelect) Limit(int) DeleteExecutor { return e }
+
+func (e errorExecutorNonSelect) Timeout(time.Duration) DeleteExecutor { return e }
+
+func (e errorExecutorNonSelect) MustAffect(int64) DeleteExecutor { return e }
+
+func (e errorExecutorNonSelect) AffectAtLeast(int64) DeleteExecutor { return e }
+
+// errorExecutorUpdate is the UpdateExecutor counterpart of errorExecutorNonSelect.
+type errorExecutorUpdate struct{ errorExecutorNonSelect }
+
+func (e errorExecutorUpdate) Timeout(time.Duration) UpdateExecutor { return e }
+
+func (e errorExecutorUpdate) MustAffect(int64) UpdateExecutor { return e }
+
+func (e errorExecutorUpdate) AffectAtLeast(int64) UpdateExecutor { return e }
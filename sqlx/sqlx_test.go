@@ -1,6 +1,8 @@
 package sqlx
 
 import (
+	"context"
+	"database/sql"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -9,6 +11,7 @@ import (
 	"testing"
 
 	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/account"
 	"github.com/kcmvp/xql/sample/gen/field/order"
 	"github.com/stretchr/testify/require"
 )
@@ -589,3 +592,83 @@ func TestMapValueObject(t *testing.T) {
 		})
 	}
 }
+
+func TestSqlGeneration_DeleteLimit(t *testing.T) {
+	where := Lt(order.Amount, 10.5)
+	cases := []struct {
+		name    string
+		dialect Dialect
+		expect  string
+	}{
+		{"Default", "", "DELETE FROM orders WHERE orders.amount < ? LIMIT 100"},
+		{"MySQL", MySQL, "DELETE FROM orders WHERE orders.amount < ? LIMIT 100"},
+		{"Postgres", Postgres, "DELETE FROM orders WHERE ctid IN (SELECT ctid FROM orders WHERE orders.amount < ? LIMIT 100)"},
+		{"SQLite", SQLite, "DELETE FROM orders WHERE rowid IN (SELECT rowid FROM orders WHERE orders.amount < ? LIMIT 100)"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, args, err := deleteLimitSQL[Order](where, 100, c.dialect)
+			require.NoError(t, err)
+			require.Equal(t, c.expect, q)
+			require.Equal(t, []any{10.5}, args)
+		})
+	}
+
+	t.Run("Executor", func(t *testing.T) {
+		q, err := Delete[Order](where).Limit(100).sql()
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM orders WHERE orders.amount < ? LIMIT 100", q)
+	})
+
+	t.Run("NonPositive_should_error", func(t *testing.T) {
+		_, err := Delete[Order](where).Limit(0).sql()
+		require.Error(t, err)
+	})
+
+	t.Run("NoWhere_should_error", func(t *testing.T) {
+		_, err := Delete[Order](nil).Limit(10).sql()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "where is required")
+	})
+}
+
+func TestSentinelErrors(t *testing.T) {
+	_, _, err := deleteSQL[Order](nil)
+	require.ErrorIs(t, err, ErrMissingWhere)
+
+	_, err = Update[Order](Schema(order.All()), nil)(nil).sql()
+	require.ErrorIs(t, err, ErrMissingWhere)
+
+	_, err = Query[Order](nil)(nil).sql()
+	require.ErrorIs(t, err, ErrEmptySchema)
+
+	_, err = Update[Order](Schema{}, nil)(Eq(order.ID, 1)).sql()
+	require.ErrorIs(t, err, ErrEmptySchema)
+
+	_, err = Query[Order](Schema{order.ID})(Eq(account.Email, "x")).sql()
+	require.ErrorIs(t, err, ErrCrossTableField)
+
+	_, err = Delete[Order](Eq(account.Email, "x")).sql()
+	require.ErrorIs(t, err, ErrCrossTableField)
+
+	_, err = Delete[Order](Eq(order.ID, 1)).Execute(context.Background(), nil)
+	require.ErrorIs(t, err, ErrMissingDB)
+}
+
+func TestQuery_NullColumn(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec("CREATE TABLE orders (id INTEGER PRIMARY KEY, amount REAL)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO orders (id, amount) VALUES (1, NULL)")
+	require.NoError(t, err)
+
+	res, err := Query[Order](Schema{order.ID, order.Amount})(nil).Execute(context.Background(), db)
+	require.NoError(t, err)
+	rows := res.MustLeft()
+	require.Len(t, rows, 1)
+	require.True(t, rows[0].Float64(order.Amount.QualifiedName()).IsAbsent())
+	require.True(t, rows[0].(valueObject).IsNull(order.Amount.QualifiedName()))
+}
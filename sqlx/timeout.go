@@ -0,0 +1,74 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// conn is the subset of *sql.DB and *sql.Tx used to run a statement.
+type conn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// statementTimeoutSQL renders the Postgres statement that bounds the
+// remaining statements of the current transaction to d.
+func statementTimeoutSQL(d time.Duration) string {
+	return fmt.Sprintf("SET LOCAL statement_timeout = %d", d.Milliseconds())
+}
+
+// withTimeout runs fn with ctx bounded by timeout. A non-positive timeout
+// runs fn directly against ds. On Postgres, fn additionally runs inside a
+// transaction that issues `SET LOCAL statement_timeout`, so the server
+// aborts the statement even if the client is not waiting on it; the setting
+// is scoped to that transaction and does not leak to other pool connections.
+func withTimeout(ctx context.Context, ds *sql.DB, timeout time.Duration, fn func(ctx context.Context, c conn) error) error {
+	if timeout <= 0 {
+		return fn(ctx, ds)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if dialectOf(ds) != Postgres {
+		return fn(ctx, ds)
+	}
+	tx, err := ds.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err = tx.ExecContext(ctx, statementTimeoutSQL(timeout)); err != nil {
+		return err
+	}
+	if err = fn(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// queryRows runs a SELECT under timeout and maps the rows using schema.
+func queryRows(ctx context.Context, ds *sql.DB, timeout time.Duration, schema Schema, query string, args []any) ([]ValueObject, error) {
+	var res []ValueObject
+	err := withTimeout(ctx, ds, timeout, func(ctx context.Context, c conn) error {
+		rows, err := c.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+		res, err = rowsToValueObjects(rows, schema)
+		return err
+	})
+	return res, err
+}
+
+// execStatement runs a non-SELECT statement under timeout.
+func execStatement(ctx context.Context, ds *sql.DB, timeout time.Duration, query string, args []any) (sql.Result, error) {
+	var res sql.Result
+	err := withTimeout(ctx, ds, timeout, func(ctx context.Context, c conn) error {
+		var err error
+		res, err = c.ExecContext(ctx, query, args...)
+		return err
+	})
+	return res, err
+}
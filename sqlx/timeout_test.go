@@ -0,0 +1,85 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/sample/gen/field/order"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementTimeoutSQL(t *testing.T) {
+	require.Equal(t, "SET LOCAL statement_timeout = 1500", statementTimeoutSQL(1500*time.Millisecond))
+}
+
+func TestWithTimeout(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+
+	t.Run("disabled", func(t *testing.T) {
+		err := withTimeout(context.Background(), db, 0, func(ctx context.Context, c conn) error {
+			_, ok := ctx.Deadline()
+			require.False(t, ok)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		err := withTimeout(context.Background(), db, 20*time.Millisecond, func(ctx context.Context, c conn) error {
+			_, ok := ctx.Deadline()
+			require.True(t, ok)
+			// long running recursive CTE; the deadline interrupts it
+			rows, err := c.QueryContext(ctx, `WITH RECURSIVE r(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM r WHERE x < 1000000000) SELECT COUNT(1) FROM r`)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = rows.Close() }()
+			for rows.Next() {
+			}
+			return rows.Err()
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestTimeout_Executors(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("CREATE TABLE orders (id INTEGER PRIMARY KEY, amount REAL)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO orders (id, amount) VALUES (1, 1), (2, 2)")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	rows, err := Query[Order](Schema{order.ID, order.Amount})(Gt(order.Amount, 0.0)).Timeout(time.Second).Execute(ctx, db)
+	require.NoError(t, err)
+	require.Len(t, rows.MustLeft(), 2)
+
+	// Timeout does not change the rendered statement
+	update := Update[Order](Schema{order.Amount}, TupleValueObject(Tuple(*order.Amount, 3.0)))(Eq(order.ID, int64(1)))
+	want, err := update.sql()
+	require.NoError(t, err)
+	got, err := update.Timeout(time.Second).sql()
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	res, err := Delete[Order](Eq(order.ID, int64(2))).Timeout(time.Second).Execute(ctx, db)
+	require.NoError(t, err)
+	n, err := res.MustRight().RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	// error executors keep their error through Timeout
+	_, err = Update[Order](nil, nil)(nil).Timeout(time.Second).Execute(ctx, db)
+	require.ErrorIs(t, err, ErrEmptySchema)
+}
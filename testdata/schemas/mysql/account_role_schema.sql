@@ -9,5 +9,5 @@ CREATE TABLE IF NOT EXISTS account_roles (
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='AccountRole is a join table for Account <-> Role.';
 
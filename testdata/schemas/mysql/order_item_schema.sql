@@ -11,5 +11,5 @@ CREATE TABLE IF NOT EXISTS order_items (
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='OrderItem represents a line item of an Order (1:N).';
 
@@ -9,4 +9,4 @@ CREATE TABLE IF NOT EXISTS orders (
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='Order represents a customer order.';
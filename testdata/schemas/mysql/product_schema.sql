@@ -10,6 +10,6 @@ CREATE TABLE IF NOT EXISTS products (
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='Product represents a product.';
 CREATE INDEX IF NOT EXISTS idx_products_sku ON products (sku);
 
@@ -10,5 +10,5 @@ CREATE TABLE IF NOT EXISTS profiles (
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='Profile represents a 1:1 extension of Account.';
 
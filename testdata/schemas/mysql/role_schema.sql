@@ -9,6 +9,6 @@ CREATE TABLE IF NOT EXISTS roles (
     updated_at DATETIME,
     created_by TEXT,
     updated_by TEXT
-);
+) COMMENT='Role represents an authorization role.';
 CREATE INDEX IF NOT EXISTS idx_roles_key ON roles (key);
 
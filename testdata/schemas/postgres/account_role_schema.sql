@@ -10,4 +10,5 @@ CREATE TABLE IF NOT EXISTS account_roles (
     created_by TEXT,
     updated_by TEXT
 );
+COMMENT ON TABLE account_roles IS 'AccountRole is a join table for Account <-> Role.';
 
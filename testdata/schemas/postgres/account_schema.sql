@@ -4,7 +4,7 @@
 CREATE TABLE IF NOT EXISTS accounts (
     id BIGINT PRIMARY KEY,
     email TEXT UNIQUE,
-    nick_name varchar(100) NOT NULL UNIQUE DEFAULT 'anonymous',
+    nick_name varchar(100) DEFAULT 'anonymous' NOT NULL UNIQUE,
     category integer DEFAULT 0,
     balance DOUBLE PRECISION,
     created_at TIMESTAMP WITH TIME ZONE,
@@ -12,4 +12,5 @@ CREATE TABLE IF NOT EXISTS accounts (
     created_by TEXT,
     updated_by TEXT
 );
+COMMENT ON TABLE accounts IS 'Account represents a user account in the system.';
 CREATE INDEX IF NOT EXISTS idx_accounts_email ON accounts (email);
@@ -12,4 +12,5 @@ CREATE TABLE IF NOT EXISTS order_items (
     created_by TEXT,
     updated_by TEXT
 );
+COMMENT ON TABLE order_items IS 'OrderItem represents a line item of an Order (1:N).';
 
@@ -10,3 +10,4 @@ CREATE TABLE IF NOT EXISTS orders (
     created_by TEXT,
     updated_by TEXT
 );
+COMMENT ON TABLE orders IS 'Order represents a customer order.';
@@ -11,5 +11,6 @@ CREATE TABLE IF NOT EXISTS products (
     created_by TEXT,
     updated_by TEXT
 );
+COMMENT ON TABLE products IS 'Product represents a product.';
 CREATE INDEX IF NOT EXISTS idx_products_sku ON products (sku);
 
@@ -11,4 +11,5 @@ CREATE TABLE IF NOT EXISTS profiles (
     created_by TEXT,
     updated_by TEXT
 );
+COMMENT ON TABLE profiles IS 'Profile represents a 1:1 extension of Account.';
 
@@ -10,5 +10,6 @@ CREATE TABLE IF NOT EXISTS roles (
     created_by TEXT,
     updated_by TEXT
 );
+COMMENT ON TABLE roles IS 'Role represents an authorization role.';
 CREATE INDEX IF NOT EXISTS idx_roles_key ON roles (key);
 
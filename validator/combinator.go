@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+var (
+	// ErrNegated is returned by Not when the inverted validator passes.
+	ErrNegated = errors.New("must not satisfy")
+	// ErrNoneSatisfied is returned by AnyOf when every validator fails.
+	ErrNoneSatisfied = errors.New("must satisfy at least one of")
+)
+
+// negatedPrefix prefixes the name of a validator inverted by Not.
+const negatedPrefix = "not_"
+
+// Not inverts vf: the value is valid when vf rejects it. Reserved user
+// names, for instance, are rejected with
+//
+//	view.Field[string]("username", validator.Not(validator.OneOf("admin", "root")))
+//
+// The validator is named after vf with a "not_" prefix ("not_one_of"), so a
+// field may carry both vf and Not(vf), and its rule keeps the rule of vf
+// under the "rule" parameter.
+func Not[T FieldType](vf ValidateFunc[T]) ValidateFunc[T] {
+	return func(rule ...*Rule) (string, Validator[T]) {
+		var inner Rule
+		name, v := vf(&inner)
+		return Named(rule, negatedPrefix+name, "rule", inner), func(val T) error {
+			if v(val) == nil {
+				return fmt.Errorf("%w %s", ErrNegated, name)
+			}
+			return nil
+		}
+	}
+}
+
+// negated returns the name of the validator inverted by the validator named
+// name, if any.
+func negated(name string) (string, bool) {
+	return strings.CutPrefix(name, negatedPrefix)
+}
+
+// AnyOf passes when at least one of vfs passes, for values accepted in
+// alternative formats:
+//
+//	view.Field[string]("ref", validator.AnyOf(validator.NumericString(), validator.Match("ORD-*")))
+//
+// The error lists the failures of every validator. The rule keeps the rules
+// of vfs under the "rules" parameter.
+func AnyOf[T FieldType](vfs ...ValidateFunc[T]) ValidateFunc[T] {
+	lo.Assertf(len(vfs) > 0, "AnyOf requires at least one validator")
+	return func(rule ...*Rule) (string, Validator[T]) {
+		rules, validators := group(vfs)
+		return Named(rule, "any_of", "rules", rules), func(val T) error {
+			msgs := make([]string, 0, len(validators))
+			for i, v := range validators {
+				err := v(val)
+				if err == nil {
+					return nil
+				}
+				msgs = append(msgs, fmt.Sprintf("%s (%s)", rules[i].Name, strings.TrimSpace(err.Error())))
+			}
+			return fmt.Errorf("%w: %s", ErrNoneSatisfied, strings.Join(msgs, ", "))
+		}
+	}
+}
+
+// AllOf groups vfs into one validator passing when all of them pass, e.g. to
+// use a set of constraints as an alternative of AnyOf. It returns the error
+// of the first failing validator.
+func AllOf[T FieldType](vfs ...ValidateFunc[T]) ValidateFunc[T] {
+	lo.Assertf(len(vfs) > 0, "AllOf requires at least one validator")
+	return func(rule ...*Rule) (string, Validator[T]) {
+		rules, validators := group(vfs)
+		return Named(rule, "all_of", "rules", rules), func(val T) error {
+			for _, v := range validators {
+				if err := v(val); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// group builds the validators of vfs along with their rules.
+func group[T FieldType](vfs []ValidateFunc[T]) ([]Rule, []Validator[T]) {
+	rules := make([]Rule, len(vfs))
+	validators := make([]Validator[T], len(vfs))
+	for i, vf := range vfs {
+		var name string
+		name, validators[i] = vf(&rules[i])
+		rules[i].Name = name
+	}
+	return rules, validators
+}
+
+// When applies vf only to the values satisfying pred; other values pass.
+// A URL that may be left empty, for instance, is checked with
+//
+//	view.Field[string]("homepage", validator.When(func(s string) bool { return s != "" }, validator.URL()))
+//
+// The validator keeps the name of vf, so its failures are reported and
+// localized as those of vf, and its rule gets the parameter "conditional"
+// in addition to those of vf.
+func When[T FieldType](pred func(T) bool, vf ValidateFunc[T]) ValidateFunc[T] {
+	lo.Assertf(pred != nil, "When requires a predicate")
+	return func(rule ...*Rule) (string, Validator[T]) {
+		var inner Rule
+		name, v := vf(&inner)
+		kv := []any{"conditional", true}
+		for k, p := range inner.Params {
+			kv = append(kv, k, p)
+		}
+		return Named(rule, name, kv...), func(val T) error {
+			if !pred(val) {
+				return nil
+			}
+			return v(val)
+		}
+	}
+}
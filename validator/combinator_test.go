@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNot(t *testing.T) {
+	reserved := Not(OneOf("admin", "root"))
+	name, v := reserved()
+	if name != "not_one_of" {
+		t.Errorf("Not() name = %v, want not_one_of", name)
+	}
+	tests := []struct {
+		val     string
+		wantErr error
+	}{
+		{"alice", nil},
+		{"admin", ErrNegated},
+		{"root", ErrNegated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.val, func(t *testing.T) {
+			if err := v(tt.val); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Not() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	rule := Describe(reserved)
+	want := map[string]any{"rule": Rule{Name: "one_of", Params: map[string]any{"values": []string{"admin", "root"}}}}
+	if !reflect.DeepEqual(rule.Params, want) {
+		t.Errorf("Describe() params = %v, want %v", rule.Params, want)
+	}
+	if code := Code(rule.Name); code != "not.value.one_of" {
+		t.Errorf("Code() = %v, want not.value.one_of", code)
+	}
+	if code := Code(Describe(Not(Not(Email()))).Name); code != "not.not.string.email" {
+		t.Errorf("Code() = %v, want not.not.string.email", code)
+	}
+}
+
+func TestAnyOf(t *testing.T) {
+	ref := AnyOf(NumericString(), Match("ORD-*"))
+	_, v := ref()
+	tests := []struct {
+		val     string
+		wantErr error
+	}{
+		{"12345", nil},
+		{"ORD-7", nil},
+		{"INV-7", ErrNoneSatisfied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.val, func(t *testing.T) {
+			if err := v(tt.val); !errors.Is(err, tt.wantErr) {
+				t.Errorf("AnyOf() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := v("INV-7"); err.Error() != "must satisfy at least one of: numeric (must contain only digits 0-9), match (not match pattern ORD-*)" {
+		t.Errorf("AnyOf() error = %v", err)
+	}
+	rule := Describe(ref)
+	want := []Rule{{Name: "numeric", Params: map[string]any{}}, {Name: "match", Params: map[string]any{"pattern": "ORD-*"}}}
+	if rule.Name != "any_of" || !reflect.DeepEqual(rule.Params["rules"], want) {
+		t.Errorf("Describe() = %v", rule)
+	}
+}
+
+func TestAllOf(t *testing.T) {
+	_, v := AnyOf(AllOf(MinLength(3), NumericString()), Email())()
+	tests := []struct {
+		val     string
+		wantErr bool
+	}{
+		{"123", false},
+		{"a@b.c", false},
+		{"12", true},
+		{"abc", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.val, func(t *testing.T) {
+			if err := v(tt.val); (err != nil) != tt.wantErr {
+				t.Errorf("AllOf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	_, all := AllOf(MinLength(3), NumericString())()
+	if err := all("ab"); !errors.Is(err, ErrLengthMin) {
+		t.Errorf("AllOf() error = %v, want %v", err, ErrLengthMin)
+	}
+}
+
+func TestWhen(t *testing.T) {
+	homepage := When(func(s string) bool { return s != "" }, URL())
+	name, v := homepage()
+	if name != "url" {
+		t.Errorf("When() name = %v, want url", name)
+	}
+	tests := []struct {
+		val     string
+		wantErr error
+	}{
+		{"", nil},
+		{"https://example.com", nil},
+		{"example", ErrNotValidURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.val, func(t *testing.T) {
+			if err := v(tt.val); !errors.Is(err, tt.wantErr) {
+				t.Errorf("When() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	rule := Describe(When(func(n int) bool { return n%2 == 0 }, Gt(10)))
+	if !reflect.DeepEqual(rule, Rule{Name: "gt", Params: map[string]any{"min": 10, "conditional": true}}) {
+		t.Errorf("Describe() = %v", rule)
+	}
+}
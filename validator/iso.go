@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+var (
+	ErrNotCountryCode  = errors.New("not an ISO 3166-1 country code")
+	ErrNotCurrencyCode = errors.New("not an ISO 4217 currency code")
+)
+
+// iso3166 lists the officially assigned ISO 3166-1 countries as alpha-2 and
+// alpha-3 code pairs.
+const iso3166 = `
+AD AND AE ARE AF AFG AG ATG AI AIA AL ALB AM ARM AO AGO AQ ATA AR ARG AS ASM AT AUT AU AUS AW ABW AX ALA AZ AZE
+BA BIH BB BRB BD BGD BE BEL BF BFA BG BGR BH BHR BI BDI BJ BEN BL BLM BM BMU BN BRN BO BOL BQ BES BR BRA BS BHS
+BT BTN BV BVT BW BWA BY BLR BZ BLZ CA CAN CC CCK CD COD CF CAF CG COG CH CHE CI CIV CK COK CL CHL CM CMR CN CHN
+CO COL CR CRI CU CUB CV CPV CW CUW CX CXR CY CYP CZ CZE DE DEU DJ DJI DK DNK DM DMA DO DOM DZ DZA EC ECU EE EST
+EG EGY EH ESH ER ERI ES ESP ET ETH FI FIN FJ FJI FK FLK FM FSM FO FRO FR FRA GA GAB GB GBR GD GRD GE GEO GF GUF
+GG GGY GH GHA GI GIB GL GRL GM GMB GN GIN GP GLP GQ GNQ GR GRC GS SGS GT GTM GU GUM GW GNB GY GUY HK HKG HM HMD
+HN HND HR HRV HT HTI HU HUN ID IDN IE IRL IL ISR IM IMN IN IND IO IOT IQ IRQ IR IRN IS ISL IT ITA JE JEY JM JAM
+JO JOR JP JPN KE KEN KG KGZ KH KHM KI KIR KM COM KN KNA KP PRK KR KOR KW KWT KY CYM KZ KAZ LA LAO LB LBN LC LCA
+LI LIE LK LKA LR LBR LS LSO LT LTU LU LUX LV LVA LY LBY MA MAR MC MCO MD MDA ME MNE MF MAF MG MDG MH MHL MK MKD
+ML MLI MM MMR MN MNG MO MAC MP MNP MQ MTQ MR MRT MS MSR MT MLT MU MUS MV MDV MW MWI MX MEX MY MYS MZ MOZ NA NAM
+NC NCL NE NER NF NFK NG NGA NI NIC NL NLD NO NOR NP NPL NR NRU NU NIU NZ NZL OM OMN PA PAN PE PER PF PYF PG PNG
+PH PHL PK PAK PL POL PM SPM PN PCN PR PRI PS PSE PT PRT PW PLW PY PRY QA QAT RE REU RO ROU RS SRB RU RUS RW RWA
+SA SAU SB SLB SC SYC SD SDN SE SWE SG SGP SH SHN SI SVN SJ SJM SK SVK SL SLE SM SMR SN SEN SO SOM SR SUR SS SSD
+ST STP SV SLV SX SXM SY SYR SZ SWZ TC TCA TD TCD TF ATF TG TGO TH THA TJ TJK TK TKL TL TLS TM TKM TN TUN TO TON
+TR TUR TT TTO TV TUV TW TWN TZ TZA UA UKR UG UGA UM UMI US USA UY URY UZ UZB VA VAT VC VCT VE VEN VG VGB VI VIR
+VN VNM VU VUT WF WLF WS WSM YE YEM YT MYT ZA ZAF ZM ZMB ZW ZWE`
+
+// iso4217 lists the active ISO 4217 currency and fund codes, including the
+// X codes for precious metals, special drawing rights and testing.
+const iso4217 = `
+AED AFN ALL AMD AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV BRL BSD BTN BWP BYN BZD CAD CDF CHE
+CHF CHW CLF CLP CNY COP COU CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ
+GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL
+LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR
+PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY
+TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED VES VND VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XCG XDR XOF XPD
+XPF XPT XSU XTS XUA XXX YER ZAR ZMW ZWG`
+
+var (
+	countryAlpha2 = codeSet(iso3166, 2)
+	countryAlpha3 = codeSet(iso3166, 3)
+	currencyCodes = codeSet(iso4217, 3)
+)
+
+// codeSet returns the codes of the given length in table.
+func codeSet(table string, size int) map[string]struct{} {
+	codes := map[string]struct{}{}
+	for _, code := range strings.Fields(table) {
+		if len(code) == size {
+			codes[code] = struct{}{}
+		}
+	}
+	return codes
+}
+
+// ISO3166Alpha2 validates that a string is an upper case ISO 3166-1 alpha-2
+// country code, e.g. "US" or "DE".
+func ISO3166Alpha2() ValidateFunc[string] {
+	return codeValidator("iso3166_alpha2", countryAlpha2, ErrNotCountryCode, "alpha-2")
+}
+
+// ISO3166Alpha3 validates that a string is an upper case ISO 3166-1 alpha-3
+// country code, e.g. "USA" or "DEU".
+func ISO3166Alpha3() ValidateFunc[string] {
+	return codeValidator("iso3166_alpha3", countryAlpha3, ErrNotCountryCode, "alpha-3")
+}
+
+// ISO4217 validates that a string is an upper case ISO 4217 currency code,
+// e.g. "USD" or "EUR". Withdrawn currencies are rejected.
+func ISO4217() ValidateFunc[string] {
+	return codeValidator("iso4217", currencyCodes, ErrNotCurrencyCode, "")
+}
+
+func codeValidator(name string, codes map[string]struct{}, err error, kind string) ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, name), func(str string) error {
+			if _, ok := codes[str]; ok {
+				return nil
+			}
+			return lo.Ternary(kind == "", fmt.Errorf("%w: %q", err, str), fmt.Errorf("%w (%s): %q", err, kind, str))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestISOCodeTables(t *testing.T) {
+	if len(countryAlpha2) != 249 || len(countryAlpha3) != 249 {
+		t.Errorf("country codes = %d alpha-2, %d alpha-3, want 249", len(countryAlpha2), len(countryAlpha3))
+	}
+	if len(currencyCodes) < 170 {
+		t.Errorf("currency codes = %d", len(currencyCodes))
+	}
+}
+
+func TestISOCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		vf      ValidateFunc[string]
+		str     string
+		wantErr error
+	}{
+		{"alpha-2", ISO3166Alpha2(), "US", nil},
+		{"alpha-2 lower case", ISO3166Alpha2(), "us", ErrNotCountryCode},
+		{"alpha-2 unassigned", ISO3166Alpha2(), "ZZ", ErrNotCountryCode},
+		{"alpha-2 given alpha-3", ISO3166Alpha2(), "USA", ErrNotCountryCode},
+		{"alpha-3", ISO3166Alpha3(), "DEU", nil},
+		{"alpha-3 given alpha-2", ISO3166Alpha3(), "DE", ErrNotCountryCode},
+		{"alpha-3 empty", ISO3166Alpha3(), "", ErrNotCountryCode},
+		{"currency", ISO4217(), "EUR", nil},
+		{"fund code", ISO4217(), "USN", nil},
+		{"withdrawn currency", ISO4217(), "DEM", ErrNotCurrencyCode},
+		{"country as currency", ISO4217(), "USA", ErrNotCurrencyCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := tt.vf()
+			if err := v(tt.str); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+)
+
+// ErrWeakPassword is wrapped by the PasswordError of a rejected password.
+var ErrWeakPassword = errors.New("password is too weak")
+
+// The checks of Password, as reported by PasswordError.Check.
+const (
+	PasswordCheckLength  = "length"
+	PasswordCheckCharSet = "charset"
+	PasswordCheckCommon  = "common"
+	PasswordCheckRepeat  = "repeat"
+)
+
+// commonPasswords are rejected by Password unless replaced with
+// PasswordBanned.
+var commonPasswords = []string{
+	"123456", "123456789", "12345678", "1234567890", "password", "password1", "password123", "qwerty", "qwerty123",
+	"qwertyuiop", "abc123", "111111", "000000", "123123", "1q2w3e4r", "iloveyou", "admin", "admin123", "welcome",
+	"welcome1", "letmein", "monkey", "dragon", "football", "baseball", "sunshine", "princess", "master", "shadow",
+	"superman", "trustno1", "passw0rd", "p@ssw0rd", "changeme", "secret",
+}
+
+// PasswordError tells which check of Password rejected a password, so
+// clients can point the user at the failed requirement.
+type PasswordError struct {
+	// Check is one of PasswordCheckLength, PasswordCheckCharSet,
+	// PasswordCheckCommon or PasswordCheckRepeat.
+	Check string
+	// Param is the minimum length, the name of the missing character set or
+	// the maximum number of repeated characters; nil for common passwords.
+	Param any
+}
+
+func (e *PasswordError) Error() string {
+	switch e.Check {
+	case PasswordCheckLength:
+		return fmt.Sprintf("%s: must be at least %v characters", ErrWeakPassword, e.Param)
+	case PasswordCheckCharSet:
+		return fmt.Sprintf("%s: must contain %v", ErrWeakPassword, e.Param)
+	case PasswordCheckCommon:
+		return fmt.Sprintf("%s: too common", ErrWeakPassword)
+	default:
+		return fmt.Sprintf("%s: must not repeat a character more than %v times in a row", ErrWeakPassword, e.Param)
+	}
+}
+
+func (e *PasswordError) Unwrap() error { return ErrWeakPassword }
+
+type passwordPolicy struct {
+	minLength int
+	charSets  []charSet
+	banned    []string
+	maxRepeat int
+}
+
+// PasswordOption configures Password.
+type PasswordOption func(p *passwordPolicy)
+
+// PasswordMinLength sets the minimum number of characters, 8 by default.
+func PasswordMinLength(n int) PasswordOption {
+	return func(p *passwordPolicy) { p.minLength = n }
+}
+
+// PasswordRequire requires at least one character of each of the sets.
+func PasswordRequire(charSets ...charSet) PasswordOption {
+	return func(p *passwordPolicy) { p.charSets = append(p.charSets, charSets...) }
+}
+
+// PasswordBanned replaces the built-in list of common passwords. Passwords
+// are compared case-insensitively; no argument disables the check.
+func PasswordBanned(passwords ...string) PasswordOption {
+	return func(p *passwordPolicy) { p.banned = passwords }
+}
+
+// PasswordMaxRepeat limits how many times a character may be repeated in a
+// row, e.g. 2 rejects "aaa". The default 0 does not limit repetitions.
+func PasswordMaxRepeat(n int) PasswordOption {
+	return func(p *passwordPolicy) { p.maxRepeat = n }
+}
+
+// Password validates the strength of a password against a policy: at least
+// 8 characters (counted as runes) and not one of a list of common passwords
+// by default, adjusted with the options:
+//
+//	validator.Password(
+//		validator.PasswordMinLength(12),
+//		validator.PasswordRequire(validator.UpperCaseChar, validator.NumberChar),
+//		validator.PasswordMaxRepeat(2),
+//	)
+//
+// A rejected password fails with a *PasswordError for the first failed check.
+func Password(opts ...PasswordOption) ValidateFunc[string] {
+	p := passwordPolicy{minLength: 8, banned: commonPasswords}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	banned := lo.SliceToMap(p.banned, func(s string) (string, struct{}) { return strings.ToLower(s), struct{}{} })
+	names := lo.Map(p.charSets, func(set charSet, _ int) string {
+		_, name := set.value()
+		return name
+	})
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "password", "min", p.minLength, "charsets", names, "max_repeat", p.maxRepeat), func(str string) error {
+			if utf8.RuneCountInString(str) < p.minLength {
+				return &PasswordError{Check: PasswordCheckLength, Param: p.minLength}
+			}
+			for i, set := range p.charSets {
+				if chars, _ := set.value(); !strings.ContainsAny(str, chars) {
+					return &PasswordError{Check: PasswordCheckCharSet, Param: names[i]}
+				}
+			}
+			if _, ok := banned[strings.ToLower(str)]; ok {
+				return &PasswordError{Check: PasswordCheckCommon}
+			}
+			if p.maxRepeat > 0 && longestRun(str) > p.maxRepeat {
+				return &PasswordError{Check: PasswordCheckRepeat, Param: p.maxRepeat}
+			}
+			return nil
+		}
+	}
+}
+
+// longestRun returns the length of the longest run of a repeated rune in str.
+func longestRun(str string) int {
+	longest, run := 0, 0
+	var last rune
+	for i, r := range []rune(str) {
+		if i > 0 && r == last {
+			run++
+		} else {
+			run = 1
+		}
+		last = r
+		longest = max(longest, run)
+	}
+	return longest
+}
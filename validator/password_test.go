@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPassword(t *testing.T) {
+	strict := Password(PasswordMinLength(10), PasswordRequire(UpperCaseChar, NumberChar), PasswordMaxRepeat(2))
+	tests := []struct {
+		name      string
+		vf        ValidateFunc[string]
+		str       string
+		wantCheck string
+		wantParam any
+	}{
+		{"default ok", Password(), "correct horse", "", nil},
+		{"default too short", Password(), "short", PasswordCheckLength, 8},
+		{"runes not bytes", Password(), "密码密码密码密", PasswordCheckLength, 8},
+		{"common", Password(), "Password123", PasswordCheckCommon, nil},
+		{"banned replaced", Password(PasswordBanned("hunter22")), "Password123", "", nil},
+		{"banned disabled", Password(PasswordBanned()), "password", "", nil},
+		{"strict ok", strict, "Tr0ub4dor&3", "", nil},
+		{"strict too short", strict, "Tr0ub4dor", PasswordCheckLength, 10},
+		{"strict no upper", strict, "tr0ub4dor&3", PasswordCheckCharSet, "upper case characters"},
+		{"strict no number", strict, "Troubadour&", PasswordCheckCharSet, "numbers"},
+		{"strict repeated", strict, "Tr0ub4dooor", PasswordCheckRepeat, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := tt.vf()
+			err := v(tt.str)
+			if tt.wantCheck == "" {
+				if err != nil {
+					t.Errorf("Password() error = %v", err)
+				}
+				return
+			}
+			var perr *PasswordError
+			if !errors.As(err, &perr) || !errors.Is(err, ErrWeakPassword) {
+				t.Fatalf("Password() error = %v, want a PasswordError", err)
+			}
+			if perr.Check != tt.wantCheck || perr.Param != tt.wantParam {
+				t.Errorf("Password() error = %+v, want check %s param %v", perr, tt.wantCheck, tt.wantParam)
+			}
+		})
+	}
+	_, v := strict()
+	if err := v("tr0ub4dor&3"); err.Error() != "password is too weak: must contain upper case characters" {
+		t.Errorf("Password() error = %v", err)
+	}
+}
+
+func TestLongestRun(t *testing.T) {
+	for str, want := range map[string]int{"": 0, "a": 1, "abc": 1, "aab": 2, "abbbc": 3, "ééé": 3} {
+		if got := longestRun(str); got != want {
+			t.Errorf("longestRun(%q) = %d, want %d", str, got, want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+var (
+	ErrMustBePast   = errors.New("must be in the past")
+	ErrMustBeFuture = errors.New("must be in the future")
+	ErrBeforeNow    = errors.New("must be before now by at least")
+	ErrAfterNow     = errors.New("must be after now by at least")
+)
+
+// now is the clock of the temporal validators; tests replace it.
+var now = time.Now
+
+// Past validates that a time is before the moment of validation, e.g. a
+// date of birth or the date of an order already placed.
+func Past() ValidateFunc[time.Time] {
+	return func(rule ...*Rule) (string, Validator[time.Time]) {
+		return Named(rule, "past"), func(t time.Time) error {
+			return lo.Ternary(!t.Before(now()), ErrMustBePast, nil)
+		}
+	}
+}
+
+// Future validates that a time is after the moment of validation, e.g. the
+// expiry of a card or the start of a scheduled job.
+func Future() ValidateFunc[time.Time] {
+	return func(rule ...*Rule) (string, Validator[time.Time]) {
+		return Named(rule, "future"), func(t time.Time) error {
+			return lo.Ternary(!t.After(now()), ErrMustBeFuture, nil)
+		}
+	}
+}
+
+// BeforeNow validates that a time is at least d before the moment of
+// validation. Adult users, for instance, are born at least 18 years ago:
+//
+//	view.Field[time.Time]("birthday", validator.BeforeNow(18*365*24*time.Hour))
+func BeforeNow(d time.Duration) ValidateFunc[time.Time] {
+	lo.Assertf(d >= 0, "BeforeNow requires a non negative duration, got %s", d)
+	return func(rule ...*Rule) (string, Validator[time.Time]) {
+		return Named(rule, "before_now", "duration", d), func(t time.Time) error {
+			return lo.Ternary(t.After(now().Add(-d)), fmt.Errorf("%w %s", ErrBeforeNow, d), nil)
+		}
+	}
+}
+
+// AfterNow validates that a time is at least d after the moment of
+// validation, e.g. a delivery slot booked one hour ahead.
+func AfterNow(d time.Duration) ValidateFunc[time.Time] {
+	lo.Assertf(d >= 0, "AfterNow requires a non negative duration, got %s", d)
+	return func(rule ...*Rule) (string, Validator[time.Time]) {
+		return Named(rule, "after_now", "duration", d), func(t time.Time) error {
+			return lo.Ternary(t.Before(now().Add(d)), fmt.Errorf("%w %s", ErrAfterNow, d), nil)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTemporal(t *testing.T) {
+	fixed := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		name    string
+		vf      ValidateFunc[time.Time]
+		val     time.Time
+		wantErr error
+	}{
+		{"past", Past(), fixed.Add(-time.Second), nil},
+		{"past now", Past(), fixed, ErrMustBePast},
+		{"past future", Past(), fixed.Add(time.Hour), ErrMustBePast},
+		{"future", Future(), fixed.Add(time.Second), nil},
+		{"future now", Future(), fixed, ErrMustBeFuture},
+		{"future past", Future(), fixed.Add(-time.Hour), ErrMustBeFuture},
+		{"before now", BeforeNow(24 * time.Hour), fixed.AddDate(0, 0, -2), nil},
+		{"before now exactly", BeforeNow(24 * time.Hour), fixed.AddDate(0, 0, -1), nil},
+		{"before now too recent", BeforeNow(24 * time.Hour), fixed.Add(-time.Hour), ErrBeforeNow},
+		{"after now", AfterNow(time.Hour), fixed.Add(2 * time.Hour), nil},
+		{"after now exactly", AfterNow(time.Hour), fixed.Add(time.Hour), nil},
+		{"after now too soon", AfterNow(time.Hour), fixed.Add(time.Minute), ErrAfterNow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := tt.vf()
+			if err := v(tt.val); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
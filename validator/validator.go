@@ -1,14 +1,20 @@
 package validator
 
 import (
+	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"math"
+	"net"
 	"net/mail"
 	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/samber/mo"
 	"github.com/tidwall/match"
@@ -22,13 +28,195 @@ type Number interface {
 	uint | uint8 | uint16 | uint32 | uint64 | int | int8 | int16 | int32 | int64 | float32 | float64
 }
 
+// Integer is the subset of Number made of the integer types.
+type Integer interface {
+	uint | uint8 | uint16 | uint32 | uint64 | int | int8 | int16 | int32 | int64
+}
+
 // FieldType is a constraint for the actual Go types we want to validate.
 type FieldType interface {
 	Number | string | time.Time | bool
 }
 
 type Validator[T FieldType] func(v T) error
-type ValidateFunc[T FieldType] func() (string, Validator[T])
+
+// ValidatorCtx is a Validator needing a context, typically to query a
+// database or another service, e.g. to check that an email is not taken.
+// It should return ctx.Err() once ctx is done.
+type ValidatorCtx[T FieldType] func(ctx context.Context, v T) error
+
+// ValidateFunc builds a named Validator. Callers normally invoke it without
+// arguments; passing a non-nil *Rule additionally fills in the validator's
+// description, which is how Describe exposes it to schema exporters. Build
+// one with Custom, or with Named to also describe parameters.
+type ValidateFunc[T FieldType] func(rule ...*Rule) (string, Validator[T])
+
+// Rule describes a validator by its name and the parameters it was built
+// with, keyed by parameter name (e.g. "min_length" with {"min": 3}).
+type Rule struct {
+	Name   string
+	Params map[string]any
+}
+
+// Error codes that are not tied to a validator, see Code.
+const (
+	CodeRequired     = "required"
+	CodeTypeMismatch = "type.mismatch"
+	CodeOverflow     = "number.overflow"
+)
+
+// codes maps validator names to their error codes.
+var codes = map[string]string{
+	"min_length":       "length.min",
+	"max_length":       "length.max",
+	"exact_length":     "length.exact",
+	"length_between":   "length.between",
+	"min_runes":        "length.min_runes",
+	"max_runes":        "length.max_runes",
+	"runes_between":    "length.runes_between",
+	"max_bytes":        "length.max_bytes",
+	"only_contains":    "charset.only",
+	"contains_any":     "charset.any",
+	"contains_all":     "charset.all",
+	"not_contains":     "charset.none",
+	"match":            "string.pattern",
+	"email":            "string.email",
+	"url":              "string.url",
+	"numeric":          "string.numeric",
+	"alphanumeric":     "string.alphanumeric",
+	"base64":           "string.base64",
+	"base64url":        "string.base64url",
+	"slug":             "string.slug",
+	"trimmed":          "string.trimmed",
+	"printable":        "string.printable",
+	"no_control_chars": "string.no_control_chars",
+	"lowercase":        "string.lowercase",
+	"uppercase":        "string.uppercase",
+	"hex":              "string.hex",
+	"hex_color":        "string.hex_color",
+	"mac_address":      "string.mac_address",
+	"time_format":      "string.time_format",
+	"iso3166_alpha2":   "string.country",
+	"iso3166_alpha3":   "string.country",
+	"iso4217":          "string.currency",
+	"past":             "time.past",
+	"future":           "time.future",
+	"before_now":       "time.before_now",
+	"after_now":        "time.after_now",
+	"one_of":           "value.one_of",
+	"gt":               "number.gt",
+	"gte":              "number.gte",
+	"lt":               "number.lt",
+	"lte":              "number.lte",
+	"between":          "number.between",
+	"multiple_of":      "number.multiple_of",
+	"finite":           "number.finite",
+	"latitude":         "geo.latitude",
+	"longitude":        "geo.longitude",
+	"port":             "number.port",
+	"any_of":           "value.any_of",
+	"all_of":           "value.all_of",
+	"password":         "string.password",
+	"be_true":          "bool.true",
+	"be_false":         "bool.false",
+}
+
+// ConstraintError is the error of a validator rejecting a value for a
+// bound, e.g. MinLength or Between, exposing the bound and the offending
+// measure as data so that messages can be rendered without parsing the
+// error string. It unwraps to the validator's sentinel error, so
+// errors.Is(err, ErrLengthMin) still holds.
+type ConstraintError struct {
+	// Code is the error code of the validator, see Code.
+	Code string
+	// Param is the bound of the validator: the minimum of MinLength, the
+	// allowed values of OneOf, or the minimum and maximum of range
+	// validators such as Between, as a slice.
+	Param any
+	// Actual is the rejected value, or its measure for length validators
+	// (the length in bytes or runes, the decoded size).
+	Actual any
+	err    error
+}
+
+func (e *ConstraintError) Error() string { return e.err.Error() }
+func (e *ConstraintError) Unwrap() error { return e.err }
+
+// violation returns the ConstraintError of the validator named name.
+func violation(name string, param, actual any, err error) error {
+	return &ConstraintError{Code: Code(name), Param: param, Actual: actual, err: err}
+}
+
+// Code returns the stable, machine-readable error code of the validator
+// named name (e.g. "length.min" for MinLength, "number.gt" for Gt), so
+// clients can branch on failures without parsing messages. Validators
+// defined outside this package use their name as code. Validators inverted
+// by Not get the code of the inverted validator prefixed with "not."
+// ("not.value.one_of").
+func Code(name string) string {
+	if code, ok := codes[name]; ok {
+		return code
+	}
+	if inner, ok := negated(name); ok {
+		return "not." + Code(inner)
+	}
+	return name
+}
+
+// Describe returns the Rule of vf.
+func Describe[T FieldType](vf ValidateFunc[T]) Rule {
+	var r Rule
+	vf(&r)
+	return r
+}
+
+// Named fills the first non-nil rule in rules with name and the key/value
+// pairs in kv, and returns name. It lets a ValidateFunc written by hand
+// describe itself like the validators of this package:
+//
+//	func MaxItems(max int) validator.ValidateFunc[string] {
+//		return func(rule ...*validator.Rule) (string, validator.Validator[string]) {
+//			return validator.Named(rule, "max_items", "max", max), func(s string) error { ... }
+//		}
+//	}
+//
+// Validators without parameters are simpler to write with Custom.
+func Named(rules []*Rule, name string, kv ...any) string {
+	for _, r := range rules {
+		if r == nil {
+			continue
+		}
+		r.Name = name
+		r.Params = make(map[string]any, len(kv)/2)
+		for i := 0; i+1 < len(kv); i += 2 {
+			r.Params[kv[i].(string)] = kv[i+1]
+		}
+		break
+	}
+	return name
+}
+
+// Custom builds a validator named name from fn, for project-specific
+// constraints:
+//
+//	noSpaces := validator.Custom("no_spaces", func(s string) error {
+//		return lo.Ternary(strings.Contains(s, " "), errors.New("must not contain spaces"), nil)
+//	})
+//	view.Field[string]("username", noSpaces)
+//
+// Like the validators of this package, it is reported under its name: a
+// field rejects two validators of the same name, Describe returns a rule
+// without parameters, and the error code is name itself. name must not be
+// empty nor taken by a validator of this package.
+func Custom[T FieldType](name string, fn func(T) error) ValidateFunc[T] {
+	lo.Assertf(strings.TrimSpace(name) != "", "Custom requires a validator name")
+	_, builtin := codes[name]
+	lo.Assertf(!builtin, "validator name '%s' is reserved", name)
+	lo.Assertf(fn != nil, "Custom validator '%s' requires a function", name)
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, name), fn
+	}
+}
 
 const (
 	LowerCaseChar charSet = iota
@@ -61,12 +249,32 @@ var (
 	ErrNotMatch      = errors.New("not match pattern")
 	ErrNotValidEmail = errors.New("not valid email address")
 	ErrNotValidURL   = errors.New("not valid url")
+	ErrNotNumeric    = errors.New("must contain only digits 0-9")
+	ErrNotAlphanum   = errors.New("must contain only letters a-z, A-Z and digits 0-9")
+	ErrNotBase64     = errors.New("not valid base64")
+	ErrNotSlug       = errors.New("must be lower case words separated by single hyphens")
+	ErrNotTrimmed    = errors.New("must not have leading or trailing whitespace")
+	ErrNotPrintable  = errors.New("must only contain printable characters")
+	ErrControlChar   = errors.New("must not contain control characters")
+	ErrNotLowercase  = errors.New("must be lower case")
+	ErrNotUppercase  = errors.New("must be upper case")
+	ErrNotHex        = errors.New("must be an even number of hexadecimal digits")
+	ErrHexLength     = errors.New("decoded length must be between")
+	ErrNotHexColor   = errors.New("must be a #RGB or #RRGGBB color")
+	ErrNotMAC        = errors.New("not valid MAC address")
+	ErrTimeFormat    = errors.New("does not match time layout")
+	ErrBase64Length  = errors.New("decoded length must be between")
 	ErrNotOneOf      = errors.New("value must be one of")
 	ErrMustGt        = errors.New("must be greater than")
 	ErrMustGte       = errors.New("must be greater than or equal to")
 	ErrMustLt        = errors.New("must be less than")
 	ErrMustLte       = errors.New("must be less than or equal to")
 	ErrMustBetween   = errors.New("must be between")
+	ErrNotMultipleOf = errors.New("must be a multiple of")
+	ErrNotFinite     = errors.New("must be a finite number")
+	ErrNotLatitude   = errors.New("latitude must be between -90 and 90")
+	ErrNotLongitude  = errors.New("longitude must be between -180 and 180")
+	ErrNotPort       = errors.New("must be a port number between")
 	ErrMustBeTrue    = errors.New("must be true")
 	ErrMustBeFalse   = errors.New("must be false")
 )
@@ -90,47 +298,98 @@ func (set charSet) value() (chars string, name string) {
 // --- Clause Validators ---
 
 // MinLength validates that a string's length is at least the specified minimum.
+// Like the other length validators, it counts bytes, as len does: "héllo" is
+// 6 bytes long. Use MinRunes to count characters of multibyte text.
 func MinLength(min int) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "min_length", func(str string) error {
-			return lo.Ternary(len(str) < min, fmt.Errorf("%w %d ", ErrLengthMin, min), nil)
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "min_length", "min", min), func(str string) error {
+			return lo.Ternary(len(str) < min, violation("min_length", min, len(str), fmt.Errorf("%w %d ", ErrLengthMin, min)), nil)
 		}
 	}
 }
 
-// MaxLength validates that a string's length is at most the specified maximum.
+// MaxLength validates that a string's length is at most the specified maximum,
+// in bytes; see MaxRunes.
 func MaxLength(max int) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "max_length", func(str string) error {
-			return lo.Ternary(len(str) > max, fmt.Errorf("%w %d ", ErrLengthMax, max), nil)
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "max_length", "max", max), func(str string) error {
+			return lo.Ternary(len(str) > max, violation("max_length", max, len(str), fmt.Errorf("%w %d ", ErrLengthMax, max)), nil)
 		}
 	}
 }
 
-// ExactLength validates that a string's length is exactly the specified length.
+// ExactLength validates that a string's length is exactly the specified length,
+// in bytes.
 func ExactLength(length int) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "exact_length", func(str string) error {
-			return lo.Ternary(len(str) != length, fmt.Errorf("%w %d characters", ErrLengthExact, length), nil)
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "exact_length", "length", length), func(str string) error {
+			return lo.Ternary(len(str) != length, violation("exact_length", length, len(str), fmt.Errorf("%w %d characters", ErrLengthExact, length)), nil)
 		}
 	}
 
 }
 
-// LengthBetween validates that a string's length is within a given range (inclusive).
+// LengthBetween validates that a string's length is within a given range (inclusive),
+// in bytes; see RunesBetween.
 func LengthBetween(min, max int) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "length_between", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "length_between", "min", min, "max", max), func(str string) error {
 			length := len(str)
-			return lo.Ternary(length < min || length > max, fmt.Errorf("%w %d and %d characters", ErrLengthBetween, min, max), nil)
+			return lo.Ternary(length < min || length > max, violation("length_between", []int{min, max}, length, fmt.Errorf("%w %d and %d characters", ErrLengthBetween, min, max)), nil)
+		}
+	}
+}
+
+// MinRunes validates that a string has at least min characters, counted as
+// runes (Unicode code points) rather than bytes, so a nickname of 100
+// Chinese characters is 100 long, not 300.
+func MinRunes(min int) ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "min_runes", "min", min), func(str string) error {
+			n := utf8.RuneCountInString(str)
+			return lo.Ternary(n < min, violation("min_runes", min, n, fmt.Errorf("%w %d characters", ErrLengthMin, min)), nil)
+		}
+	}
+}
+
+// MaxRunes validates that a string has at most max characters, counted as
+// runes.
+func MaxRunes(max int) ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "max_runes", "max", max), func(str string) error {
+			n := utf8.RuneCountInString(str)
+			return lo.Ternary(n > max, violation("max_runes", max, n, fmt.Errorf("%w %d characters", ErrLengthMax, max)), nil)
+		}
+	}
+}
+
+// RunesBetween validates that a string has between min and max characters
+// (inclusive), counted as runes.
+func RunesBetween(min, max int) ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "runes_between", "min", min, "max", max), func(str string) error {
+			n := utf8.RuneCountInString(str)
+			return lo.Ternary(n < min || n > max, violation("runes_between", []int{min, max}, n, fmt.Errorf("%w %d and %d characters", ErrLengthBetween, min, max)), nil)
+		}
+	}
+}
+
+// MaxBytes validates that a string is at most n bytes long once UTF-8
+// encoded, for columns limited in bytes rather than characters, e.g. the 767
+// byte index prefix of a MySQL utf8mb4 column. It measures the same as
+// MaxLength and states the intent; MaxRunes limits characters.
+func MaxBytes(n int) ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "max_bytes", "max", n), func(str string) error {
+			return lo.Ternary(len(str) > n, violation("max_bytes", n, len(str), fmt.Errorf("%w %d bytes", ErrLengthMax, n)), nil)
 		}
 	}
 }
 
 // CharSetOnly validates that a string only contains characters from the specified character sets.
 func CharSetOnly(charSets ...charSet) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "only_contains", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "only_contains", "charsets", charSets), func(str string) error {
 			var allChars strings.Builder
 			var names []string
 			for _, set := range charSets {
@@ -150,8 +409,8 @@ func CharSetOnly(charSets ...charSet) ValidateFunc[string] {
 
 // CharSetAny validates that a string contains at least one character from any of the specified character sets.
 func CharSetAny(charSets ...charSet) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "contains_any", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "contains_any", "charsets", charSets), func(str string) error {
 			var allChars strings.Builder
 			var names []string
 			for _, set := range charSets {
@@ -166,8 +425,8 @@ func CharSetAny(charSets ...charSet) ValidateFunc[string] {
 
 // CharSetAll validates that a string contains at least one character from each of the specified character sets.
 func CharSetAll(charSets ...charSet) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "contains_all", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "contains_all", "charsets", charSets), func(str string) error {
 			for _, set := range charSets {
 				chars, name := set.value()
 				if !strings.ContainsAny(chars, str) {
@@ -182,8 +441,8 @@ func CharSetAll(charSets ...charSet) ValidateFunc[string] {
 
 // CharSetNo validates that a string does not contain any characters from the specified character sets.
 func CharSetNo(charSets ...charSet) ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "not_contains", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "not_contains", "charsets", charSets), func(str string) error {
 			for _, set := range charSets {
 				chars, name := set.value()
 				if strings.ContainsAny(str, chars) {
@@ -203,8 +462,8 @@ func CharSetNo(charSets ...charSet) ValidateFunc[string] {
 // Example: Match("foo*") will match "foobar", "foo", etc.
 func Match(pattern string) ValidateFunc[string] {
 	lo.Assertf(match.IsPattern(pattern), "invalid pattern `%s`: `?` stands for one character, `*` stands for any number of characters", pattern)
-	return func() (string, Validator[string]) {
-		return "match", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "match", "pattern", pattern), func(str string) error {
 			return lo.Ternary(!match.Match(str, pattern), fmt.Errorf("%w %s", ErrNotMatch, pattern), nil)
 		}
 	}
@@ -212,8 +471,8 @@ func Match(pattern string) ValidateFunc[string] {
 
 // Email validates that a string is a valid email address.
 func Email() ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "email", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "email"), func(str string) error {
 			return lo.Ternary(mo.TupleToResult[*mail.Address](mail.ParseAddress(str)).IsError(), fmt.Errorf("%w:%s", ErrNotValidEmail, str), nil)
 		}
 	}
@@ -221,8 +480,8 @@ func Email() ValidateFunc[string] {
 
 // URL validates that a string is a valid URL.
 func URL() ValidateFunc[string] {
-	return func() (string, Validator[string]) {
-		return "url", func(str string) error {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "url"), func(str string) error {
 			rs := mo.TupleToResult[*url.URL](url.Parse(str))
 			errRs := rs.IsError() || rs.MustGet().Scheme == "" || rs.MustGet().Host == ""
 			return lo.Ternary(errRs, fmt.Errorf("%w: %s", ErrNotValidURL, str), nil)
@@ -230,59 +489,390 @@ func URL() ValidateFunc[string] {
 	}
 }
 
+// NumericString validates that a string only contains the ASCII digits 0-9,
+// e.g. a zip code or an account number that must keep its leading zeros.
+// The empty string is valid; combine with MinLength to require digits.
+func NumericString() ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "numeric"), func(str string) error {
+			return lo.Ternary(!onlyRunes(str, isDigit), ErrNotNumeric, nil)
+		}
+	}
+}
+
+// Alphanumeric validates that a string only contains ASCII letters and
+// digits. The empty string is valid.
+func Alphanumeric() ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "alphanumeric"), func(str string) error {
+			return lo.Ternary(!onlyRunes(str, func(r rune) bool { return isDigit(r) || isLetter(r) }), ErrNotAlphanum, nil)
+		}
+	}
+}
+
+// Base64 validates that a string is standard base64 (RFC 4648 §4), with or
+// without padding. The optional bounds are the minimum and maximum length of
+// the decoded bytes, e.g. Base64(32, 32) for a 256-bit key.
+func Base64(bounds ...int) ValidateFunc[string] {
+	return base64Validator("base64", "std", base64.StdEncoding, bounds)
+}
+
+// Base64URL is Base64 for the URL and file name safe alphabet (RFC 4648 §5),
+// as used by JWTs and URL tokens. Its rule is named "base64url".
+func Base64URL(bounds ...int) ValidateFunc[string] {
+	return base64Validator("base64url", "url", base64.URLEncoding, bounds)
+}
+
+func base64Validator(rule, alphabet string, enc *base64.Encoding, bounds []int) ValidateFunc[string] {
+	lo.Assertf(len(bounds) == 0 || len(bounds) == 2 && bounds[0] <= bounds[1], "base64 bounds must be a minimum and a maximum decoded length")
+	kv := []any{"encoding", alphabet}
+	if len(bounds) == 2 {
+		kv = append(kv, "min", bounds[0], "max", bounds[1])
+	}
+	return func(r ...*Rule) (string, Validator[string]) {
+		return Named(r, rule, kv...), func(str string) error {
+			// the decoders skip line breaks, a value must not contain any
+			if strings.ContainsAny(str, "\r\n") {
+				return fmt.Errorf("%w (%s alphabet)", ErrNotBase64, alphabet)
+			}
+			// padding is optional, but must be complete when present
+			decoded, err := enc.Strict().DecodeString(str)
+			if err != nil && !strings.HasSuffix(str, "=") {
+				decoded, err = enc.WithPadding(base64.NoPadding).Strict().DecodeString(str)
+			}
+			if err != nil {
+				return fmt.Errorf("%w (%s alphabet)", ErrNotBase64, alphabet)
+			}
+			if len(bounds) == 2 && (len(decoded) < bounds[0] || len(decoded) > bounds[1]) {
+				return violation(rule, bounds, len(decoded), fmt.Errorf("%w %d and %d bytes", ErrBase64Length, bounds[0], bounds[1]))
+			}
+			return nil
+		}
+	}
+}
+
+// Trimmed validates that a string has no leading or trailing whitespace, a
+// common leftover of copy and paste.
+func Trimmed() ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "trimmed"), func(str string) error {
+			return lo.Ternary(strings.TrimSpace(str) != str, ErrNotTrimmed, nil)
+		}
+	}
+}
+
+// Printable validates that a string is valid UTF-8 made of printable
+// characters as defined by unicode.IsPrint: letters, marks, numbers,
+// punctuation, symbols and the ASCII space. Tabs, line breaks and other
+// spaces are rejected.
+func Printable() ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "printable"), func(str string) error {
+			return lo.Ternary(!utf8.ValidString(str) || !onlyRunes(str, unicode.IsPrint), ErrNotPrintable, nil)
+		}
+	}
+}
+
+// NoControlChars validates that a string contains no control characters
+// (U+0000-U+001F, U+007F-U+009F), which rejects the CR and LF of header
+// injections and NUL bytes while accepting any other text.
+func NoControlChars() ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "no_control_chars"), func(str string) error {
+			return lo.Ternary(strings.IndexFunc(str, unicode.IsControl) >= 0, ErrControlChar, nil)
+		}
+	}
+}
+
+// Lowercase validates that a string equals its lower case form, so it has no
+// upper case letter in any script; digits and symbols are allowed.
+func Lowercase() ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "lowercase"), func(str string) error {
+			return lo.Ternary(strings.ToLower(str) != str, ErrNotLowercase, nil)
+		}
+	}
+}
+
+// Uppercase validates that a string equals its upper case form, e.g. a
+// ticker symbol.
+func Uppercase() ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "uppercase"), func(str string) error {
+			return lo.Ternary(strings.ToUpper(str) != str, ErrNotUppercase, nil)
+		}
+	}
+}
+
+// SlugOption configures Slug.
+type SlugOption func(s *slugPolicy)
+
+type slugPolicy struct {
+	maxLength int
+	chars     string
+}
+
+// SlugMaxLength limits the length of a slug, unlimited by default.
+func SlugMaxLength(n int) SlugOption {
+	return func(s *slugPolicy) { s.maxLength = n }
+}
+
+// SlugChars allows the ASCII characters of chars in the words of a slug,
+// besides lower case letters and digits, e.g. SlugChars("_").
+func SlugChars(chars string) SlugOption {
+	lo.Assertf(!strings.ContainsAny(chars, "-") && onlyRunes(chars, func(r rune) bool { return r < utf8.RuneSelf }),
+		"slug characters must be ASCII other than '-', got %q", chars)
+	return func(s *slugPolicy) { s.chars = chars }
+}
+
+// Slug validates a URL-safe name such as "hello-world-2": words of lower
+// case ASCII letters and digits separated by single hyphens, with no leading
+// or trailing hyphen. The empty string is not a slug.
+func Slug(opts ...SlugOption) ValidateFunc[string] {
+	var p slugPolicy
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "slug", "max", p.maxLength, "chars", p.chars), func(str string) error {
+			if p.maxLength > 0 && len(str) > p.maxLength {
+				return violation("slug", p.maxLength, len(str), fmt.Errorf("%w %d ", ErrLengthMax, p.maxLength))
+			}
+			for _, word := range strings.Split(str, "-") {
+				if word == "" || !onlyRunes(word, func(r rune) bool { return isDigit(r) || 'a' <= r && r <= 'z' || strings.ContainsRune(p.chars, r) }) {
+					return ErrNotSlug
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// Hex validates that a string is hex encoded bytes: an even number of
+// hexadecimal digits, in either case. The optional bounds are the minimum and
+// maximum number of decoded bytes, e.g. Hex(32, 32) for a SHA-256 digest.
+func Hex(bounds ...int) ValidateFunc[string] {
+	lo.Assertf(len(bounds) == 0 || len(bounds) == 2 && bounds[0] <= bounds[1], "hex bounds must be a minimum and a maximum decoded length")
+	var kv []any
+	if len(bounds) == 2 {
+		kv = append(kv, "min", bounds[0], "max", bounds[1])
+	}
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "hex", kv...), func(str string) error {
+			if len(str)%2 != 0 || !onlyRunes(str, isHexDigit) {
+				return ErrNotHex
+			}
+			if n := len(str) / 2; len(bounds) == 2 && (n < bounds[0] || n > bounds[1]) {
+				return violation("hex", bounds, n, fmt.Errorf("%w %d and %d bytes", ErrHexLength, bounds[0], bounds[1]))
+			}
+			return nil
+		}
+	}
+}
+
+// HexColor validates a CSS hex color, "#RGB" or "#RRGGBB", in either case.
+func HexColor() ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "hex_color"), func(str string) error {
+			digits, ok := strings.CutPrefix(str, "#")
+			ok = ok && (len(digits) == 3 || len(digits) == 6) && onlyRunes(digits, isHexDigit)
+			return lo.Ternary(!ok, ErrNotHexColor, nil)
+		}
+	}
+}
+
+// MACAddress validates a MAC address (EUI-48 or EUI-64) written as hex
+// pairs separated by colons or dashes, e.g. "00:1a:2b:3c:4d:5e" or
+// "00-1A-2B-3C-4D-5E". Separators must not be mixed, and the dotted form
+// also accepted by net.ParseMAC is rejected.
+func MACAddress() ValidateFunc[string] {
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "mac_address"), func(str string) error {
+			ok := len(str) > 2 && (str[2] == ':' || str[2] == '-')
+			if ok {
+				_, err := net.ParseMAC(str)
+				ok = err == nil
+			}
+			return lo.Ternary(!ok, fmt.Errorf("%w: %s", ErrNotMAC, str), nil)
+		}
+	}
+}
+
+// TimeFormat validates that a string parses as a time with layout, in the
+// format of time.Parse, e.g. TimeFormat(time.DateOnly) for "2024-01-31". The
+// field keeps the string as given; use a time.Time field to convert it.
+func TimeFormat(layout string) ValidateFunc[string] {
+	lo.Assertf(layout != "", "TimeFormat requires a layout")
+	return func(rule ...*Rule) (string, Validator[string]) {
+		return Named(rule, "time_format", "layout", layout), func(str string) error {
+			_, err := time.Parse(layout, str)
+			return lo.Ternary(err != nil, fmt.Errorf("%w %q", ErrTimeFormat, layout), nil)
+		}
+	}
+}
+
+// onlyRunes reports whether every rune of str satisfies ok.
+func onlyRunes(str string, ok func(r rune) bool) bool {
+	for _, r := range str {
+		if !ok(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(r rune) bool    { return '0' <= r && r <= '9' }
+func isLetter(r rune) bool   { return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' }
+func isHexDigit(r rune) bool { return isDigit(r) || 'a' <= r && r <= 'f' || 'A' <= r && r <= 'F' }
+
 // --- Generic and Comparison types.Validators ---
 
 // OneOf validates that a value is one of the allowed values.
 // This works for any comparable type in FieldType (string, bool, all numbers).
 func OneOf[T FieldType](allowed ...T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "one_of", func(val T) error {
-			return lo.Ternary(!lo.Contains(allowed, val), fmt.Errorf("%w:%v", ErrNotOneOf, allowed), nil)
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "one_of", "values", allowed), func(val T) error {
+			return lo.Ternary(!lo.Contains(allowed, val), violation("one_of", allowed, val, fmt.Errorf("%w:%v", ErrNotOneOf, allowed)), nil)
 		}
 	}
 }
 
+// OneOfStringer validates that a string is the String() of one of the allowed
+// values, so a Go enum type drives the allowed values of its JSON form:
+//
+//	type Status int
+//	func (s Status) String() string { ... }
+//
+//	view.Field[string]("status", validator.OneOfStringer(Active, Suspended))
+//
+// It is OneOf over the strings of allowed and is described, reported and
+// exported as such.
+func OneOfStringer[T fmt.Stringer](allowed ...T) ValidateFunc[string] {
+	return OneOf(lo.Map(allowed, func(v T, _ int) string { return v.String() })...)
+}
+
 // Gt validates that a value is greater than the specified minimum.
 func Gt[T Number | time.Time](min T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "gt", func(val T) error {
-			return lo.Ternary(!isGreaterThan(val, min), fmt.Errorf("%w %v", ErrMustGt, min), nil)
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "gt", "min", min), func(val T) error {
+			return lo.Ternary(!isGreaterThan(val, min), violation("gt", min, val, fmt.Errorf("%w %v", ErrMustGt, min)), nil)
 		}
 	}
 }
 
 // Gte validates that a value is greater than or equal to the specified minimum.
 func Gte[T Number | time.Time](min T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "gte", func(val T) error {
-			return lo.Ternary(isLessThan(val, min), fmt.Errorf("%w %v", ErrMustGte, min), nil)
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "gte", "min", min), func(val T) error {
+			return lo.Ternary(isLessThan(val, min), violation("gte", min, val, fmt.Errorf("%w %v", ErrMustGte, min)), nil)
 		}
 	}
 }
 
 // Lt validates that a value is less than the specified maximum.
 func Lt[T Number | time.Time](max T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "lt", func(val T) error {
-			return lo.Ternary(!isLessThan(val, max), fmt.Errorf("%w %v", ErrMustLt, max), nil)
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "lt", "max", max), func(val T) error {
+			return lo.Ternary(!isLessThan(val, max), violation("lt", max, val, fmt.Errorf("%w %v", ErrMustLt, max)), nil)
 		}
 	}
 }
 
 // Lte validates that a value is less than or equal to the specified maximum.
 func Lte[T Number | time.Time](max T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "lte", func(val T) error {
-			return lo.Ternary(isGreaterThan(val, max), fmt.Errorf("%w %v", ErrMustLte, max), nil)
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "lte", "max", max), func(val T) error {
+			return lo.Ternary(isGreaterThan(val, max), violation("lte", max, val, fmt.Errorf("%w %v", ErrMustLte, max)), nil)
 		}
 	}
 }
 
 // Between validates that a value is within a given range (inclusive of min and max).
 func Between[T Number | time.Time](min, max T) ValidateFunc[T] {
-	return func() (string, Validator[T]) {
-		return "between", func(val T) error {
-			return lo.Ternary(isLessThan(val, min) || isGreaterThan(val, max), fmt.Errorf("%w %v and %v", ErrMustBetween, min, max), nil)
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "between", "min", min, "max", max), func(val T) error {
+			return lo.Ternary(isLessThan(val, min) || isGreaterThan(val, max), violation("between", []T{min, max}, val, fmt.Errorf("%w %v and %v", ErrMustBetween, min, max)), nil)
+		}
+	}
+}
+
+// MultipleOf validates that a number is an integer multiple of step, like
+// the JSON Schema keyword multipleOf, e.g. MultipleOf(5) for quantities sold
+// by five or MultipleOf(0.05) for prices. Floats are compared with a relative
+// tolerance, so 0.15 is a multiple of 0.05 despite binary rounding.
+func MultipleOf[T Number](step T) ValidateFunc[T] {
+	lo.Assertf(step > 0, "MultipleOf requires a positive step, got %v", step)
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "multiple_of", "step", step), func(val T) error {
+			return lo.Ternary(!isMultipleOf(val, step), violation("multiple_of", step, val, fmt.Errorf("%w %v", ErrNotMultipleOf, step)), nil)
+		}
+	}
+}
+
+// isMultipleOf reports whether val is an integer multiple of step.
+func isMultipleOf[T Number](val, step T) bool {
+	switch any(step).(type) {
+	case float32, float64:
+		// float32 values carry about 7 significant digits
+		epsilon := lo.Ternary[float64](reflect.TypeFor[T]().Kind() == reflect.Float32, 1e-6, 1e-9)
+		q := float64(val) / float64(step)
+		return !math.IsInf(q, 0) && math.Abs(q-math.Round(q)) <= epsilon*math.Max(1, math.Abs(q))
+	case uint, uint8, uint16, uint32, uint64:
+		return uint64(val)%uint64(step) == 0
+	default:
+		return int64(val)%int64(step) == 0
+	}
+}
+
+// Finite validates that a float is neither NaN nor ±Inf. JSON numbers beyond
+// the range of float64, such as 1.8e+308, are parsed as +Inf and would
+// otherwise pass any field without range validators.
+func Finite[T float32 | float64]() ValidateFunc[T] {
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "finite"), func(val T) error {
+			f := float64(val)
+			return lo.Ternary(math.IsNaN(f) || math.IsInf(f, 0), ErrNotFinite, nil)
+		}
+	}
+}
+
+// Latitude validates a latitude in decimal degrees, between -90 and 90.
+func Latitude[T float32 | float64]() ValidateFunc[T] {
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "latitude", "min", -90, "max", 90), func(val T) error {
+			// written so that NaN fails too
+			return lo.Ternary(!(val >= -90 && val <= 90), ErrNotLatitude, nil)
+		}
+	}
+}
+
+// Longitude validates a longitude in decimal degrees, between -180 and 180.
+func Longitude[T float32 | float64]() ValidateFunc[T] {
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "longitude", "min", -180, "max", 180), func(val T) error {
+			return lo.Ternary(!(val >= -180 && val <= 180), ErrNotLongitude, nil)
+		}
+	}
+}
+
+// PortOption configures Port.
+type PortOption func(minPort *int)
+
+// AllowAutoPort makes Port accept 0, commonly meaning "pick any free port".
+func AllowAutoPort() PortOption {
+	return func(minPort *int) { *minPort = 0 }
+}
+
+// Port validates a TCP/UDP port number, between 1 and 65535.
+func Port[T Integer](opts ...PortOption) ValidateFunc[T] {
+	minPort := 1
+	for _, opt := range opts {
+		opt(&minPort)
+	}
+	return func(rule ...*Rule) (string, Validator[T]) {
+		return Named(rule, "port", "min", minPort, "max", 65535), func(val T) error {
+			ok := val >= 0 && uint64(val) >= uint64(minPort) && uint64(val) <= 65535
+			return lo.Ternary(!ok, violation("port", []int{minPort, 65535}, val, fmt.Errorf("%w %d and 65535", ErrNotPort, minPort)), nil)
 		}
 	}
 }
@@ -291,8 +881,8 @@ func Between[T Number | time.Time](min, max T) ValidateFunc[T] {
 
 // BeTrue validates that a boolean value is true.
 func BeTrue() ValidateFunc[bool] {
-	return func() (string, Validator[bool]) {
-		return "be_true", func(b bool) error {
+	return func(rule ...*Rule) (string, Validator[bool]) {
+		return Named(rule, "be_true"), func(b bool) error {
 			return lo.Ternary(!b, ErrMustBeTrue, nil)
 		}
 	}
@@ -300,8 +890,8 @@ func BeTrue() ValidateFunc[bool] {
 
 // BeFalse validates that a boolean value is false.
 func BeFalse() ValidateFunc[bool] {
-	return func() (string, Validator[bool]) {
-		return "be_false", func(b bool) error {
+	return func(rule ...*Rule) (string, Validator[bool]) {
+		return Named(rule, "be_false"), func(b bool) error {
 			return lo.Ternary(b, ErrMustBeFalse, nil)
 		}
 	}
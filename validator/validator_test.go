@@ -1,7 +1,12 @@
 package validator
 
 import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Full set of tests migrated from meta/constraint_test.go
@@ -171,3 +176,570 @@ func TestSmokeValidatorsCompile(t *testing.T) {
 	_, b1 := BeFalse()()
 	_ = b1(false)
 }
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       Rule
+		wantName   string
+		wantParams map[string]any
+	}{
+		{"min length", Describe(MinLength(3)), "min_length", map[string]any{"min": 3}},
+		{"length between", Describe(LengthBetween(1, 5)), "length_between", map[string]any{"min": 1, "max": 5}},
+		{"between", Describe(Between[int](1, 3)), "between", map[string]any{"min": 1, "max": 3}},
+		{"one of", Describe(OneOf("a", "b")), "one_of", map[string]any{"values": []string{"a", "b"}}},
+		{"email", Describe(Email()), "email", map[string]any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.rule.Name != tt.wantName {
+				t.Errorf("Describe() name = %v, want %v", tt.rule.Name, tt.wantName)
+			}
+			if !reflect.DeepEqual(tt.rule.Params, tt.wantParams) {
+				t.Errorf("Describe() params = %v, want %v", tt.rule.Params, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{Describe(MinLength(1)).Name, "length.min"},
+		{Describe(Gt(1)).Name, "number.gt"},
+		{Describe(Email()).Name, "string.email"},
+		{Describe(OneOf("a")).Name, "value.one_of"},
+		{Describe(BeTrue()).Name, "bool.true"},
+		{Describe(NumericString()).Name, "string.numeric"},
+		{Describe(Base64()).Name, "string.base64"},
+		{Describe(Base64URL()).Name, "string.base64url"},
+		{"no_spaces", "no_spaces"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Code(tt.name); got != tt.want {
+				t.Errorf("Code() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumericString(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		wantErr bool
+	}{
+		{"digits", "0123", false},
+		{"empty", "", false},
+		{"sign", "-1", true},
+		{"decimal point", "1.5", true},
+		{"letters", "12a", true},
+		{"non ascii digit", "١٢", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := NumericString()()
+			err := v(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NumericString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err != ErrNotNumeric {
+				t.Errorf("NumericString() error = %v, want %v", err, ErrNotNumeric)
+			}
+		})
+	}
+}
+
+func TestAlphanumeric(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		wantErr bool
+	}{
+		{"letters and digits", "abcXYZ019", false},
+		{"empty", "", false},
+		{"space", "ab c", true},
+		{"underscore", "ab_c", true},
+		{"accented", "café", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := Alphanumeric()()
+			if err := v(tt.str); (err != nil) != tt.wantErr {
+				t.Errorf("Alphanumeric() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBase64(t *testing.T) {
+	tests := []struct {
+		name    string
+		vf      ValidateFunc[string]
+		str     string
+		wantErr error
+	}{
+		{"padded", Base64(), "aGVsbG8=", nil},
+		{"unpadded", Base64(), "aGVsbG8", nil},
+		{"empty", Base64(), "", nil},
+		{"incomplete padding", Base64(), "aGVsbA=", ErrNotBase64},
+		{"url alphabet in std", Base64(), "-_8=", ErrNotBase64},
+		{"std alphabet in url", Base64URL(), "+/8=", ErrNotBase64},
+		{"url", Base64URL(), "-_8", nil},
+		{"not base64", Base64(), "hello world", ErrNotBase64},
+		{"line break", Base64(), "aGVs\nbG8=", ErrNotBase64},
+		{"within bounds", Base64(5, 5), "aGVsbG8=", nil},
+		{"too short", Base64(6, 10), "aGVsbG8=", ErrBase64Length},
+		{"too long", Base64(1, 4), "aGVsbG8=", ErrBase64Length},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := tt.vf()
+			if err := v(tt.str); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Base64() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if rule := Describe(Base64URL(16, 32)); rule.Name != "base64url" || !reflect.DeepEqual(rule.Params, map[string]any{"encoding": "url", "min": 16, "max": 32}) {
+		t.Errorf("Describe() = %v %v", rule.Name, rule.Params)
+	}
+}
+
+func TestMultipleOf(t *testing.T) {
+	ints := []struct {
+		val, step int
+		wantErr   bool
+	}{
+		{10, 5, false},
+		{0, 5, false},
+		{-15, 5, false},
+		{12, 5, true},
+	}
+	for _, tt := range ints {
+		_, v := MultipleOf(tt.step)()
+		if err := v(tt.val); (err != nil) != tt.wantErr {
+			t.Errorf("MultipleOf(%d)(%d) error = %v, wantErr %v", tt.step, tt.val, err, tt.wantErr)
+		}
+	}
+	floats := []struct {
+		val, step float64
+		wantErr   bool
+	}{
+		{0.15, 0.05, false},
+		{19.95, 0.05, false},
+		{1e6 + 0.05, 0.05, false},
+		{0.17, 0.05, true},
+		{1.0, 0.3, true},
+	}
+	for _, tt := range floats {
+		_, v := MultipleOf(tt.step)()
+		if err := v(tt.val); (err != nil) != tt.wantErr {
+			t.Errorf("MultipleOf(%v)(%v) error = %v, wantErr %v", tt.step, tt.val, err, tt.wantErr)
+		}
+	}
+	_, f32 := MultipleOf[float32](0.05)()
+	if err := f32(0.15); err != nil {
+		t.Errorf("MultipleOf float32 error = %v", err)
+	}
+	_, u := MultipleOf[uint8](4)()
+	if err := u(6); !errors.Is(err, ErrNotMultipleOf) {
+		t.Errorf("MultipleOf uint8 error = %v", err)
+	}
+}
+
+func TestFinite(t *testing.T) {
+	_, v := Finite[float64]()()
+	for _, f := range []float64{0, -1.5, math.MaxFloat64} {
+		if err := v(f); err != nil {
+			t.Errorf("Finite(%v) error = %v", f, err)
+		}
+	}
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if err := v(f); err != ErrNotFinite {
+			t.Errorf("Finite(%v) error = %v, want %v", f, err, ErrNotFinite)
+		}
+	}
+	_, v32 := Finite[float32]()()
+	if err := v32(float32(math.Inf(1))); err != ErrNotFinite {
+		t.Errorf("Finite float32 error = %v", err)
+	}
+}
+
+func TestCustom(t *testing.T) {
+	noSpaces := Custom("no_spaces", func(s string) error {
+		if strings.Contains(s, " ") {
+			return errors.New("must not contain spaces")
+		}
+		return nil
+	})
+	name, v := noSpaces()
+	if name != "no_spaces" {
+		t.Errorf("Custom() name = %v, want no_spaces", name)
+	}
+	if err := v("a b"); err == nil || err.Error() != "must not contain spaces" {
+		t.Errorf("Custom() error = %v", err)
+	}
+	if err := v("ab"); err != nil {
+		t.Errorf("Custom() error = %v", err)
+	}
+	if rule := Describe(noSpaces); !reflect.DeepEqual(rule, Rule{Name: "no_spaces", Params: map[string]any{}}) {
+		t.Errorf("Describe() = %v", rule)
+	}
+	for name, build := range map[string]func(){
+		"empty name":    func() { Custom[string](" ", func(string) error { return nil }) },
+		"reserved name": func() { Custom[string]("email", func(string) error { return nil }) },
+		"nil func":      func() { Custom[string]("no_spaces", nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Custom() did not panic")
+				}
+			}()
+			build()
+		})
+	}
+}
+
+func TestNamed(t *testing.T) {
+	maxWords := func(max int) ValidateFunc[string] {
+		return func(rule ...*Rule) (string, Validator[string]) {
+			return Named(rule, "max_words", "max", max), func(s string) error {
+				if len(strings.Fields(s)) > max {
+					return errors.New("too many words")
+				}
+				return nil
+			}
+		}
+	}
+	name, v := maxWords(2)()
+	if name != "max_words" || v("a b c") == nil || v("a b") != nil {
+		t.Errorf("Named() name = %v", name)
+	}
+	if rule := Describe(maxWords(2)); !reflect.DeepEqual(rule, Rule{Name: "max_words", Params: map[string]any{"max": 2}}) {
+		t.Errorf("Describe() = %v", rule)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name    string
+		vf      ValidateFunc[string]
+		str     string
+		wantErr error
+	}{
+		{"slug", Slug(), "hello-world-2", nil},
+		{"single word", Slug(), "go", nil},
+		{"empty", Slug(), "", ErrNotSlug},
+		{"upper case", Slug(), "Hello-world", ErrNotSlug},
+		{"leading hyphen", Slug(), "-hello", ErrNotSlug},
+		{"trailing hyphen", Slug(), "hello-", ErrNotSlug},
+		{"double hyphen", Slug(), "hello--world", ErrNotSlug},
+		{"underscore", Slug(), "hello_world", ErrNotSlug},
+		{"allowed underscore", Slug(SlugChars("_")), "hello_world-2", nil},
+		{"within max length", Slug(SlugMaxLength(5)), "ab-cd", nil},
+		{"too long", Slug(SlugMaxLength(5)), "ab-cde", ErrLengthMax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := tt.vf()
+			if err := v(tt.str); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Slug() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWhitespaceAndControlChars(t *testing.T) {
+	tests := []struct {
+		name    string
+		vf      ValidateFunc[string]
+		str     string
+		wantErr error
+	}{
+		{"trimmed", Trimmed(), "hello world", nil},
+		{"trimmed empty", Trimmed(), "", nil},
+		{"leading space", Trimmed(), " hello", ErrNotTrimmed},
+		{"trailing newline", Trimmed(), "hello\n", ErrNotTrimmed},
+		{"trailing nbsp", Trimmed(), "hello\u00a0", ErrNotTrimmed},
+		{"printable", Printable(), "Grüße, 世界! ✓", nil},
+		{"printable tab", Printable(), "a\tb", ErrNotPrintable},
+		{"printable zero width space", Printable(), "a\u200bb", ErrNotPrintable},
+		{"printable invalid utf8", Printable(), "a\xffb", ErrNotPrintable},
+		{"no control chars", NoControlChars(), "Grüße 世界", nil},
+		{"header injection", NoControlChars(), "value\r\nX-Injected: 1", ErrControlChar},
+		{"nul", NoControlChars(), "a\x00b", ErrControlChar},
+		{"c1 control", NoControlChars(), "a\u0085b", ErrControlChar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := tt.vf()
+			if err := v(tt.str); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunes(t *testing.T) {
+	nickname := strings.Repeat("龙", 100)
+	tests := []struct {
+		name    string
+		vf      ValidateFunc[string]
+		str     string
+		wantErr error
+	}{
+		{"min runes", MinRunes(5), "héllo", nil},
+		{"min runes short", MinRunes(5), "héll", ErrLengthMin},
+		{"min bytes counts bytes", MinLength(5), "héll", nil},
+		{"max runes", MaxRunes(100), nickname, nil},
+		{"max runes long", MaxRunes(99), nickname, ErrLengthMax},
+		{"max bytes counts bytes", MaxLength(100), nickname, ErrLengthMax},
+		{"max bytes", MaxBytes(300), nickname, nil},
+		{"max bytes exceeded", MaxBytes(299), nickname, ErrLengthMax},
+		{"max bytes ascii", MaxBytes(5), "hello", nil},
+		{"runes between", RunesBetween(2, 4), "日本語", nil},
+		{"runes between short", RunesBetween(2, 4), "日", ErrLengthBetween},
+		{"runes between long", RunesBetween(2, 4), "日本語です", ErrLengthBetween},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := tt.vf()
+			if err := v(tt.str); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		vf      ValidateFunc[string]
+		str     string
+		wantErr error
+	}{
+		{"lower", Lowercase(), "hello-world_42", nil},
+		{"lower empty", Lowercase(), "", nil},
+		{"lower non latin", Lowercase(), "straße ελλάδα", nil},
+		{"lower with upper", Lowercase(), "hello World", ErrNotLowercase},
+		{"lower with greek upper", Lowercase(), "Ελλάδα", ErrNotLowercase},
+		{"upper", Uppercase(), "USD-2024", nil},
+		{"upper with lower", Uppercase(), "USd", ErrNotUppercase},
+		{"upper cyrillic", Uppercase(), "МОСКВА", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := tt.vf()
+			if err := v(tt.str); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHex(t *testing.T) {
+	sha256 := strings.Repeat("ab", 32)
+	tests := []struct {
+		name    string
+		vf      ValidateFunc[string]
+		str     string
+		wantErr error
+	}{
+		{"hex", Hex(), "deadBEEF", nil},
+		{"empty", Hex(), "", nil},
+		{"odd length", Hex(), "abc", ErrNotHex},
+		{"not hex", Hex(), "abcg", ErrNotHex},
+		{"prefixed", Hex(), "0xab", ErrNotHex},
+		{"digest", Hex(32, 32), sha256, nil},
+		{"digest too short", Hex(32, 32), sha256[2:], ErrHexLength},
+		{"color short", HexColor(), "#fFf", nil},
+		{"color long", HexColor(), "#00FF7f", nil},
+		{"color without hash", HexColor(), "00ff7f", ErrNotHexColor},
+		{"color with alpha", HexColor(), "#00ff7f80", ErrNotHexColor},
+		{"color not hex", HexColor(), "#ggg", ErrNotHexColor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := tt.vf()
+			if err := v(tt.str); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLatitudeLongitude(t *testing.T) {
+	_, lat := Latitude[float64]()()
+	_, lng := Longitude[float64]()()
+	tests := []struct {
+		name    string
+		v       Validator[float64]
+		val     float64
+		wantErr error
+	}{
+		{"latitude", lat, 48.8566, nil},
+		{"south pole", lat, -90, nil},
+		{"latitude out of range", lat, 90.0001, ErrNotLatitude},
+		{"latitude nan", lat, math.NaN(), ErrNotLatitude},
+		{"longitude", lng, 2.3522, nil},
+		{"antimeridian", lng, -180, nil},
+		{"longitude out of range", lng, 181, ErrNotLongitude},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.v(tt.val); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPort(t *testing.T) {
+	tests := []struct {
+		name    string
+		vf      ValidateFunc[int]
+		val     int
+		wantErr bool
+	}{
+		{"http", Port[int](), 80, false},
+		{"highest", Port[int](), 65535, false},
+		{"zero", Port[int](), 0, true},
+		{"auto", Port[int](AllowAutoPort()), 0, false},
+		{"negative", Port[int](AllowAutoPort()), -1, true},
+		{"too high", Port[int](), 65536, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := tt.vf()
+			if err := v(tt.val); (err != nil) != tt.wantErr {
+				t.Errorf("Port() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	_, u16 := Port[uint16]()()
+	if err := u16(0); !errors.Is(err, ErrNotPort) {
+		t.Errorf("Port uint16 error = %v", err)
+	}
+	_, u64 := Port[uint64]()()
+	if err := u64(1 << 40); !errors.Is(err, ErrNotPort) {
+		t.Errorf("Port uint64 error = %v", err)
+	}
+}
+
+func TestMACAddress(t *testing.T) {
+	tests := []struct {
+		str     string
+		wantErr bool
+	}{
+		{"00:1a:2b:3c:4d:5e", false},
+		{"00-1A-2B-3C-4D-5E", false},
+		{"02:00:5e:10:00:00:00:01", false},
+		{"00:1a-2b:3c:4d:5e", true},
+		{"001a.2b3c.4d5e", true},
+		{"00:1a:2b:3c:4d", true},
+		{"00:1a:2b:3c:4d:zz", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			_, v := MACAddress()()
+			if err := v(tt.str); (err != nil) != tt.wantErr {
+				t.Errorf("MACAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type testStatus int
+
+const (
+	statusActive testStatus = iota
+	statusSuspended
+	statusClosed
+)
+
+func (s testStatus) String() string {
+	return [...]string{"active", "suspended", "closed"}[s]
+}
+
+func TestOneOfStringer(t *testing.T) {
+	vf := OneOfStringer(statusActive, statusSuspended)
+	_, v := vf()
+	if err := v("suspended"); err != nil {
+		t.Errorf("OneOfStringer() error = %v", err)
+	}
+	if err := v(statusClosed.String()); !errors.Is(err, ErrNotOneOf) {
+		t.Errorf("OneOfStringer() error = %v, want %v", err, ErrNotOneOf)
+	}
+	if rule := Describe(vf); !reflect.DeepEqual(rule, Rule{Name: "one_of", Params: map[string]any{"values": []string{"active", "suspended"}}}) {
+		t.Errorf("Describe() = %v", rule)
+	}
+}
+
+func TestTimeFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  string
+		str     string
+		wantErr bool
+	}{
+		{"date", time.DateOnly, "2024-01-31", false},
+		{"invalid date", time.DateOnly, "2024-02-30", true},
+		{"date time for date", time.DateOnly, "2024-01-31T10:00:00Z", true},
+		{"rfc3339", time.RFC3339, "2024-01-31T10:00:00+02:00", false},
+		{"rfc3339 without zone", time.RFC3339, "2024-01-31T10:00:00", true},
+		{"custom", "02/01/2006", "31/01/2024", false},
+		{"custom swapped", "02/01/2006", "01/31/2024", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, v := TimeFormat(tt.layout)()
+			if err := v(tt.str); (err != nil) != tt.wantErr {
+				t.Errorf("TimeFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConstraintError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		sentinel   error
+		wantCode   string
+		wantParam  any
+		wantActual any
+	}{
+		{"min length", built(MinLength(5)())("abc"), ErrLengthMin, "length.min", 5, 3},
+		{"min runes", built(MinRunes(5)())("日本語"), ErrLengthMin, "length.min_runes", 5, 3},
+		{"length between", built(LengthBetween(1, 2)())("abc"), ErrLengthBetween, "length.between", []int{1, 2}, 3},
+		{"gt", built(Gt(10)())(3), ErrMustGt, "number.gt", 10, 3},
+		{"between", built(Between(1.5, 2.5)())(3.0), ErrMustBetween, "number.between", []float64{1.5, 2.5}, 3.0},
+		{"one of", built(OneOf("a", "b")())("c"), ErrNotOneOf, "value.one_of", []string{"a", "b"}, "c"},
+		{"hex", built(Hex(4, 4)())("abcd"), ErrHexLength, "string.hex", []int{4, 4}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ce *ConstraintError
+			if !errors.As(tt.err, &ce) {
+				t.Fatalf("error %v is not a ConstraintError", tt.err)
+			}
+			if !errors.Is(tt.err, tt.sentinel) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.sentinel)
+			}
+			if ce.Code != tt.wantCode || !reflect.DeepEqual(ce.Param, tt.wantParam) || !reflect.DeepEqual(ce.Actual, tt.wantActual) {
+				t.Errorf("ConstraintError = %+v, want {%s %v %v}", ce, tt.wantCode, tt.wantParam, tt.wantActual)
+			}
+		})
+	}
+	if err := built(MinLength(5)())("abc"); err.Error() != "length must be at least 5 " {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+// built returns the validator built by a ValidateFunc.
+func built[T FieldType](_ string, v Validator[T]) Validator[T] { return v }
@@ -0,0 +1,130 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kcmvp/xql/internal"
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+)
+
+// access tells in which direction a field travels, see ReadOnly and
+// WriteOnly.
+type access int
+
+const (
+	readWrite access = iota
+	readOnly
+	writeOnly
+)
+
+// ReadOnly marks a field that is only sent back to clients, such as a
+// generated id: validation rejects a payload or URL parameter setting it,
+// and it is never required. JSONSchema marks it with `readOnly`.
+func (f *JSONField[T]) ReadOnly() *JSONField[T] {
+	lo.Assertf(f.access != writeOnly, "xql: field '%s' cannot be both read-only and write-only", f.Name())
+	f.access = readOnly
+	f.required = false
+	return f
+}
+
+// WriteOnly marks a field that is accepted from clients but never sent
+// back, such as a password: Schema.Output drops it. JSONSchema marks it
+// with `writeOnly`.
+func (f *JSONField[T]) WriteOnly() *JSONField[T] {
+	lo.Assertf(f.access != readOnly, "xql: field '%s' cannot be both read-only and write-only", f.Name())
+	f.access = writeOnly
+	return f
+}
+
+func (f *JSONField[T]) fieldAccess() access {
+	return f.access
+}
+
+// accessOf returns the access of a field, readWrite unless it was marked
+// with ReadOnly or WriteOnly.
+func accessOf(field ViewField) access {
+	if a, ok := field.(interface{ fieldAccess() access }); ok {
+		return a.fieldAccess()
+	}
+	return readWrite
+}
+
+// readOnlyError is the error of a read-only field set by a client.
+func readOnlyError(field ViewField) error {
+	return fmt.Errorf("field '%s': %w", field.Name(), &ruleError{
+		rule: validator.Rule{Name: "read_only", Params: map[string]any{}},
+		err:  errors.New("is read-only"),
+	})
+}
+
+// Output returns vo as it should be sent back to clients: the fields
+// marked WriteOnly are dropped, including those of embedded objects and
+// arrays of objects. vo is not modified.
+//
+//	res := schema.Validate(body)
+//	json.Marshal(schema.Output(res.MustGet())) // no "password"
+func (s *Schema) Output(vo ValueObject) ValueObject {
+	out := valueObject{Data: s.output(asData(vo))}
+	if v, ok := vo.(valueObject); ok {
+		out.sensitive, out.encoded = v.sensitive, v.encoded
+	}
+	return out
+}
+
+// output returns a copy of data without the write-only fields of s.
+func (s *Schema) output(data internal.Data) internal.Data {
+	for _, field := range s.fields {
+		path := strings.Split(field.UniqueName(), ".")
+		if accessOf(field) == writeOnly {
+			data = rewrite(data, path, func(any) (any, bool) { return nil, false })
+			continue
+		}
+		nested, ok := field.embeddedObject().Get()
+		if !ok {
+			continue
+		}
+		data = rewrite(data, path, func(v any) (any, bool) {
+			switch v := v.(type) {
+			case ValueObject:
+				return nested.Output(v), true
+			case []ValueObject:
+				return lo.Map(v, func(item ValueObject, _ int) ValueObject { return nested.Output(item) }), true
+			default:
+				return v, true
+			}
+		})
+	}
+	return data
+}
+
+// rewrite returns a copy of data with the value at path replaced by fn, or
+// removed when fn reports false. Maps along the path are copied; data is
+// returned unchanged when path does not exist.
+func rewrite(data internal.Data, path []string, fn func(any) (any, bool)) internal.Data {
+	v, ok := data[path[0]]
+	if !ok {
+		return data
+	}
+	if len(path) > 1 {
+		inner, isData := v.(internal.Data)
+		if !isData {
+			return data
+		}
+		v, ok = rewrite(inner, path[1:], fn), true
+	} else {
+		v, ok = fn(v)
+	}
+	out := make(internal.Data, len(data))
+	for k, val := range data {
+		out[k] = val
+	}
+	if ok {
+		out[path[0]] = v
+	} else {
+		delete(out, path[0])
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package view
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONField_ReadOnly(t *testing.T) {
+	schema := WithFields(
+		Field[int64]("id").ReadOnly(),
+		Field[string]("name"),
+	)
+	tests := []struct {
+		name   string
+		json   string
+		params url.Values
+		err    bool
+	}{
+		{name: "absent", json: `{"name":"a"}`},
+		{name: "set in json", json: `{"id":1,"name":"a"}`, err: true},
+		{name: "set in url", json: `{"name":"a"}`, params: url.Values{"id": {"1"}}, err: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := schema.ValidateValues(tc.json, tc.params)
+			if !tc.err {
+				require.NoError(t, res.Error())
+				return
+			}
+			var verr ValidationError
+			require.ErrorAs(t, res.Error(), &verr)
+			require.Equal(t, map[string]string{"id": "is read-only"}, verr.Errors())
+			require.Equal(t, map[string]string{"id": "read_only"}, verr.Codes())
+		})
+	}
+
+	t.Run("form", func(t *testing.T) {
+		res := schema.ValidateForm(url.Values{"id": {"1"}, "name": {"a"}})
+		require.ErrorContains(t, res.Error(), "is read-only")
+	})
+
+	t.Run("exclusive markers", func(t *testing.T) {
+		require.Panics(t, func() { Field[string]("a").ReadOnly().WriteOnly() })
+		require.Panics(t, func() { Field[string]("a").WriteOnly().ReadOnly() })
+	})
+}
+
+func TestSchema_Output(t *testing.T) {
+	credentials := WithFields(
+		Field[string]("user"),
+		Field[string]("secret").WriteOnly(),
+	)
+	schema := WithFields(
+		Field[string]("name"),
+		Field[string]("password").WriteOnly(),
+		ObjectField("login", credentials),
+		ArrayOfObjectField("keys", credentials),
+	)
+	res := schema.Validate(`{"name":"a","password":"p","login":{"user":"u","secret":"s"},"keys":[{"user":"k","secret":"s"}]}`)
+	require.NoError(t, res.Error())
+	vo := res.MustGet()
+
+	data, err := json.Marshal(schema.Output(vo))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"a","login":{"user":"u"},"keys":[{"user":"k"}]}`, string(data))
+
+	// the validated object keeps every field
+	require.Equal(t, "p", vo.MstString("password"))
+	require.Equal(t, "s", vo.MstString("login.secret"))
+}
+
+func TestSchema_JSONSchema_access(t *testing.T) {
+	schema := WithFields(
+		Field[int64]("id").ReadOnly(),
+		Field[string]("password").WriteOnly(),
+	)
+	data, err := schema.JSONSchema()
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(data, &doc))
+	props := doc["properties"].(map[string]any)
+	require.Equal(t, true, props["id"].(map[string]any)["readOnly"])
+	require.Equal(t, true, props["password"].(map[string]any)["writeOnly"])
+	require.Equal(t, []any{"password"}, doc["required"])
+}
@@ -0,0 +1,333 @@
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"mime/multipart"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"unicode"
+
+	"github.com/kcmvp/xql/internal"
+	"github.com/samber/mo"
+)
+
+// Value returns the value stored under name in vo, with the dotted lookup
+// of the ValueObject getters. It is used by the code emitted by
+// GenerateAccessors and panics when the value is not a T.
+func Value[T any](vo ValueObject, name string) mo.Option[T] {
+	if vo == nil {
+		return mo.None[T]()
+	}
+	return internal.Get[T](asData(vo), name)
+}
+
+// GenerateAccessors emits the Go source of package pkg declaring a typed
+// struct per schema, named by its key in schemas, with a
+// <Name>FromValueObject function filling it from a ValueObject the schema
+// validated, so call sites get compile-time checked access instead of
+// string keys:
+//
+//	src, err := view.GenerateAccessors("api", map[string]*view.Schema{"CreateUserInput": createUser})
+//	// type CreateUserInput struct { Email string `json:"email"`; Age *int `json:"age,omitempty"`; ... }
+//	// func CreateUserInputFromValueObject(vo view.ValueObject) CreateUserInput
+//
+// Optional and Nullable fields become pointers, arrays slices, and embedded
+// objects nested structs named after their parent, e.g. CreateUserInputAddress.
+// Header fields are included; file fields map to *multipart.FileHeader. The
+// result is gofmt-ed; write it to a file from a go:generate program.
+func GenerateAccessors(pkg string, schemas map[string]*Schema) ([]byte, error) {
+	g := &accessorGen{imports: map[string]bool{"github.com/kcmvp/xql/view": true}, named: map[*Schema]string{}}
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		if !isExportedIdent(name) {
+			return nil, fmt.Errorf("view: accessor name '%s' is not an exported Go identifier", name)
+		}
+		if schemas[name] == nil {
+			return nil, fmt.Errorf("view: schema for accessor '%s' is nil", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if other, ok := g.named[schemas[name]]; ok {
+			return nil, fmt.Errorf("view: schema registered as both '%s' and '%s'", other, name)
+		}
+		g.named[schemas[name]] = name
+	}
+	for _, name := range names {
+		if err := g.add(name, schemas[name]); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	if err := accessorTemplate.Execute(&buf, map[string]any{
+		"Package": pkg,
+		"Imports": sortedKeys(g.imports),
+		"Types":   g.types,
+	}); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("view: format generated accessors: %w", err)
+	}
+	return src, nil
+}
+
+// accessorType is a generated struct.
+type accessorType struct {
+	Name   string
+	Fields []accessorField
+}
+
+// accessorField is a field of a generated struct.
+type accessorField struct {
+	GoName string
+	Type   string
+	Key    string
+	Tag    string
+	// Pointer stores the address of the value; Nested names the accessor
+	// type of an embedded object; Slice marks an array of such objects.
+	Pointer bool
+	Nested  string
+	Slice   bool
+	// Lookup is the type argument of view.Value.
+	Lookup string
+}
+
+type accessorGen struct {
+	imports map[string]bool
+	named   map[*Schema]string
+	done    map[string]bool
+	types   []accessorType
+}
+
+// add declares the accessor type name for s and the types of its embedded
+// objects.
+func (g *accessorGen) add(name string, s *Schema) error {
+	if g.done == nil {
+		g.done = map[string]bool{}
+	}
+	if g.done[name] {
+		return nil
+	}
+	g.done[name] = true
+	// reserve the slot so a type precedes the types of its embedded objects
+	idx := len(g.types)
+	g.types = append(g.types, accessorType{})
+	at := accessorType{Name: name}
+	goNames := map[string]string{}
+	for _, field := range s.fields {
+		goName := exportedName(field.Name())
+		if other, ok := goNames[goName]; ok {
+			return fmt.Errorf("view: fields '%s' and '%s' of '%s' map to the same Go name %s", other, field.Name(), name, goName)
+		}
+		goNames[goName] = field.Name()
+		af := accessorField{
+			GoName:  goName,
+			Key:     field.UniqueName(),
+			Pointer: !field.Required() || isNullable(field),
+		}
+		af.Tag = fmt.Sprintf("`json:\"%s%s\"`", field.Name(), map[bool]string{true: ",omitempty"}[af.Pointer])
+		if nested, ok := field.embeddedObject().Get(); ok {
+			af.Nested = g.nestedName(name, goName, nested)
+			af.Slice = field.IsArray()
+			af.Pointer = af.Pointer && !af.Slice
+			af.Lookup = lookupType(af.Slice)
+			af.Type = map[bool]string{true: "[]", false: ""}[af.Slice] + map[bool]string{true: "*", false: ""}[af.Pointer] + af.Nested
+			at.Fields = append(at.Fields, af)
+			if err := g.add(af.Nested, nested); err != nil {
+				return err
+			}
+			continue
+		}
+		t := storedType(field)
+		if t == nil {
+			return fmt.Errorf("view: field '%s' of '%s' has no Go type", field.Name(), name)
+		}
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+			af.Pointer = false
+			af.Tag = fmt.Sprintf("`json:\"%s%s\"`", field.Name(), map[bool]string{true: ",omitempty"}[!field.Required()])
+		}
+		typeName, err := g.typeName(t)
+		if err != nil {
+			return fmt.Errorf("view: field '%s' of '%s': %w", field.Name(), name, err)
+		}
+		af.Lookup = typeName
+		af.Type = map[bool]string{true: "*", false: ""}[af.Pointer] + typeName
+		at.Fields = append(at.Fields, af)
+	}
+	g.types[idx] = at
+	return nil
+}
+
+// nestedName returns the accessor type of an embedded schema, the
+// registered name when there is one.
+func (g *accessorGen) nestedName(parent, goName string, nested *Schema) string {
+	if name, ok := g.named[nested]; ok {
+		return name
+	}
+	name := parent + goName
+	g.named[nested] = name
+	return name
+}
+
+func lookupType(slice bool) string {
+	if slice {
+		return "[]view.ValueObject"
+	}
+	return "view.ValueObject"
+}
+
+// typeName returns the Go source of t, recording the imports it needs.
+func (g *accessorGen) typeName(t reflect.Type) (string, error) {
+	switch t.Kind() {
+	case reflect.Slice:
+		elem, err := g.typeName(t.Elem())
+		return "[]" + elem, err
+	case reflect.Map:
+		key, err := g.typeName(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := g.typeName(t.Elem())
+		return "map[" + key + "]" + elem, err
+	case reflect.Pointer:
+		elem, err := g.typeName(t.Elem())
+		return "*" + elem, err
+	}
+	if t.PkgPath() == "" {
+		return t.String(), nil
+	}
+	if t.PkgPath() == "main" || strings.Contains(t.PkgPath(), "/internal") || t.Name() == "" {
+		return "", fmt.Errorf("type %s cannot be referenced from another package", t)
+	}
+	g.imports[t.PkgPath()] = true
+	return path.Base(t.PkgPath()) + "." + t.Name(), nil
+}
+
+// storedType returns the type of the value a field stores in a ValueObject.
+func storedType(field ViewField) reflect.Type {
+	if st, ok := field.(interface{ storedType() reflect.Type }); ok {
+		return st.storedType()
+	}
+	return nil
+}
+
+func (f *JSONField[T]) storedType() reflect.Type {
+	return sliceIf(reflect.TypeFor[T](), f.array)
+}
+
+func (f *TypedField[T]) storedType() reflect.Type {
+	return sliceIf(reflect.TypeFor[T](), f.array)
+}
+
+func (f *DictField[V]) storedType() reflect.Type {
+	return reflect.TypeFor[map[string]V]()
+}
+
+func (f *GridField[T]) storedType() reflect.Type {
+	return reflect.TypeFor[[][]T]()
+}
+
+func (f *FormFileField) storedType() reflect.Type {
+	return sliceIf(reflect.TypeFor[*multipart.FileHeader](), f.array)
+}
+
+// sliceIf returns the slice type of t when array is set, t otherwise.
+func sliceIf(t reflect.Type, array bool) reflect.Type {
+	if array {
+		return reflect.SliceOf(t)
+	}
+	return t
+}
+
+// isNullable reports whether a field accepts an explicit null.
+func isNullable(field ViewField) bool {
+	f, ok := field.(interface{ isNullable() bool })
+	return ok && f.isNullable()
+}
+
+func (f *JSONField[T]) isNullable() bool {
+	return f.nullable
+}
+
+// exportedName converts a field name such as "first_name" or "userId" to
+// an exported Go identifier: FirstName, UserId.
+func exportedName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if out == "" || !unicode.IsLetter([]rune(out)[0]) {
+		out = "F" + out
+	}
+	return out
+}
+
+func isExportedIdent(name string) bool {
+	return name != "" && exportedName(name) == name && unicode.IsUpper([]rune(name)[0])
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var accessorTemplate = texttemplate.Must(texttemplate.New("accessors").Parse(`// Code generated by view.GenerateAccessors. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .Imports }}
+	"{{ . }}"
+{{- end }}
+)
+{{ range .Types }}
+// {{ .Name }} is the typed form of a ValueObject validated by its schema.
+type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ .GoName }} {{ .Type }} {{ .Tag }}
+{{- end }}
+}
+
+// {{ .Name }}FromValueObject copies the fields of vo into a {{ .Name }}.
+func {{ .Name }}FromValueObject(vo view.ValueObject) {{ .Name }} {
+	var out {{ .Name }}
+{{- range .Fields }}
+	if v, ok := view.Value[{{ .Lookup }}](vo, "{{ .Key }}").Get(); ok {
+{{- if and .Nested .Slice }}
+		out.{{ .GoName }} = make([]{{ .Nested }}, len(v))
+		for i, e := range v {
+			out.{{ .GoName }}[i] = {{ .Nested }}FromValueObject(e)
+		}
+{{- else if .Nested }}
+		nested := {{ .Nested }}FromValueObject(v)
+		out.{{ .GoName }} = {{ if .Pointer }}&{{ end }}nested
+{{- else }}
+		out.{{ .GoName }} = {{ if .Pointer }}&{{ end }}v
+{{- end }}
+	}
+{{- end }}
+	return out
+}
+{{ end }}`))
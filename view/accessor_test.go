@@ -0,0 +1,98 @@
+package view
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAccessors(t *testing.T) {
+	address := WithFields(
+		Field[string]("street"),
+		Field[string]("zip").Optional(),
+	)
+	signup := WithFields(
+		Field[string]("email"),
+		Field[int]("age").Optional(),
+		Field[string]("nick_name").Nullable(),
+		Field[time.Time]("born_at"),
+		ArrayField[string]("tags").Optional(),
+		MapField[int]("scores").Optional(),
+		ObjectField("home", address),
+		ObjectField("work", address).Optional(),
+		ArrayOfObjectField("addresses", address),
+	)
+	src, err := GenerateAccessors("api", map[string]*Schema{"Signup": signup})
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "accessors.go", src, parser.AllErrors)
+	require.NoError(t, err)
+	code := strings.Join(strings.Fields(string(src)), " ")
+	for _, want := range []string{
+		"// Code generated by view.GenerateAccessors. DO NOT EDIT.",
+		"package api",
+		`"github.com/kcmvp/xql/view"`,
+		`"time"`,
+		"type Signup struct {",
+		"Email string `json:\"email\"`",
+		"Age *int `json:\"age,omitempty\"`",
+		"NickName *string `json:\"nick_name,omitempty\"`",
+		"BornAt time.Time `json:\"born_at\"`",
+		"Tags []string `json:\"tags,omitempty\"`",
+		"Scores map[string]int `json:\"scores,omitempty\"`",
+		"Home SignupHome `json:\"home\"`",
+		"Work *SignupHome `json:\"work,omitempty\"`",
+		"Addresses []SignupHome `json:\"addresses\"`",
+		"type SignupHome struct {",
+		"func SignupFromValueObject(vo view.ValueObject) Signup {",
+		`view.Value[view.ValueObject](vo, "home")`,
+		`view.Value[[]view.ValueObject](vo, "addresses")`,
+		"out.Addresses[i] = SignupHomeFromValueObject(e)",
+	} {
+		require.Contains(t, code, want)
+	}
+
+	t.Run("registered nested schema", func(t *testing.T) {
+		src, err := GenerateAccessors("api", map[string]*Schema{"Signup": signup, "Address": address})
+		require.NoError(t, err)
+		require.Contains(t, strings.Join(strings.Fields(string(src)), " "), "Home Address `json:\"home\"`")
+		require.NotContains(t, string(src), "SignupHome")
+	})
+
+	t.Run("self reference", func(t *testing.T) {
+		node := WithFields(Field[string]("name"), ArrayOfObjectField("children", SelfRef()))
+		src, err := GenerateAccessors("api", map[string]*Schema{"Node": node})
+		require.NoError(t, err)
+		require.Contains(t, strings.Join(strings.Fields(string(src)), " "), "Children []Node `json:\"children\"`")
+	})
+
+	for name, schemas := range map[string]map[string]*Schema{
+		"unexported name": {"signup": signup},
+		"nil schema":      {"Signup": nil},
+		"duplicate":       {"A": address, "B": address},
+		"go name clash":   {"A": WithFields(Field[string]("first_name"), Field[string]("firstName"))},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := GenerateAccessors("api", schemas)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	schema := WithFields(
+		Field[string]("name"),
+		Field[int]("age").Optional(),
+		ObjectField("home", WithFields(Field[string]("street"))),
+	)
+	vo := schema.Validate(`{"name":"a","home":{"street":"s"}}`).MustGet()
+	require.Equal(t, "a", Value[string](vo, "name").MustGet())
+	require.True(t, Value[int](vo, "age").IsAbsent())
+	home := Value[ValueObject](vo, "home").MustGet()
+	require.Equal(t, "s", Value[string](home, "street").MustGet())
+	require.True(t, Value[string](nil, "name").IsAbsent())
+	require.Panics(t, func() { Value[int](vo, "name") })
+}
@@ -0,0 +1,63 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/tidwall/gjson"
+)
+
+// Alias accepts the field under other JSON keys, URL parameter or form
+// names too, typically the old spelling during a migration:
+//
+//	Field[string]("user_name").Alias("userName", "username")
+//
+// The value is stored under the field's UniqueName whatever the key it
+// came from, and errors are reported under the field name. Giving the
+// field under more than one of its names is an error.
+func (f *JSONField[T]) Alias(names ...string) *JSONField[T] {
+	for _, name := range names {
+		lo.Assertf(name != "" && !strings.ContainsAny(name, ".#*?"), "xql: invalid alias '%s' for field '%s'", name, f.Name())
+		lo.Assertf(name != f.Name(), "xql: alias '%s' is the name of field '%s'", name, f.Name())
+	}
+	f.aliases = append(f.aliases, names...)
+	return f
+}
+
+// aliasesOf returns the aliases of field, see Alias.
+func aliasesOf(field ViewField) []string {
+	if af, ok := field.(interface{ aliasNames() []string }); ok {
+		return af.aliasNames()
+	}
+	return nil
+}
+
+func (f *JSONField[T]) aliasNames() []string {
+	return f.aliases
+}
+
+// inputNames returns the names field is read from: its name, then its aliases.
+func inputNames(field ViewField) []string {
+	return append([]string{field.Name()}, aliasesOf(field)...)
+}
+
+// inputName returns the name field is given under, its own name when it is
+// not given at all. present reports whether a name is given.
+func inputName(field ViewField, present func(name string) bool) (string, error) {
+	given := lo.Filter(inputNames(field), func(name string, _ int) bool { return present(name) })
+	if len(given) > 1 {
+		return "", fmt.Errorf("field '%s' is given under several names: %s", field.Name(), strings.Join(given, ", "))
+	}
+	return lo.FirstOr(given, field.Name()), nil
+}
+
+// payloadName returns the name field is given under in a JSON payload or
+// URL parameters, see inputName. nodes holds the top-level members of the
+// JSON payload by key.
+func payloadName(field ViewField, nodes map[string]gjson.Result, urlPair map[string][]string) (string, error) {
+	return inputName(field, func(name string) bool {
+		_, inURL := urlPair[name]
+		return inURL || nodes[name].Exists()
+	})
+}
@@ -0,0 +1,69 @@
+package view
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONField_Alias(t *testing.T) {
+	schema := WithFields(
+		Field[string]("user_name").Alias("userName", "username"),
+		Field[int]("age").Optional(),
+	)
+	tests := []struct {
+		name    string
+		json    string
+		params  map[string]string
+		want    string
+		wantErr string
+	}{
+		{name: "canonical", json: `{"user_name":"a"}`, want: "a"},
+		{name: "alias", json: `{"userName":"b"}`, want: "b"},
+		{name: "second alias", json: `{"username":"c","age":1}`, want: "c"},
+		{name: "url parameter alias", params: map[string]string{"userName": "d"}, want: "d"},
+		{name: "several names", json: `{"user_name":"a","userName":"b"}`, wantErr: "field 'user_name' is given under several names: user_name, userName"},
+		{name: "several names across sources", json: `{"username":"a"}`, params: map[string]string{"userName": "b"}, wantErr: "field 'user_name' is given under several names: userName, username"},
+		{name: "missing", json: `{"age":1}`, wantErr: "user_name is required but not found"},
+	}
+	for _, tt := range tests {
+		for _, compiled := range []bool{false, true} {
+			t.Run(tt.name, func(t *testing.T) {
+				s := schema
+				if compiled {
+					s = WithFields(schema.fields...).Compile()
+				}
+				res := s.Validate(tt.json, tt.params)
+				if tt.wantErr != "" {
+					require.Error(t, res.Error())
+					require.Equal(t, tt.wantErr, res.Error().(*validationError).Errors()["user_name"])
+					return
+				}
+				require.NoError(t, res.Error())
+				vo := res.MustGet()
+				require.Equal(t, tt.want, vo.String("user_name").MustGet())
+				require.Contains(t, vo.Fields(), "user_name")
+				require.NotContains(t, vo.Fields(), "userName")
+			})
+		}
+	}
+
+	t.Run("form", func(t *testing.T) {
+		res := schema.ValidateForm(url.Values{"userName": {"e"}})
+		require.NoError(t, res.Error())
+		require.Equal(t, "e", res.MustGet().String("user_name").MustGet())
+		res = schema.ValidateForm(url.Values{"userName": {"e"}, "user_name": {"f"}})
+		require.Error(t, res.Error())
+	})
+
+	t.Run("captured unknown fields exclude aliases", func(t *testing.T) {
+		s := WithFields(Field[string]("user_name").Alias("userName")).CaptureUnknown()
+		vo := s.Validate(`{"userName":"a","other":1}`).MustGet()
+		require.Equal(t, map[string]any{"other": float64(1)}, vo.Unknown())
+	})
+
+	require.Panics(t, func() { WithFields(Field[string]("a"), Field[string]("b").Alias("a")) })
+	require.Panics(t, func() { Field[string]("a").Alias("a") })
+	require.Panics(t, func() { Field[string]("a").Alias("x.y") })
+}
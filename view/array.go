@@ -0,0 +1,74 @@
+package view
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+)
+
+// ValidateArray validates a JSON payload whose root is an array, each
+// element being an object validated by the schema, as bulk endpoints
+// receive them:
+//
+//	res := item.ValidateArray(body, 1, 100)
+//	// errors: {"[2].email": "..."}
+//
+// The array must hold between minItems and maxItems elements, a zero
+// maxItems meaning no limit; the limit is checked before any element is
+// validated. Element errors are reported under their index; every element
+// is validated unless the schema sets MaxErrors.
+func (s *Schema) ValidateArray(json string, minItems, maxItems int) mo.Result[[]ValueObject] {
+	lo.Assertf(minItems >= 0 && maxItems >= 0, "xql: ValidateArray bounds must not be negative")
+	if !gjson.Valid(json) {
+		return mo.Err[[]ValueObject](fmt.Errorf("invalid json %s", json))
+	}
+	root := gjson.Parse(json)
+	if !root.IsArray() {
+		return mo.Err[[]ValueObject](fmt.Errorf("%w: expected a JSON array but got raw type %s", validator.ErrTypeMismatch, root.Type))
+	}
+	count := 0
+	root.ForEach(func(_, _ gjson.Result) bool {
+		count++
+		return maxItems == 0 || count <= maxItems
+	})
+	switch {
+	case count < minItems:
+		return mo.Err[[]ValueObject](&ruleError{
+			rule: validator.Rule{Name: "min_items", Params: map[string]any{"min": minItems}},
+			err:  fmt.Errorf("must have at least %d items", minItems),
+		})
+	case maxItems > 0 && count > maxItems:
+		return mo.Err[[]ValueObject](&ruleError{
+			rule: validator.Rule{Name: "max_items", Params: map[string]any{"max": maxItems}},
+			err:  fmt.Errorf("must have at most %d items", maxItems),
+		})
+	}
+	errs := &validationError{style: s.pathStyle}
+	st := walkState{maxErrors: s.maxErrors}
+	values := make([]ValueObject, 0, count)
+	root.ForEach(func(index, element gjson.Result) bool {
+		key := fmt.Sprintf("[%d]", index.Int())
+		if !element.IsObject() {
+			errs.add(key, fmt.Errorf("%w: expected a JSON object but got raw type %s", validator.ErrTypeMismatch, element.Type))
+			return true
+		}
+		if st.full(errs) {
+			return false
+		}
+		res := s.checkCtx(context.Background(), s.validateAt(element.Raw, nil, nil, walkState{}.nest()))
+		if res.IsError() {
+			errs.add(key, res.Error())
+			return true
+		}
+		values = append(values, s.styledWarnings(res).MustGet())
+		return true
+	})
+	if errs.err() != nil && s.maxErrors > 0 {
+		return mo.Err[[]ValueObject](errs.truncate(s.maxErrors))
+	}
+	return lo.Ternary(errs.err() != nil, mo.Err[[]ValueObject](errs.err()), mo.Ok(values))
+}
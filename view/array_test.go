@@ -0,0 +1,68 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_ValidateArray(t *testing.T) {
+	item := WithFields(
+		Field[string]("email", validator.Email()),
+		Field[int]("age").Optional(),
+	)
+	tests := []struct {
+		name     string
+		json     string
+		min, max int
+		want     int
+		errors   map[string]string
+		wantErr  string
+	}{
+		{name: "valid", json: `[{"email":"a@b.c"},{"email":"d@e.f","age":3}]`, max: 10, want: 2},
+		{name: "empty", json: `[]`, want: 0},
+		{
+			name: "element errors",
+			json: `[{"email":"a@b.c"},{"email":"x"},1,{"age":2}]`,
+			errors: map[string]string{
+				"[1].email": "not valid email address:x",
+				"[2]":       "type mismatch: expected a JSON object but got raw type Number",
+				"[3].email": "email is required but not found",
+			},
+		},
+		{name: "too few", json: `[]`, min: 1, wantErr: "must have at least 1 items"},
+		{name: "too many", json: `[{},{},{}]`, max: 2, wantErr: "must have at most 2 items"},
+		{name: "not an array", json: `{"email":"a@b.c"}`, wantErr: "type mismatch: expected a JSON array but got raw type JSON"},
+		{name: "invalid json", json: `[{]`, wantErr: "invalid json [{]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := item.ValidateArray(tt.json, tt.min, tt.max)
+			switch {
+			case tt.errors != nil:
+				var verr *validationError
+				require.ErrorAs(t, res.Error(), &verr)
+				require.Equal(t, tt.errors, verr.Errors())
+			case tt.wantErr != "":
+				require.EqualError(t, res.Error(), tt.wantErr)
+			default:
+				require.NoError(t, res.Error())
+				require.Len(t, res.MustGet(), tt.want)
+			}
+		})
+	}
+
+	t.Run("values", func(t *testing.T) {
+		values := item.ValidateArray(`[{"email":"a@b.c","age":1}]`, 0, 0).MustGet()
+		require.Equal(t, "a@b.c", values[0].String("email").MustGet())
+		require.Equal(t, 1, values[0].Int("age").MustGet())
+	})
+
+	t.Run("path style", func(t *testing.T) {
+		s := WithFields(Field[string]("email")).ErrorPaths(PathPointer)
+		var verr *validationError
+		require.ErrorAs(t, s.ValidateArray(`[{"email":"a"},{}]`, 0, 0).Error(), &verr)
+		require.Equal(t, map[string]string{"/1/email": "email is required but not found"}, verr.Errors())
+	})
+}
@@ -0,0 +1,192 @@
+package view
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/kcmvp/xql/internal"
+	"github.com/samber/lo"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Bind populates a new T from vo. See BindInto for the mapping rules.
+//
+//	type Signup struct {
+//		Email   string `json:"email"`
+//		Address struct {
+//			City string `json:"city"`
+//		} `json:"address"`
+//		Tags []string `json:"tags"`
+//	}
+//	res := schema.Validate(body)
+//	signup, err := view.Bind[Signup](res.MustGet())
+func Bind[T any](vo ValueObject) (T, error) {
+	var t T
+	err := BindInto(vo, &t)
+	return t, err
+}
+
+// BindInto populates the struct pointed to by dst from vo.
+//
+// Each exported struct field is matched against the top-level keys of vo by
+// its json tag name, falling back to the Go field name; matching is
+// case-insensitive when there is no exact match. Fields tagged `json:"-"`
+// are skipped and anonymous embedded structs are flattened, as with
+// encoding/json. Keys missing from vo leave the destination field untouched,
+// while fields explicitly set to null (see JSONField.Nullable) are zeroed.
+//
+// Nested objects bind into structs (or pointers to structs), arrays bind into
+// slices element by element, and numeric values convert between Go numeric
+// types as long as the value fits. Any other type mismatch is reported with
+// the dotted path of the offending field.
+func BindInto(vo ValueObject, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("view: bind destination must be a non-nil pointer to struct, got %T", dst)
+	}
+	if vo == nil {
+		return nil
+	}
+	return bindStruct(vo, rv.Elem(), "")
+}
+
+// bindStruct copies the values of obj into the fields of the struct dst.
+func bindStruct(obj internal.ValueObject, dst reflect.Value, path string) error {
+	keys := obj.Fields()
+	lookup := func(name string) (string, bool) {
+		for _, k := range keys {
+			if k == name {
+				return k, true
+			}
+		}
+		for _, k := range keys {
+			if strings.EqualFold(k, name) {
+				return k, true
+			}
+		}
+		return "", false
+	}
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		if sf.Anonymous && tag == "" && sf.Type.Kind() == reflect.Struct {
+			if err := bindStruct(obj, dst.Field(i), path); err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name := lo.Ternary(tag != "", tag, sf.Name)
+		key, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		src := obj.Get(key).OrElse(internal.Null)
+		if err := bindValue(src, dst.Field(i), joinPath(path, key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindValue assigns src to dst, converting nested objects, arrays and
+// numbers as needed.
+func bindValue(src any, dst reflect.Value, path string) error {
+	if src == nil {
+		return nil
+	}
+	if src == internal.Null {
+		// an explicit null clears the destination
+		dst.SetZero()
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return bindValue(src, dst.Elem(), path)
+	case reflect.Interface:
+		sv := reflect.ValueOf(src)
+		if !sv.Type().AssignableTo(dst.Type()) {
+			return bindError(src, dst, path)
+		}
+		dst.Set(sv)
+		return nil
+	case reflect.Struct:
+		if dst.Type() == timeType {
+			break
+		}
+		obj, ok := src.(internal.ValueObject)
+		if !ok {
+			return bindError(src, dst, path)
+		}
+		return bindStruct(obj, dst, path)
+	case reflect.Slice:
+		sv := reflect.ValueOf(src)
+		if sv.Kind() != reflect.Slice {
+			return bindError(src, dst, path)
+		}
+		out := reflect.MakeSlice(dst.Type(), sv.Len(), sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			if err := bindValue(sv.Index(i).Interface(), out.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(out)
+		return nil
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+	if !sameFamily(sv.Kind(), dst.Kind()) || !sv.CanConvert(dst.Type()) {
+		return bindError(src, dst, path)
+	}
+	converted := sv.Convert(dst.Type())
+	if !converted.Convert(sv.Type()).Equal(sv) {
+		return fmt.Errorf("view: value %v of field '%s' does not fit in %s", src, path, dst.Type())
+	}
+	dst.Set(converted)
+	return nil
+}
+
+// sameFamily reports whether a value of kind src may be converted to kind
+// dst without changing its meaning (number to number, string to string,
+// bool to bool).
+func sameFamily(src, dst reflect.Kind) bool {
+	family := func(k reflect.Kind) int {
+		switch {
+		case k >= reflect.Int && k <= reflect.Float64:
+			return 1
+		case k == reflect.String:
+			return 2
+		case k == reflect.Bool:
+			return 3
+		default:
+			return 0
+		}
+	}
+	return family(src) != 0 && family(src) == family(dst)
+}
+
+func bindError(src any, dst reflect.Value, path string) error {
+	return fmt.Errorf("view: cannot bind %T to %s for field '%s'", src, dst.Type(), path)
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,111 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type bindAddress struct {
+	City string `json:"city"`
+	Zip  int32  `json:"zip"`
+}
+
+type bindAudit struct {
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type bindUser struct {
+	bindAudit
+	Name     string        `json:"name"`
+	Age      uint8         `json:"age"`
+	Score    float32       `json:"score"`
+	Active   bool          // matched case-insensitively by field name
+	Tags     []string      `json:"tags"`
+	Address  *bindAddress  `json:"address"`
+	Previous []bindAddress `json:"previous"`
+	Ignored  string        `json:"-"`
+	Missing  string        `json:"missing"`
+}
+
+func bindSchema() *Schema {
+	address := WithFields(Field[string]("city"), Field[int]("zip"))
+	return WithFields(
+		Field[string]("name"),
+		Field[int]("age"),
+		Field[float64]("score"),
+		Field[bool]("active"),
+		Field[time.Time]("createdAt"),
+		ArrayField[string]("tags"),
+		ObjectField("address", address),
+		ArrayOfObjectField("previous", address),
+		Field[string]("Ignored").Optional(),
+	)
+}
+
+func TestBind(t *testing.T) {
+	res := bindSchema().Validate(`{
+		"name": "jack",
+		"age": 30,
+		"score": 4.5,
+		"active": true,
+		"createdAt": "2024-01-02T03:04:05Z",
+		"tags": ["a", "b"],
+		"address": {"city": "Paris", "zip": 75001},
+		"previous": [{"city": "Lyon", "zip": 69001}, {"city": "Nice", "zip": 6000}],
+		"Ignored": "x"
+	}`)
+	require.NoError(t, res.Error())
+
+	user, err := Bind[bindUser](res.MustGet())
+	require.NoError(t, err)
+	require.Equal(t, "jack", user.Name)
+	require.Equal(t, uint8(30), user.Age)
+	require.Equal(t, float32(4.5), user.Score)
+	require.True(t, user.Active)
+	require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), user.CreatedAt.UTC())
+	require.Equal(t, []string{"a", "b"}, user.Tags)
+	require.Equal(t, &bindAddress{City: "Paris", Zip: 75001}, user.Address)
+	require.Equal(t, []bindAddress{{"Lyon", 69001}, {"Nice", 6000}}, user.Previous)
+	require.Empty(t, user.Ignored)
+	require.Empty(t, user.Missing)
+}
+
+func TestBindInto(t *testing.T) {
+	t.Run("keeps fields missing from the value object", func(t *testing.T) {
+		res := WithFields(Field[string]("name")).Validate(`{"name":"rose"}`)
+		require.NoError(t, res.Error())
+		user := bindUser{Missing: "kept"}
+		require.NoError(t, BindInto(res.MustGet(), &user))
+		require.Equal(t, "rose", user.Name)
+		require.Equal(t, "kept", user.Missing)
+	})
+
+	t.Run("invalid destination", func(t *testing.T) {
+		var user bindUser
+		require.ErrorContains(t, BindInto(nil, user), "non-nil pointer to struct")
+		require.ErrorContains(t, BindInto(nil, (*bindUser)(nil)), "non-nil pointer to struct")
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		res := WithFields(Field[int]("age")).Validate(`{"age":300}`)
+		require.NoError(t, res.Error())
+		var user bindUser
+		require.ErrorContains(t, BindInto(res.MustGet(), &user), "value 300 of field 'age' does not fit in uint8")
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		res := WithFields(Field[int]("name")).Validate(`{"name":1}`)
+		require.NoError(t, res.Error())
+		var user bindUser
+		require.ErrorContains(t, BindInto(res.MustGet(), &user), "cannot bind int to string for field 'name'")
+	})
+
+	t.Run("nested path in error", func(t *testing.T) {
+		res := WithFields(ArrayOfObjectField("previous", WithFields(Field[string]("zip")))).Validate(`{"previous":[{"zip":"x"}]}`)
+		require.NoError(t, res.Error())
+		var user bindUser
+		require.ErrorContains(t, BindInto(res.MustGet(), &user), "field 'previous[0].zip'")
+	})
+}
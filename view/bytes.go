@@ -0,0 +1,127 @@
+package view
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kcmvp/xql"
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+)
+
+// ErrInvalidBase64 is returned for a BytesField value that is not base64.
+var ErrInvalidBase64 = errors.New("value must be base64 encoded")
+
+// base64Encodings are tried in order to decode a BytesField value.
+var base64Encodings = []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding}
+
+// BlobField is the ViewField of binary data, see BytesField.
+type BlobField struct {
+	qualifiedName string
+	scope         string
+	required      bool
+	maxBytes      int
+}
+
+var _ ViewField = (*BlobField)(nil)
+
+// BytesField creates a required field decoding a base64 JSON string, in
+// the standard or URL-safe alphabet with or without padding, into a []byte
+// of at most maxBytes bytes; a non-positive maxBytes disables the limit.
+// The []byte is stored in the ValueObject and marshals back to standard
+// base64.
+func BytesField(name string, maxBytes int) *BlobField {
+	if strings.ContainsAny(name, ".#") {
+		panic(fmt.Sprintf("xql: field name '%s' cannot contain '.' or '#'", name))
+	}
+	return &BlobField{qualifiedName: name, required: true, maxBytes: maxBytes}
+}
+
+// PersistentBytesField is BytesField for the BYTEA or BLOB column of a
+// persistent field: the value is stored under the field's qualified name,
+// so ToInsert and sqlx.Update write it to the column.
+func PersistentBytesField(f xql.Field, maxBytes int) *BlobField {
+	lo.Assertf(f != nil, "view: PersistentBytesField requires a non-nil xql.Field")
+	return &BlobField{qualifiedName: f.QualifiedName(), scope: f.Scope(), required: true, maxBytes: maxBytes}
+}
+
+// Optional marks the field as optional.
+func (f *BlobField) Optional() *BlobField {
+	f.required = false
+	return f
+}
+
+func (f *BlobField) Scope() string         { return f.scope }
+func (f *BlobField) QualifiedName() string { return f.qualifiedName }
+func (f *BlobField) UniqueName() string    { return f.qualifiedName }
+func (f *BlobField) IsArray() bool         { return false }
+func (f *BlobField) IsObject() bool        { return false }
+func (f *BlobField) Required() bool        { return f.required }
+
+func (f *BlobField) Name() string {
+	return f.qualifiedName[strings.LastIndex(f.qualifiedName, ".")+1:]
+}
+
+// decode decodes s and checks its length.
+func (f *BlobField) decode(s string) ([]byte, error) {
+	// reject oversized values before decoding them
+	if f.maxBytes > 0 && base64.RawStdEncoding.DecodedLen(len(strings.TrimRight(s, "="))) > f.maxBytes {
+		return nil, f.tooLarge()
+	}
+	for _, enc := range base64Encodings {
+		if data, err := enc.DecodeString(s); err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("field '%s': %w", f.Name(), ErrInvalidBase64)
+}
+
+func (f *BlobField) tooLarge() error {
+	return fmt.Errorf("field '%s': %w", f.Name(), &ruleError{
+		rule: validator.Rule{Name: "max_bytes", Params: map[string]any{"max": f.maxBytes}},
+		err:  fmt.Errorf("must be at most %d bytes", f.maxBytes),
+	})
+}
+
+func (f *BlobField) validate(node gjson.Result, _ walkState) mo.Result[any] {
+	if node.Type != gjson.String {
+		return mo.Err[any](fmt.Errorf("field '%s': %w: expected a base64 string but got raw type %s", f.Name(), validator.ErrTypeMismatch, node.Type))
+	}
+	return f.validateRaw(node.Str)
+}
+
+func (f *BlobField) validateRaw(s string) mo.Result[any] {
+	data, err := f.decode(s)
+	return lo.Ternary(err != nil, mo.Err[any](err), mo.Ok[any](data))
+}
+
+func (f *BlobField) validateRawArray([]string) mo.Result[any] {
+	return mo.Err[any](fmt.Errorf("field '%s' has multiple values", f.Name()))
+}
+
+func (f *BlobField) optional(map[*Schema]*Schema) ViewField {
+	cp := *f
+	cp.required = false
+	return &cp
+}
+
+func (f *BlobField) embeddedObject() mo.Option[*Schema] {
+	return mo.None[*Schema]()
+}
+
+func (f *BlobField) jsonSchema(map[*Schema]string) map[string]any {
+	node := map[string]any{"type": "string", "contentEncoding": "base64"}
+	if f.maxBytes > 0 {
+		node["maxLength"] = base64.StdEncoding.EncodedLen(f.maxBytes)
+	}
+	return node
+}
+
+func (f *BlobField) storedType() reflect.Type {
+	return reflect.TypeFor[[]byte]()
+}
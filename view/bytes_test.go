@@ -0,0 +1,98 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/kcmvp/xql"
+	. "github.com/kcmvp/xql/sample/entity"
+	acct "github.com/kcmvp/xql/sample/gen/field/account"
+	"github.com/kcmvp/xql/validator"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytesField(t *testing.T) {
+	schema := WithFields(
+		BytesField("avatar", 4),
+		BytesField("blob", 0).Optional(),
+	)
+	tests := []struct {
+		name    string
+		json    string
+		want    []byte
+		wantErr error
+	}{
+		{name: "padded", json: `{"avatar":"AQID"}`, want: []byte{1, 2, 3}},
+		{name: "standard", json: `{"avatar":"+/8="}`, want: []byte{0xfb, 0xff}},
+		{name: "unpadded", json: `{"avatar":"+/8"}`, want: []byte{0xfb, 0xff}},
+		{name: "url safe", json: `{"avatar":"-_8="}`, want: []byte{0xfb, 0xff}},
+		{name: "empty", json: `{"avatar":""}`, want: []byte{}},
+		{name: "too large", json: `{"avatar":"AQIDBAU="}`, wantErr: nil},
+		{name: "not base64", json: `{"avatar":"a b"}`, wantErr: ErrInvalidBase64},
+		{name: "not a string", json: `{"avatar":[1,2]}`, wantErr: validator.ErrTypeMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := schema.Validate(tt.json)
+			if tt.want == nil {
+				require.Error(t, res.Error())
+				if tt.wantErr != nil {
+					require.ErrorIs(t, firstError(t, res.Error()), tt.wantErr)
+				} else {
+					require.Equal(t, map[string]string{"avatar": "length.max_bytes"}, res.Error().(*validationError).Codes())
+				}
+				return
+			}
+			require.NoError(t, res.Error())
+			require.Equal(t, tt.want, Value[[]byte](res.MustGet(), "avatar").MustGet())
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		res := schema.ValidateForm(url.Values{"avatar": {"AQID"}})
+		require.NoError(t, res.Error())
+		data, err := json.Marshal(res.MustGet())
+		require.NoError(t, err)
+		require.JSONEq(t, `{"avatar":"AQID"}`, string(data))
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		require.Equal(t, map[string]any{"type": "string", "contentEncoding": "base64", "maxLength": 8}, BytesField("b", 4).jsonSchema(nil))
+		require.NotContains(t, BytesField("b", 0).jsonSchema(nil), "maxLength")
+	})
+}
+
+func TestPersistentBytesField(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec("CREATE TABLE accounts (id INTEGER PRIMARY KEY, email TEXT NOT NULL, nick_name BLOB)")
+	require.NoError(t, err)
+
+	schema := WithFields(PersistentField(acct.Email), PersistentBytesField(acct.Nickname, 16))
+	res := schema.Validate(`{"Email":"a@b.c","Nickname":"AAEC"}`)
+	require.NoError(t, res.Error())
+	exec, err := ToInsert[Account](res.MustGet(), acct.Email)
+	require.NoError(t, err)
+	_, err = exec.Execute(context.Background(), db)
+	require.NoError(t, err)
+	var blob []byte
+	require.NoError(t, db.QueryRow("SELECT nick_name FROM accounts").Scan(&blob))
+	require.Equal(t, []byte{0, 1, 2}, blob)
+
+	// generated []byte fields convert through WithXQLFields
+	avatar := xql.NewBlobField[Account]("nick_name", "Avatar")
+	res = WithXQLFields(acct.Email, avatar).Validate(`{"Email":"b@b.c","Avatar":"AwQ"}`)
+	require.NoError(t, res.Error())
+	exec, err = ToInsert[Account](res.MustGet(), acct.Email)
+	require.NoError(t, err)
+	_, err = exec.Execute(context.Background(), db)
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRow("SELECT nick_name FROM accounts WHERE email = 'b@b.c'").Scan(&blob))
+	require.Equal(t, []byte{3, 4}, blob)
+}
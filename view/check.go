@@ -0,0 +1,48 @@
+package view
+
+import (
+	"strings"
+
+	"github.com/kcmvp/xql/internal"
+	"github.com/samber/lo"
+)
+
+// schemaCheck is a whole-object rule registered with Schema.Check.
+type schemaCheck struct {
+	name string
+	fn   func(ValueObject) error
+}
+
+// Check registers a cross-field rule evaluated against the validated
+// object, such as matching passwords or an ordered date range:
+//
+//	schema.Check("confirm_password", func(vo view.ValueObject) error {
+//		if vo.MstString("password") != vo.MstString("confirm_password") {
+//			return errors.New("passwords do not match")
+//		}
+//		return nil
+//	})
+//
+// Checks run in registration order once every field validated successfully,
+// so fn may rely on the fields it reads being valid (optional fields may
+// still be absent). A non-nil error is reported under name in the
+// validation error. It returns the same Schema pointer for chaining.
+func (s *Schema) Check(name string, fn func(ValueObject) error) *Schema {
+	name = strings.TrimSpace(name)
+	lo.Assertf(name != "", "xql: check name is required")
+	lo.Assertf(fn != nil, "xql: nil check '%s'", name)
+	s.checks = append(s.checks, schemaCheck{name: name, fn: fn})
+	return s
+}
+
+// runChecks evaluates the registered checks against object when no field
+// failed validation.
+func (s *Schema) runChecks(object internal.Data, errs *validationError) {
+	if len(s.checks) == 0 || errs.err() != nil {
+		return
+	}
+	vo := valueObject{Data: object}
+	for _, check := range s.checks {
+		errs.add(check.name, check.fn(vo))
+	}
+}
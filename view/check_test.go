@@ -0,0 +1,80 @@
+package view
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Check(t *testing.T) {
+	calls := 0
+	schema := WithFields(
+		Field[string]("password"),
+		Field[string]("confirm_password"),
+		Field[time.Time]("start").Optional(),
+		Field[time.Time]("end").Optional(),
+	).Check("confirm_password", func(vo ValueObject) error {
+		calls++
+		if vo.MstString("password") != vo.MstString("confirm_password") {
+			return errors.New("passwords do not match")
+		}
+		return nil
+	}).Check("end", func(vo ValueObject) error {
+		start, end := vo.Time("start"), vo.Time("end")
+		if start.IsPresent() && end.IsPresent() && !start.MustGet().Before(end.MustGet()) {
+			return errors.New("end must be after start")
+		}
+		return nil
+	})
+
+	tests := []struct {
+		name   string
+		json   string
+		errKey []string
+	}{
+		{name: "valid", json: `{"password":"a","confirm_password":"a","start":"2024-01-01","end":"2024-02-01"}`},
+		{name: "mismatch", json: `{"password":"a","confirm_password":"b"}`, errKey: []string{"confirm_password"}},
+		{name: "both fail", json: `{"password":"a","confirm_password":"b","start":"2024-02-01","end":"2024-01-01"}`, errKey: []string{"confirm_password", "end"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := schema.Validate(tc.json)
+			if len(tc.errKey) == 0 {
+				require.NoError(t, res.Error())
+				return
+			}
+			var verr *validationError
+			require.ErrorAs(t, res.Error(), &verr)
+			require.Len(t, verr.errors, len(tc.errKey))
+			for _, k := range tc.errKey {
+				require.Contains(t, verr.errors, k)
+			}
+		})
+	}
+
+	t.Run("skipped when fields fail", func(t *testing.T) {
+		calls = 0
+		res := schema.Validate(`{"password":"a"}`)
+		require.ErrorContains(t, res.Error(), "confirm_password is required")
+		require.Zero(t, calls)
+	})
+
+	t.Run("form", func(t *testing.T) {
+		res := schema.ValidateForm(url.Values{"password": {"a"}, "confirm_password": {"b"}})
+		require.ErrorContains(t, res.Error(), "passwords do not match")
+	})
+
+	t.Run("extend keeps checks", func(t *testing.T) {
+		extended := schema.Extend(WithFields(Field[string]("name")))
+		res := extended.Validate(`{"password":"a","confirm_password":"b","name":"x"}`)
+		require.ErrorContains(t, res.Error(), "passwords do not match")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.Panics(t, func() { WithFields().Check(" ", func(ValueObject) error { return nil }) })
+		require.Panics(t, func() { WithFields().Check("a", nil) })
+	})
+}
@@ -0,0 +1,141 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Coerce(t *testing.T) {
+	address := WithFields(Field[int]("zip"))
+	newSchema := func() *Schema {
+		return WithFields(
+			Field[int]("age", validator.Gte(18)),
+			Field[bool]("active").Optional(),
+			Field[float64]("score").Optional(),
+			Field[time.Time]("since").Optional(),
+			ArrayField[int]("ids").Optional(),
+			ObjectField("address", address).Optional(),
+		)
+	}
+
+	t.Run("strict by default", func(t *testing.T) {
+		res := newSchema().Validate(`{"age":"20"}`)
+		require.Error(t, res.Error())
+	})
+
+	tests := []struct {
+		name string
+		json string
+		err  bool
+		want map[string]any
+	}{
+		{name: "numeric string", json: `{"age":"20"}`, want: map[string]any{"age": 20}},
+		{name: "native value", json: `{"age":20}`, want: map[string]any{"age": 20}},
+		{name: "bool and float", json: `{"age":"20","active":"true","score":"1.5"}`, want: map[string]any{"active": true, "score": 1.5}},
+		{name: "time", json: `{"age":20,"since":"2024-01-02"}`, want: map[string]any{"since": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}},
+		{name: "array elements", json: `{"age":20,"ids":["1",2]}`, want: map[string]any{"ids": []int{1, 2}}},
+		{name: "embedded object inherits", json: `{"age":20,"address":{"zip":"12345"}}`, want: map[string]any{"address.zip": 12345}},
+		{name: "unparsable string", json: `{"age":"twenty"}`, err: true},
+		{name: "validators still apply", json: `{"age":"17"}`, err: true},
+		{name: "non string mismatch", json: `{"age":true}`, err: true},
+	}
+	schema := newSchema().Coerce()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := schema.Validate(tc.json)
+			if tc.err {
+				require.Error(t, res.Error())
+				return
+			}
+			require.NoError(t, res.Error())
+			for k, v := range tc.want {
+				require.Equal(t, v, res.MustGet().Get(k).MustGet(), k)
+			}
+		})
+	}
+
+	t.Run("embedded schema is not modified", func(t *testing.T) {
+		require.False(t, address.coerce)
+		require.Error(t, address.Validate(`{"zip":"12345"}`).Error())
+	})
+
+	t.Run("extend keeps coercion", func(t *testing.T) {
+		extended := WithFields(Field[string]("name")).Extend(newSchema().Coerce())
+		require.NoError(t, extended.Validate(`{"name":"a","age":"20"}`).Error())
+	})
+}
+
+func TestJSONField_Lenient(t *testing.T) {
+	schema := WithFields(
+		Field[int]("page").Lenient(),
+		Field[int]("size"),
+	)
+	res := schema.Validate(`{"page":"2","size":10}`)
+	require.NoError(t, res.Error())
+	require.Equal(t, 2, res.MustGet().MstInt("page"))
+
+	res = schema.Validate(`{"page":2,"size":"10"}`)
+	require.Error(t, res.Error())
+}
+
+func TestSchema_StrictNumbers(t *testing.T) {
+	newSchema := func() *Schema {
+		return WithFields(
+			Field[float64]("price").Optional(),
+			Field[float32]("ratio").Optional(),
+			ArrayField[float64]("scores").Optional(),
+			ObjectField("dims", WithFields(Field[float64]("width"))).Optional(),
+			Field[float64]("weight").Lenient().Optional(),
+			Field[string]("code").Optional(),
+		)
+	}
+
+	t.Run("float strings accepted by default", func(t *testing.T) {
+		res := newSchema().Validate(`{"price":"1.5"}`)
+		require.NoError(t, res.Error())
+		require.Equal(t, 1.5, res.MustGet().MstFloat64("price"))
+	})
+
+	tests := []struct {
+		name string
+		json string
+		err  bool
+	}{
+		{name: "json number", json: `{"price":1.5,"ratio":0.5,"scores":[1,2.5]}`},
+		{name: "float string", json: `{"price":"1.5"}`, err: true},
+		{name: "float32 string", json: `{"ratio":"0.5"}`, err: true},
+		{name: "array element string", json: `{"scores":[1,"2.5"]}`, err: true},
+		{name: "embedded object inherits", json: `{"dims":{"width":"3"}}`, err: true},
+		{name: "lenient field accepts strings", json: `{"weight":"2.5"}`},
+		{name: "string field unaffected", json: `{"code":"1.5"}`},
+	}
+	schema := newSchema().StrictNumbers()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := schema.Validate(tc.json).Error()
+			if tc.err {
+				require.ErrorIs(t, firstError(t, err), validator.ErrTypeMismatch)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("coerce takes precedence", func(t *testing.T) {
+		res := WithFields(Field[float64]("price"), Field[int]("qty")).StrictNumbers().Coerce().Validate(`{"price":"1.5","qty":"2"}`)
+		require.NoError(t, res.Error())
+	})
+}
+
+// firstError returns the single field error of a failed validation.
+func firstError(t *testing.T, err error) error {
+	t.Helper()
+	var verr *validationError
+	require.ErrorAs(t, err, &verr)
+	var found error
+	verr.walk("", func(_ string, err error) { found = err })
+	return found
+}
@@ -0,0 +1,212 @@
+package view
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+)
+
+// Compile prepares the schema for repeated validation: every field, including
+// those of embedded schemas, gets a parser specialized for its type, so
+// values are converted without reflection, and the lookup table of the
+// schema's fields is built once instead of on every call. Validation results
+// are the same with or without Compile.
+//
+// Compile modifies the schema and its fields; call it once, after the
+// schema is fully configured and before it is shared between goroutines:
+//
+//	var signup = view.WithFields(...).Compile()
+func (s *Schema) Compile() *Schema {
+	s.compile(map[*Schema]bool{})
+	return s
+}
+
+// compile compiles s and the schemas it embeds, visiting each schema once.
+func (s *Schema) compile(done map[*Schema]bool) {
+	if s == nil || done[s] {
+		return
+	}
+	done[s] = true
+	s.known = s.knownFields()
+	for _, field := range s.fields {
+		if c, ok := field.(interface{ compile(map[*Schema]bool) }); ok {
+			c.compile(done)
+		}
+	}
+}
+
+// knownFields maps the names of the fields read from the payload or URL
+// parameters, aliases included, to whether they are embedded objects.
+// Header fields are left out.
+func (s *Schema) knownFields() map[string]bool {
+	if s.known != nil {
+		return s.known
+	}
+	known := map[string]bool{}
+	for _, field := range s.fields {
+		if headerOf(field) != "" {
+			continue
+		}
+		for _, name := range inputNames(field) {
+			known[name] = field.IsObject()
+		}
+	}
+	return known
+}
+
+func (f *JSONField[T]) compile(done map[*Schema]bool) {
+	f.parse = lo.Ternary(f.timed(), f.jsonTime, jsonParser[T]())
+	f.embedded.compile(done)
+}
+
+func (f *DictField[V]) compile(done map[*Schema]bool) {
+	f.value.compile(done)
+}
+
+func (f *GridField[T]) compile(done map[*Schema]bool) {
+	f.element.compile(done)
+}
+
+// parser returns the parser of f, typedJson unless f was compiled or
+// parses times with its own layouts.
+func (f *JSONField[T]) parser() func(gjson.Result) mo.Result[T] {
+	if f.parse != nil {
+		return f.parse
+	}
+	if f.timed() {
+		return f.jsonTime
+	}
+	return typedJson[T]
+}
+
+// jsonParser returns a parser equivalent to typedJson specialized for T, so
+// the type is switched on once instead of for every value.
+func jsonParser[T validator.FieldType]() func(gjson.Result) mo.Result[T] {
+	var parse any
+	switch any(*new(T)).(type) {
+	case string:
+		parse = func(res gjson.Result) mo.Result[string] {
+			if res.Type == gjson.String {
+				return mo.Ok(res.Str)
+			}
+			return mismatch[string](res)
+		}
+	case bool:
+		parse = func(res gjson.Result) mo.Result[bool] {
+			if res.Type == gjson.True || res.Type == gjson.False {
+				return mo.Ok(res.Type == gjson.True)
+			}
+			return mismatch[bool](res)
+		}
+	case int:
+		parse = signedParser[int](strconv.IntSize)
+	case int8:
+		parse = signedParser[int8](8)
+	case int16:
+		parse = signedParser[int16](16)
+	case int32:
+		parse = signedParser[int32](32)
+	case int64:
+		parse = signedParser[int64](64)
+	case uint:
+		parse = unsignedParser[uint](strconv.IntSize)
+	case uint8:
+		parse = unsignedParser[uint8](8)
+	case uint16:
+		parse = unsignedParser[uint16](16)
+	case uint32:
+		parse = unsignedParser[uint32](32)
+	case uint64:
+		parse = unsignedParser[uint64](64)
+	case float32:
+		parse = floatParser[float32](32)
+	case float64:
+		parse = floatParser[float64](64)
+	case time.Time:
+		parse = timeParser
+	default:
+		return typedJson[T]
+	}
+	return parse.(func(gjson.Result) mo.Result[T])
+}
+
+// mismatch is the error of a JSON value of the wrong type, as reported by
+// typedJson.
+func mismatch[T validator.FieldType](res gjson.Result) mo.Result[T] {
+	return mo.Err[T](fmt.Errorf("%w: expected %T but got raw type %s", validator.ErrTypeMismatch, *new(T), res.Type))
+}
+
+func signedParser[T int | int8 | int16 | int32 | int64](bits int) func(gjson.Result) mo.Result[T] {
+	return func(res gjson.Result) mo.Result[T] {
+		if res.Type != gjson.Number {
+			return mismatch[T](res)
+		}
+		val, err := strconv.ParseInt(res.Raw, 10, bits)
+		if err != nil {
+			if strings.Contains(res.Raw, ".") {
+				return mo.Err[T](fmt.Errorf("%w: cannot assign float value %s to integer type", validator.ErrTypeMismatch, res.Raw))
+			}
+			return mo.Err[T](overflowError(*new(T)))
+		}
+		return mo.Ok(T(val))
+	}
+}
+
+func unsignedParser[T uint | uint8 | uint16 | uint32 | uint64](bits int) func(gjson.Result) mo.Result[T] {
+	return func(res gjson.Result) mo.Result[T] {
+		if res.Type != gjson.Number {
+			return mismatch[T](res)
+		}
+		if strings.Contains(res.Raw, "-") {
+			return mo.Err[T](overflowError(*new(T)))
+		}
+		val, err := strconv.ParseUint(res.Raw, 10, bits)
+		if err != nil {
+			if strings.Contains(res.Raw, ".") {
+				return mo.Err[T](fmt.Errorf("%w: cannot assign float value %s to unsigned integer type", validator.ErrTypeMismatch, res.Raw))
+			}
+			return mo.Err[T](overflowError(*new(T)))
+		}
+		return mo.Ok(T(val))
+	}
+}
+
+func floatParser[T float32 | float64](bits int) func(gjson.Result) mo.Result[T] {
+	return func(res gjson.Result) mo.Result[T] {
+		var val float64
+		switch res.Type {
+		case gjson.Number:
+			val = res.Num
+		case gjson.String:
+			var err error
+			if val, err = strconv.ParseFloat(res.Str, 64); err != nil {
+				return mo.Err[T](fmt.Errorf("could not parse string '%s' as float: %w", res.Str, err))
+			}
+		default:
+			return mismatch[T](res)
+		}
+		if abs := math.Abs(val); bits == 32 && abs > math.MaxFloat32 && abs <= math.MaxFloat64 {
+			return mo.Err[T](fmt.Errorf("value %f overflows type %T", val, *new(T)))
+		}
+		return mo.Ok(T(val))
+	}
+}
+
+func timeParser(res gjson.Result) mo.Result[time.Time] {
+	if res.Type != gjson.String {
+		return mismatch[time.Time](res)
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, res.Str); err == nil {
+			return mo.Ok(t)
+		}
+	}
+	return mo.Err[time.Time](fmt.Errorf("incorrect date format for string '%s'", res.Str))
+}
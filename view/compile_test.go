@@ -0,0 +1,202 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+// requireSameParse checks that the compiled parser of T agrees with typedJson.
+func requireSameParse[T validator.FieldType](t *testing.T, raws ...string) {
+	t.Helper()
+	parse := jsonParser[T]()
+	for _, raw := range raws {
+		node := gjson.Parse(raw)
+		want, got := typedJson[T](node), parse(node)
+		require.Equal(t, want.IsOk(), got.IsOk(), "%T %s", *new(T), raw)
+		if want.IsOk() {
+			require.Equal(t, want.MustGet(), got.MustGet(), "%T %s", *new(T), raw)
+		} else {
+			require.Equal(t, want.Error().Error(), got.Error().Error(), "%T %s", *new(T), raw)
+		}
+	}
+}
+
+func TestJSONParser(t *testing.T) {
+	raws := []string{`"abc"`, `""`, `true`, `false`, `null`, `{}`, `[1]`,
+		`0`, `1`, `-1`, `127`, `128`, `-129`, `255`, `256`, `65536`, `4294967296`,
+		`9223372036854775807`, `9223372036854775808`, `18446744073709551616`,
+		`1.5`, `-2.25`, `1e3`, `3.5e38`, `1e309`, `"1.5"`, `"x"`,
+		`"2024-01-02"`, `"2024-01-02T03:04:05Z"`, `"2024-01-02T03:04:05.123+08:00"`, `"02/01/2024"`}
+	requireSameParse[string](t, raws...)
+	requireSameParse[bool](t, raws...)
+	requireSameParse[int](t, raws...)
+	requireSameParse[int8](t, raws...)
+	requireSameParse[int16](t, raws...)
+	requireSameParse[int32](t, raws...)
+	requireSameParse[int64](t, raws...)
+	requireSameParse[uint](t, raws...)
+	requireSameParse[uint8](t, raws...)
+	requireSameParse[uint16](t, raws...)
+	requireSameParse[uint32](t, raws...)
+	requireSameParse[uint64](t, raws...)
+	requireSameParse[float32](t, raws...)
+	requireSameParse[float64](t, raws...)
+	requireSameParse[time.Time](t, raws...)
+}
+
+func TestSchema_Compile(t *testing.T) {
+	newSchema := func() *Schema {
+		item := WithFields(Field[int]("id"), Field[float64]("price", validator.Gt(0.0)))
+		return WithFields(
+			Field[string]("name", validator.MinLength(2)),
+			Field[uint8]("age"),
+			Field[time.Time]("since").Optional(),
+			ArrayField[int64]("ids").Optional(),
+			ArrayOfObjectField("items", item).Optional(),
+			MapField[int]("counts").Optional(),
+			MatrixField[float32]("grid").Optional(),
+		)
+	}
+	payloads := []string{
+		`{"name":"ab","age":20,"since":"2024-01-02","ids":[1,2],"items":[{"id":1,"price":2.5}],"counts":{"a":1},"grid":[[1.5]]}`,
+		`{"name":"a","age":300,"ids":[1,"x"],"items":[{"id":1.5,"price":0}],"counts":{"a":true},"grid":[["x"]]}`,
+		`{"name":1,"age":-1}`,
+		`{"age":20}`,
+	}
+	interpreted, compiled := newSchema(), newSchema().Compile()
+	for i, payload := range payloads {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			want, got := interpreted.Validate(payload), compiled.Validate(payload)
+			if want.IsError() {
+				require.EqualError(t, got.Error(), want.Error().Error())
+				return
+			}
+			require.NoError(t, got.Error())
+			require.Equal(t, want.MustGet(), got.MustGet())
+		})
+	}
+
+	t.Run("recursive schema", func(t *testing.T) {
+		node := WithFields(Field[int]("value"), ArrayOfObjectField("children", SelfRef()).Optional()).Compile()
+		require.NoError(t, node.Validate(`{"value":1,"children":[{"value":2}]}`).Error())
+		require.Error(t, node.Validate(`{"value":1,"children":[{"value":"x"}]}`).Error())
+	})
+}
+
+func BenchmarkSchema_Validate(b *testing.B) {
+	newSchema := func() *Schema {
+		item := WithFields(Field[int]("id"), Field[float64]("price"), Field[uint16]("qty"))
+		return WithFields(
+			Field[string]("name"),
+			Field[int]("age"),
+			Field[bool]("active"),
+			Field[time.Time]("since"),
+			ArrayField[int64]("ids"),
+			ArrayOfObjectField("items", item),
+		)
+	}
+	payload := `{"name":"alice","age":30,"active":true,"since":"2024-01-02T03:04:05Z","ids":[1,2,3,4,5,6,7,8],` +
+		`"items":[{"id":1,"price":9.99,"qty":2},{"id":2,"price":19.5,"qty":1},{"id":3,"price":4.25,"qty":7}]}`
+	for _, bc := range []struct {
+		name   string
+		schema *Schema
+	}{
+		{"interpreted", newSchema()},
+		{"compiled", newSchema().Compile()},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if res := bc.schema.Validate(payload); res.IsError() {
+					b.Fatal(res.Error())
+				}
+			}
+		})
+	}
+}
+
+// wideSchema returns a schema of n flat fields and a payload giving all of them.
+func wideSchema(n int) (*Schema, string) {
+	fields := make([]ViewField, 0, n)
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("field%02d", i)
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		if i%2 == 0 {
+			fields = append(fields, Field[string](name))
+			fmt.Fprintf(&sb, `"%s":"value %d"`, name, i)
+		} else {
+			fields = append(fields, Field[int](name))
+			fmt.Fprintf(&sb, `"%s":%d`, name, i)
+		}
+	}
+	sb.WriteByte('}')
+	return WithFields(fields...), sb.String()
+}
+
+func TestSchema_ValidateWide(t *testing.T) {
+	schema, payload := wideSchema(60)
+	vo := schema.Validate(payload).MustGet()
+	require.Len(t, vo.Fields(), 60)
+	require.Equal(t, "value 58", vo.MstString("field58"))
+	require.Equal(t, 59, vo.MstInt("field59"))
+
+	// the first of duplicated keys wins, as with gjson.Get
+	vo = WithFields(Field[string]("name")).Validate(`{"name":"a","name":"b"}`).MustGet()
+	require.Equal(t, "a", vo.MstString("name"))
+}
+
+// BenchmarkSchema_ValidateWide validates payloads of schemas with 50+ fields.
+// The lookup sub-benchmarks compare finding every member of the payload with
+// one gjson.Get per field, as Validate used to, against the single pass it
+// makes now.
+func BenchmarkSchema_ValidateWide(b *testing.B) {
+	for _, n := range []int{50, 100} {
+		schema, payload := wideSchema(n)
+		names := lo.Map(schema.fields, func(f ViewField, _ int) string { return f.Name() })
+		b.Run(fmt.Sprintf("validate/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if res := schema.Validate(payload); res.IsError() {
+					b.Fatal(res.Error())
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("lookup_per_field/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = gjson.Get(payload, "@keys")
+				for _, name := range names {
+					if !gjson.Get(payload, name).Exists() {
+						b.Fatal(name)
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("lookup_single_pass/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				nodes := make(map[string]gjson.Result, len(names))
+				gjson.Parse(payload).ForEach(func(key, value gjson.Result) bool {
+					nodes[key.String()] = value
+					return true
+				})
+				for _, name := range names {
+					if !nodes[name].Exists() {
+						b.Fatal(name)
+					}
+				}
+			}
+		})
+	}
+}
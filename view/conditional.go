@@ -0,0 +1,116 @@
+package view
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kcmvp/xql/internal"
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+)
+
+// requirement is a presence rule of a field that depends on the value of
+// another field of the same Schema.
+type requirement struct {
+	field  string
+	values []any
+	unless bool
+}
+
+// applies reports whether the dependent field is required, given the
+// validated value of the referenced field.
+func (r requirement) applies(v any, present bool) bool {
+	matched := present && (len(r.values) == 0 || lo.ContainsBy(r.values, func(want any) bool {
+		return reflect.DeepEqual(v, want) || fmt.Sprint(v) == fmt.Sprint(want)
+	}))
+	return matched != r.unless
+}
+
+// describe renders the condition for error messages.
+func (r requirement) describe() string {
+	var cond string
+	switch len(r.values) {
+	case 0:
+		cond = fmt.Sprintf("%s is present", r.field)
+	case 1:
+		cond = fmt.Sprintf("%s is %v", r.field, r.values[0])
+	default:
+		cond = fmt.Sprintf("%s is one of %v", r.field, r.values)
+	}
+	return lo.Ternary(r.unless, "unless ", "when ") + cond
+}
+
+// RequiredIf makes the field required when the field named other has one of
+// the given values; without values it is required whenever other is
+// present. The field is optional otherwise:
+//
+//	view.Field[string]("state").RequiredIf("country", "US")
+//
+// Conditions are evaluated after all fields are parsed against the validated
+// values, so `"country"` must name a field of the same Schema (a field
+// missing from the Schema is treated as absent). A failed condition is
+// reported under the dependent field like any other required field.
+func (f *JSONField[T]) RequiredIf(other string, values ...any) *JSONField[T] {
+	return f.require(requirement{field: other, values: values})
+}
+
+// RequiredUnless makes the field required unless the field named other has
+// one of the given values; without values it is required unless other is
+// present. See RequiredIf.
+func (f *JSONField[T]) RequiredUnless(other string, values ...any) *JSONField[T] {
+	return f.require(requirement{field: other, values: values, unless: true})
+}
+
+func (f *JSONField[T]) require(r requirement) *JSONField[T] {
+	r.field = strings.TrimSpace(r.field)
+	lo.Assertf(r.field != "" && r.field != f.Name(), "xql: field '%s' cannot depend on '%s'", f.Name(), r.field)
+	f.required = false
+	f.requirements = append(f.requirements, r)
+	return f
+}
+
+func (f *JSONField[T]) conditions() []requirement {
+	return f.requirements
+}
+
+// conditionsOf returns the conditional requirements of a field.
+func conditionsOf(field ViewField) []requirement {
+	if c, ok := field.(interface{ conditions() []requirement }); ok {
+		return c.conditions()
+	}
+	return nil
+}
+
+// checkConditions evaluates the conditional requirements of s against the
+// validated object, adding an ErrRequired entry to errs for every dependent
+// field that is missing. Fields that already failed validation are skipped.
+func (s *Schema) checkConditions(object internal.Data, errs *validationError) {
+	uniqueNames := lo.SliceToMap(s.fields, func(field ViewField) (string, string) {
+		return field.Name(), field.UniqueName()
+	})
+	for _, field := range s.fields {
+		conds := conditionsOf(field)
+		if len(conds) == 0 || object.Get(field.UniqueName()).IsPresent() {
+			continue
+		}
+		name := field.Name()
+		if lo.SomeBy(lo.Keys(errs.errors), func(k string) bool {
+			return k == name || strings.HasPrefix(k, name+"[") || strings.HasPrefix(k, name+".")
+		}) {
+			continue
+		}
+		for _, cond := range conds {
+			var v any
+			present := false
+			if key, ok := uniqueNames[cond.field]; ok {
+				opt := object.Get(key)
+				v, present = opt.OrEmpty(), opt.IsPresent()
+			}
+			if cond.applies(v, present) {
+				errs.add(field.Name(), fmt.Errorf("%s %w %s", field.Name(), validator.ErrRequired, cond.describe()))
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package view
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONField_RequiredIf(t *testing.T) {
+	schema := WithFields(
+		Field[string]("country"),
+		Field[string]("state", validator.ExactLength(2)).RequiredIf("country", "US", "CA"),
+		Field[string]("vat").RequiredUnless("country", "US"),
+		Field[string]("email").Optional(),
+		Field[bool]("subscribe").Optional(),
+		Field[string]("reason").RequiredIf("subscribe", true),
+		Field[string]("phone").RequiredUnless("email"),
+	)
+	tests := []struct {
+		name   string
+		json   string
+		errKey []string
+	}{
+		{name: "us with state", json: `{"country":"US","state":"NY","email":"a@x.io"}`},
+		{name: "us without state", json: `{"country":"US","email":"a@x.io"}`, errKey: []string{"state"}},
+		{name: "second value", json: `{"country":"CA","vat":"1","email":"a@x.io"}`, errKey: []string{"state"}},
+		{name: "other country", json: `{"country":"FR","vat":"FR1","email":"a@x.io"}`},
+		{name: "unless not met", json: `{"country":"FR","email":"a@x.io"}`, errKey: []string{"vat"}},
+		{name: "typed value", json: `{"country":"US","state":"NY","email":"a@x.io","subscribe":true}`, errKey: []string{"reason"}},
+		{name: "typed value not matched", json: `{"country":"US","state":"NY","email":"a@x.io","subscribe":false}`},
+		{name: "presence", json: `{"country":"US","state":"NY"}`, errKey: []string{"phone"}},
+		{name: "invalid value keeps its own error", json: `{"country":"US","state":"NYC","email":"a@x.io"}`, errKey: []string{"state"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := schema.Validate(tc.json)
+			if len(tc.errKey) == 0 {
+				require.NoError(t, res.Error())
+				return
+			}
+			require.Error(t, res.Error())
+			var verr *validationError
+			require.ErrorAs(t, res.Error(), &verr)
+			require.Len(t, verr.errors, len(tc.errKey))
+			for _, k := range tc.errKey {
+				require.Contains(t, verr.errors, k)
+			}
+		})
+	}
+
+	t.Run("error message", func(t *testing.T) {
+		res := schema.Validate(`{"country":"US","email":"a@x.io"}`)
+		var verr *validationError
+		require.ErrorAs(t, res.Error(), &verr)
+		require.ErrorIs(t, verr.first("state"), validator.ErrRequired)
+		require.ErrorContains(t, res.Error(), "state is required but not found when country is one of [US CA]")
+	})
+
+	t.Run("form", func(t *testing.T) {
+		res := schema.ValidateForm(url.Values{"country": {"US"}, "email": {"a@x.io"}})
+		require.ErrorContains(t, res.Error(), "state is required")
+	})
+
+	t.Run("self reference", func(t *testing.T) {
+		require.Panics(t, func() { Field[string]("a").RequiredIf("a") })
+	})
+}
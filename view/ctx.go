@@ -0,0 +1,142 @@
+package view
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kcmvp/xql/internal"
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// CheckCtx registers validators needing a context, such as a uniqueness
+// check against the database:
+//
+//	view.Field[string]("email", validator.Email()).CheckCtx(func(ctx context.Context, email string) error {
+//		taken, err := users.EmailTaken(ctx, email)
+//		if err != nil {
+//			return err
+//		}
+//		return lo.Ternary(taken, errors.New("email is already taken"), nil)
+//	})
+//
+// They run after the whole payload passed every other check, so no query
+// is made for an invalid payload, with the context given to ValidateCtx,
+// the request's for ValidateRequest and context.Background otherwise. For
+// an array field they run on every element.
+func (f *JSONField[T]) CheckCtx(fns ...validator.ValidatorCtx[T]) *JSONField[T] {
+	for _, fn := range fns {
+		lo.Assertf(fn != nil, "xql: nil context validator for field '%s'", f.Name())
+	}
+	f.ctxValidators = append(f.ctxValidators, fns...)
+	return f
+}
+
+// ValidateCtx is Validate running the CheckCtx validators with ctx. Once
+// ctx is done validation stops with an error wrapping ctx.Err().
+func (s *Schema) ValidateCtx(ctx context.Context, json string, urlParams ...map[string]string) mo.Result[ValueObject] {
+	return s.validate(ctx, json, singleValues(urlParams), nil)
+}
+
+// ctxChecker is implemented by fields with CheckCtx validators.
+type ctxChecker interface {
+	checkCtx(ctx context.Context, key string, val any, errs *validationError) error
+}
+
+func (f *JSONField[T]) checkCtx(ctx context.Context, key string, val any, errs *validationError) error {
+	check := func(key string, v T) error {
+		for _, fn := range f.ctxValidators {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(ctx, v); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				errs.add(key, fmt.Errorf("field '%s': %w", f.Name(), err))
+				return nil
+			}
+		}
+		return nil
+	}
+	switch v := val.(type) {
+	case T:
+		return check(key, v)
+	case []T:
+		for i, item := range v {
+			if err := check(fmt.Sprintf("%s[%d]", key, i), item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// usesCtx reports whether s or a schema it embeds has CheckCtx validators.
+func (s *Schema) usesCtx(done map[*Schema]bool) bool {
+	if s == nil || done[s] {
+		return false
+	}
+	done[s] = true
+	return lo.SomeBy(s.fields, func(field ViewField) bool {
+		if f, ok := field.(interface{ hasCtxValidators() bool }); ok && f.hasCtxValidators() {
+			return true
+		}
+		nested, ok := field.embeddedObject().Get()
+		return ok && nested.usesCtx(done)
+	})
+}
+
+func (f *JSONField[T]) hasCtxValidators() bool {
+	return len(f.ctxValidators) > 0
+}
+
+// checkCtx runs the CheckCtx validators over the value of a successful
+// validation.
+func (s *Schema) checkCtx(ctx context.Context, res mo.Result[ValueObject]) mo.Result[ValueObject] {
+	if res.IsError() || !s.usesCtx(map[*Schema]bool{}) {
+		return res
+	}
+	errs := &validationError{}
+	if err := s.runCtx(ctx, "", asData(res.MustGet()), errs); err != nil {
+		return mo.Err[ValueObject](fmt.Errorf("validation aborted: %w", err))
+	}
+	return lo.Ternary(errs.err() != nil, mo.Err[ValueObject](errs.err()), res)
+}
+
+// runCtx runs the CheckCtx validators of s over data, the value of an
+// object found at prefix. It returns the error of a done context.
+func (s *Schema) runCtx(ctx context.Context, prefix string, data internal.Data, errs *validationError) error {
+	for _, field := range s.fields {
+		val, ok := internal.Get[any](data, field.UniqueName()).Get()
+		if !ok {
+			continue
+		}
+		key := joinPath(prefix, field.Name())
+		if c, ok := field.(ctxChecker); ok {
+			if err := c.checkCtx(ctx, key, val, errs); err != nil {
+				return err
+			}
+		}
+		nested, ok := field.embeddedObject().Get()
+		if !ok {
+			continue
+		}
+		var err error
+		switch v := val.(type) {
+		case ValueObject:
+			err = nested.runCtx(ctx, key, asData(v), errs)
+		case []ValueObject:
+			for i, item := range v {
+				if err = nested.runCtx(ctx, fmt.Sprintf("%s[%d]", key, i), asData(item), errs); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
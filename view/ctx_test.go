@@ -0,0 +1,83 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+func TestSchema_ValidateCtx(t *testing.T) {
+	taken := map[string]bool{"taken@b.c": true}
+	var calls int
+	notTaken := func(ctx context.Context, email string) error {
+		calls++
+		if ctx.Value(ctxKey{}) != nil {
+			return errors.New("tenant " + ctx.Value(ctxKey{}).(string))
+		}
+		if taken[email] {
+			return errors.New("email is already taken")
+		}
+		return nil
+	}
+	member := WithFields(Field[string]("email").CheckCtx(notTaken))
+	schema := WithFields(
+		Field[string]("email").CheckCtx(notTaken),
+		Field[int]("age").Optional(),
+		ArrayField[string]("aliases").CheckCtx(notTaken).Optional(),
+		ArrayOfObjectField("members", member).Optional(),
+	)
+	tests := []struct {
+		name   string
+		json   string
+		calls  int
+		errors map[string]string
+	}{
+		{name: "valid", json: `{"email":"a@b.c","aliases":["x@b.c"],"members":[{"email":"m@b.c"}]}`, calls: 3},
+		{
+			name:   "taken",
+			json:   `{"email":"taken@b.c","aliases":["x@b.c","taken@b.c"],"members":[{"email":"m@b.c"},{"email":"taken@b.c"}]}`,
+			calls:  5,
+			errors: map[string]string{"email": "email is already taken", "aliases[1]": "email is already taken", "members[1].email": "email is already taken"},
+		},
+		{name: "not run on invalid payload", json: `{"email":"taken@b.c","age":"x"}`, errors: map[string]string{"age": "type mismatch: expected int but got raw type String"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			res := schema.ValidateCtx(context.Background(), tt.json)
+			require.Equal(t, tt.calls, calls)
+			if tt.errors == nil {
+				require.NoError(t, res.Error())
+				return
+			}
+			var verr *validationError
+			require.ErrorAs(t, res.Error(), &verr)
+			require.Equal(t, tt.errors, verr.Errors())
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		res := schema.ValidateCtx(ctx, `{"email":"a@b.c"}`)
+		require.ErrorIs(t, res.Error(), context.Canceled)
+	})
+
+	t.Run("validate uses background", func(t *testing.T) {
+		require.Error(t, schema.Validate(`{"email":"taken@b.c"}`).Error())
+	})
+
+	t.Run("request context", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"a@b.c"}`))
+		r = r.WithContext(context.WithValue(r.Context(), ctxKey{}, "acme"))
+		var verr *validationError
+		require.ErrorAs(t, schema.ValidateRequest(r).Error(), &verr)
+		require.Equal(t, map[string]string{"email": "tenant acme"}, verr.Errors())
+	})
+}
@@ -0,0 +1,171 @@
+package view
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+)
+
+// fieldTypes holds the parse functions registered with RegisterFieldType,
+// keyed by type.
+var fieldTypes sync.Map
+
+// RegisterFieldType registers the parse function of a custom field type, so
+// CustomField[T] fields can validate it:
+//
+//	view.RegisterFieldType(decimal.NewFromString)
+//
+// parse receives the JSON string value, or the raw JSON token for other
+// JSON types (so a number such as 12.50 arrives as "12.50"), and the URL
+// parameter or form value as is. Types whose pointer implements
+// encoding.TextUnmarshaler, like uuid.UUID or net.IP, work without
+// registration. Registering a type twice replaces the previous function.
+func RegisterFieldType[T any](parse func(string) (T, error)) {
+	lo.Assertf(parse != nil, "xql: nil parse function for %s", reflect.TypeFor[T]())
+	fieldTypes.Store(reflect.TypeFor[T](), parse)
+}
+
+// parserOf returns the parse function of T: the registered one, or the
+// encoding.TextUnmarshaler implementation of *T.
+func parserOf[T any]() (func(string) (T, error), bool) {
+	if parse, ok := fieldTypes.Load(reflect.TypeFor[T]()); ok {
+		return parse.(func(string) (T, error)), true
+	}
+	var zero T
+	if _, ok := any(&zero).(encoding.TextUnmarshaler); ok {
+		return func(s string) (T, error) {
+			var v T
+			err := any(&v).(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			return v, err
+		}, true
+	}
+	return nil, false
+}
+
+// TypedField is the ViewField of a custom field type, see CustomField.
+type TypedField[T any] struct {
+	name       string
+	required   bool
+	array      bool
+	validators []func(T) error
+}
+
+var _ ViewField = (*TypedField[struct{}])(nil)
+
+// CustomField creates a required field of a type outside
+// validator.FieldType, parsed by the function registered with
+// RegisterFieldType (or encoding.TextUnmarshaler) and checked by the given
+// validators:
+//
+//	view.CustomField[uuid.UUID]("id", func(id uuid.UUID) error {
+//		return lo.Ternary(id.Version() != 4, errors.New("must be a v4 uuid"), nil)
+//	})
+//
+// The validated value is stored as T. CustomField panics if T cannot be
+// parsed, so types must be registered before the Schema is built.
+func CustomField[T any](name string, validators ...func(T) error) *TypedField[T] {
+	if strings.ContainsAny(name, ".#") {
+		panic(fmt.Sprintf("xql: field name '%s' cannot contain '.' or '#'", name))
+	}
+	_, ok := parserOf[T]()
+	lo.Assertf(ok, "xql: type %s of field '%s' is not registered, see RegisterFieldType", reflect.TypeFor[T](), name)
+	return &TypedField[T]{name: name, required: true, validators: validators}
+}
+
+// Optional marks the field as optional.
+func (f *TypedField[T]) Optional() *TypedField[T] {
+	f.required = false
+	return f
+}
+
+// AsArray accepts an array of T.
+func (f *TypedField[T]) AsArray() *TypedField[T] {
+	f.array = true
+	return f
+}
+
+func (f *TypedField[T]) Scope() string         { return "" }
+func (f *TypedField[T]) QualifiedName() string { return f.name }
+func (f *TypedField[T]) Name() string          { return f.name }
+func (f *TypedField[T]) UniqueName() string    { return f.name }
+func (f *TypedField[T]) IsArray() bool         { return f.array }
+func (f *TypedField[T]) IsObject() bool        { return false }
+func (f *TypedField[T]) Required() bool        { return f.required }
+
+// parse parses and validates a single value.
+func (f *TypedField[T]) parse(s string) (T, error) {
+	parse, _ := parserOf[T]()
+	v, err := parse(s)
+	if err != nil {
+		return v, fmt.Errorf("field '%s': invalid %s: %w", f.name, reflect.TypeFor[T](), err)
+	}
+	for _, vfn := range f.validators {
+		if err = vfn(v); err != nil {
+			return v, fmt.Errorf("field '%s': %w", f.name, err)
+		}
+	}
+	return v, nil
+}
+
+// text returns the string handed to the parse function for a JSON node.
+func text(node gjson.Result) string {
+	return lo.Ternary(node.Type == gjson.String, node.Str, node.Raw)
+}
+
+func (f *TypedField[T]) validate(node gjson.Result, _ walkState) mo.Result[any] {
+	if !f.array {
+		if node.IsArray() || node.IsObject() {
+			return mo.Err[any](fmt.Errorf("field '%s': expected a single value", f.name))
+		}
+		return f.validateRaw(text(node))
+	}
+	if !node.IsArray() {
+		return mo.Err[any](fmt.Errorf("field '%s': expected an array", f.name))
+	}
+	return f.validateRawArray(lo.Map(node.Array(), func(el gjson.Result, _ int) string {
+		return text(el)
+	}))
+}
+
+func (f *TypedField[T]) validateRaw(s string) mo.Result[any] {
+	v, err := f.parse(s)
+	return lo.Ternary(err != nil, mo.Err[any](err), mo.Ok[any](v))
+}
+
+func (f *TypedField[T]) validateRawArray(vs []string) mo.Result[any] {
+	errs := &validationError{}
+	values := make([]T, 0, len(vs))
+	for i, s := range vs {
+		v, err := f.parse(s)
+		errs.add(fmt.Sprintf("%s[%d]", f.name, i), err)
+		values = append(values, v)
+	}
+	if errs.err() != nil {
+		return mo.Err[any](errs.err())
+	}
+	return mo.Ok[any](values)
+}
+
+func (f *TypedField[T]) optional(map[*Schema]*Schema) ViewField {
+	cp := *f
+	cp.required = false
+	return &cp
+}
+
+func (f *TypedField[T]) embeddedObject() mo.Option[*Schema] {
+	return mo.None[*Schema]()
+}
+
+func (f *TypedField[T]) jsonSchema(map[*Schema]string) map[string]any {
+	node := map[string]any{"type": "string"}
+	if f.array {
+		return map[string]any{"type": "array", "items": node}
+	}
+	return node
+}
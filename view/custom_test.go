@@ -0,0 +1,82 @@
+package view
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cents is a money amount parsed from a decimal string like "12.50".
+type cents int64
+
+func parseCents(s string) (cents, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	if len(frac) > 2 {
+		return 0, errors.New("at most two decimals")
+	}
+	v, err := strconv.ParseInt(whole+(frac + "00")[:2], 10, 64)
+	return cents(v), err
+}
+
+func TestCustomField(t *testing.T) {
+	RegisterFieldType(parseCents)
+	positive := func(c cents) error {
+		if c <= 0 {
+			return errors.New("must be positive")
+		}
+		return nil
+	}
+	schema := WithFields(
+		CustomField[cents]("amount", positive),
+		CustomField[net.IP]("ip").Optional(),
+		CustomField[cents]("fees").AsArray().Optional(),
+	)
+	tests := []struct {
+		name   string
+		json   string
+		params url.Values
+		errKey string
+	}{
+		{name: "number", json: `{"amount":12.5,"ip":"10.0.0.1","fees":["1.25",2]}`},
+		{name: "string", json: `{"amount":"12.50"}`},
+		{name: "url params", json: `{}`, params: url.Values{"amount": {"3"}, "fees": {"1", "2"}}},
+		{name: "parse error", json: `{"amount":"12.505"}`, errKey: "amount"},
+		{name: "validator", json: `{"amount":0}`, errKey: "amount"},
+		{name: "text unmarshaler", json: `{"amount":1,"ip":"not an ip"}`, errKey: "ip"},
+		{name: "array element", json: `{"amount":1,"fees":[1,"x"]}`, errKey: "fees[1]"},
+		{name: "array expected", json: `{"amount":1,"fees":1}`, errKey: "fees"},
+		{name: "single expected", json: `{"amount":[1]}`, errKey: "amount"},
+		{name: "required", json: `{}`, errKey: "amount"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := schema.ValidateValues(tc.json, tc.params)
+			if tc.errKey == "" {
+				require.NoError(t, res.Error())
+				return
+			}
+			var verr *validationError
+			require.ErrorAs(t, res.Error(), &verr)
+			require.Contains(t, verr.errors, tc.errKey)
+		})
+	}
+
+	t.Run("values", func(t *testing.T) {
+		res := schema.Validate(`{"amount":"12.5","ip":"10.0.0.1","fees":[1,"0.05"]}`)
+		require.NoError(t, res.Error())
+		vo := res.MustGet()
+		require.Equal(t, cents(1250), vo.Get("amount").MustGet())
+		require.Equal(t, net.ParseIP("10.0.0.1"), vo.Get("ip").MustGet())
+		require.Equal(t, []cents{100, 5}, vo.Get("fees").MustGet())
+	})
+
+	t.Run("unregistered type", func(t *testing.T) {
+		type point struct{ x, y int }
+		require.Panics(t, func() { CustomField[point]("p") })
+	})
+}
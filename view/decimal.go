@@ -0,0 +1,299 @@
+package view
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+)
+
+// ErrDecimalRange is returned for a decimal that does not fit the precision
+// and scale of its DecimalField.
+var ErrDecimalRange = errors.New("decimal out of range")
+
+// Decimal is an arbitrary-precision decimal number, the value of a
+// DecimalField: the unscaled integer times 10^-scale. Unlike float64 it
+// represents amounts such as 0.10 exactly, and it is written to the
+// database as its string form through driver.Valuer. The zero value is 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// maxDecimalExponent bounds the exponent accepted by ParseDecimal.
+const maxDecimalExponent = 1000
+
+// ParseDecimal parses a decimal number such as "-12.50" or "1.5e3".
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		// the exponent is bounded so a short input cannot allocate a huge number
+		if err != nil || e < -maxDecimalExponent || e > maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+		}
+		mantissa, exp = s[:i], e
+	}
+	whole, frac, _ := strings.Cut(mantissa, ".")
+	digits := strings.TrimLeft(whole, "+-") + frac
+	if digits == "" || strings.Trim(digits, "0123456789") != "" || len(whole)-len(strings.TrimLeft(whole, "+-")) > 1 {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+	unscaled, _ := new(big.Int).SetString(digits, 10)
+	if strings.HasPrefix(whole, "-") {
+		unscaled.Neg(unscaled)
+	}
+	d := Decimal{unscaled: unscaled, scale: len(frac) - exp}
+	if d.scale < 0 {
+		return d.Rescale(0), nil
+	}
+	return d, nil
+}
+
+// MustParseDecimal is ParseDecimal panicking on error, for constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	lo.Must0(err)
+	return d
+}
+
+func (d Decimal) int() *big.Int {
+	return lo.Ternary(d.unscaled != nil, d.unscaled, new(big.Int))
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Rescale returns d with scale digits after the decimal point. Extra
+// digits are truncated toward zero.
+func (d Decimal) Rescale(scale int) Decimal {
+	unscaled := new(big.Int).Set(d.int())
+	if diff := scale - d.scale; diff > 0 {
+		unscaled.Mul(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(diff)), nil))
+	} else if diff < 0 {
+		unscaled.Quo(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-diff)), nil))
+	}
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+// Cmp compares d and other, returning -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// Rat returns d as a rational number.
+func (d Decimal) Rat() *big.Rat {
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(d.int(), denom)
+}
+
+// String returns d with exactly Scale digits after the decimal point.
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		digits = strings.Repeat("0", max(0, d.scale+1-len(digits))) + digits
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	return lo.Ternary(d.int().Sign() < 0, "-", "") + digits
+}
+
+// MarshalText implements encoding.TextMarshaler, so JSON renders a Decimal
+// as a string and JavaScript clients keep every digit.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err == nil {
+		*d = v
+	}
+	return err
+}
+
+// Value implements driver.Valuer.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner for the string, []byte, integer and float
+// values returned by drivers for DECIMAL and NUMERIC columns.
+func (d *Decimal) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	case int64:
+		*d = Decimal{unscaled: big.NewInt(v)}
+		return nil
+	case float64:
+		return d.UnmarshalText([]byte(strconv.FormatFloat(v, 'f', -1, 64)))
+	default:
+		return fmt.Errorf("xql: cannot scan %T into Decimal", src)
+	}
+}
+
+// NumericField is the ViewField of a decimal number, see DecimalField.
+type NumericField struct {
+	name       string
+	required   bool
+	array      bool
+	precision  int
+	scale      int
+	validators []func(Decimal) error
+}
+
+var _ ViewField = (*NumericField)(nil)
+
+// DecimalField creates a required field holding a Decimal with at most
+// precision significant digits, scale of them after the decimal point, like
+// a SQL DECIMAL(precision, scale) column:
+//
+//	view.DecimalField("amount", 10, 2) // accepts 12.5 and "12.50", rejects 12.505
+//
+// JSON numbers are read from their literal text, never through float64,
+// and JSON strings holding a number are accepted as well. Values are
+// stored with exactly scale fractional digits; a value with more is
+// rejected rather than rounded.
+func DecimalField(name string, precision, scale int, validators ...func(Decimal) error) *NumericField {
+	if strings.ContainsAny(name, ".#") {
+		panic(fmt.Sprintf("xql: field name '%s' cannot contain '.' or '#'", name))
+	}
+	lo.Assertf(precision > 0 && scale >= 0 && scale <= precision,
+		"xql: invalid precision %d and scale %d for field '%s'", precision, scale, name)
+	return &NumericField{name: name, required: true, precision: precision, scale: scale, validators: validators}
+}
+
+// Optional marks the field as optional.
+func (f *NumericField) Optional() *NumericField {
+	f.required = false
+	return f
+}
+
+// AsArray accepts an array of decimals.
+func (f *NumericField) AsArray() *NumericField {
+	f.array = true
+	return f
+}
+
+func (f *NumericField) Scope() string         { return "" }
+func (f *NumericField) QualifiedName() string { return f.name }
+func (f *NumericField) Name() string          { return f.name }
+func (f *NumericField) UniqueName() string    { return f.name }
+func (f *NumericField) IsArray() bool         { return f.array }
+func (f *NumericField) IsObject() bool        { return false }
+func (f *NumericField) Required() bool        { return f.required }
+
+// parse parses s, checks precision and scale and runs the validators.
+func (f *NumericField) parse(s string) (Decimal, error) {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return d, fmt.Errorf("field '%s': %w: %w", f.name, validator.ErrTypeMismatch, err)
+	}
+	if d.scale > f.scale || len(new(big.Int).Abs(d.Rescale(f.scale).int()).String()) > f.precision {
+		return d, fmt.Errorf("field '%s': %w", f.name, &ruleError{
+			rule: validator.Rule{Name: "decimal", Params: map[string]any{"precision": f.precision, "scale": f.scale}},
+			err:  fmt.Errorf("%w: at most %d digits with %d decimals", ErrDecimalRange, f.precision, f.scale),
+		})
+	}
+	d = d.Rescale(f.scale)
+	for _, vfn := range f.validators {
+		if err = vfn(d); err != nil {
+			return d, fmt.Errorf("field '%s': %w", f.name, err)
+		}
+	}
+	return d, nil
+}
+
+// token returns the text of a JSON number or string node.
+func (f *NumericField) token(node gjson.Result) (string, error) {
+	switch node.Type {
+	case gjson.Number:
+		return node.Raw, nil
+	case gjson.String:
+		return node.Str, nil
+	default:
+		return "", fmt.Errorf("field '%s': %w: expected a decimal but got raw type %s", f.name, validator.ErrTypeMismatch, node.Type)
+	}
+}
+
+func (f *NumericField) validate(node gjson.Result, _ walkState) mo.Result[any] {
+	if !f.array {
+		s, err := f.token(node)
+		if err != nil {
+			return mo.Err[any](err)
+		}
+		return f.validateRaw(s)
+	}
+	if !node.IsArray() {
+		return mo.Err[any](fmt.Errorf("field '%s': expected an array", f.name))
+	}
+	errs := &validationError{}
+	values := make([]Decimal, 0, len(node.Array()))
+	for i, el := range node.Array() {
+		s, err := f.token(el)
+		if err == nil {
+			var d Decimal
+			d, err = f.parse(s)
+			values = append(values, d)
+		}
+		errs.add(fmt.Sprintf("%s[%d]", f.name, i), err)
+	}
+	return lo.Ternary(errs.err() != nil, mo.Err[any](errs.err()), mo.Ok[any](values))
+}
+
+func (f *NumericField) validateRaw(s string) mo.Result[any] {
+	d, err := f.parse(s)
+	return lo.Ternary(err != nil, mo.Err[any](err), mo.Ok[any](d))
+}
+
+func (f *NumericField) validateRawArray(vs []string) mo.Result[any] {
+	errs := &validationError{}
+	values := make([]Decimal, 0, len(vs))
+	for i, s := range vs {
+		d, err := f.parse(s)
+		errs.add(fmt.Sprintf("%s[%d]", f.name, i), err)
+		values = append(values, d)
+	}
+	return lo.Ternary(errs.err() != nil, mo.Err[any](errs.err()), mo.Ok[any](values))
+}
+
+func (f *NumericField) optional(map[*Schema]*Schema) ViewField {
+	cp := *f
+	cp.required = false
+	return &cp
+}
+
+func (f *NumericField) embeddedObject() mo.Option[*Schema] {
+	return mo.None[*Schema]()
+}
+
+// jsonSchema describes the string form Decimal is marshaled to; JSON
+// numbers are accepted on input too.
+func (f *NumericField) jsonSchema(map[*Schema]string) map[string]any {
+	pattern := fmt.Sprintf(`^-?[0-9]{1,%d}$`, max(1, f.precision-f.scale))
+	if f.scale > 0 {
+		pattern = fmt.Sprintf(`^-?[0-9]{1,%d}(\.[0-9]{1,%d})?$`, max(1, f.precision-f.scale), f.scale)
+	}
+	node := map[string]any{"type": "string", "format": "decimal", "pattern": pattern}
+	if f.array {
+		return map[string]any{"type": "array", "items": node}
+	}
+	return node
+}
+
+func (f *NumericField) storedType() reflect.Type {
+	return sliceIf(reflect.TypeFor[Decimal](), f.array)
+}
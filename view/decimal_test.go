@@ -0,0 +1,131 @@
+package view
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "0", want: "0"},
+		{in: "12.50", want: "12.50"},
+		{in: "-0.05", want: "-0.05"},
+		{in: "+3.", want: "3"},
+		{in: ".5", want: "0.5"},
+		{in: "1.5e3", want: "1500"},
+		{in: "15E-3", want: "0.015"},
+		{in: "123456789012345678901234567890.123456789", want: "123456789012345678901234567890.123456789"},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "--1", wantErr: true},
+		{in: "1-2", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "1e", wantErr: true},
+		{in: "1e100000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := ParseDecimal(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d.String())
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	d := MustParseDecimal("0.1")
+	require.Equal(t, "0.10", d.Rescale(2).String())
+	require.Equal(t, "-1.2", MustParseDecimal("-1.29").Rescale(1).String())
+	require.Zero(t, d.Cmp(MustParseDecimal("0.100")))
+	require.Equal(t, -1, d.Cmp(MustParseDecimal("0.2")))
+	require.Equal(t, "0", Decimal{}.String())
+
+	data, err := json.Marshal(map[string]Decimal{"amount": MustParseDecimal("12.50")})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":"12.50"}`, string(data))
+
+	v, err := d.Value()
+	require.NoError(t, err)
+	require.Equal(t, "0.1", v)
+	for _, src := range []any{"0.1", []byte("0.1"), 0.1} {
+		var scanned Decimal
+		require.NoError(t, scanned.Scan(src))
+		require.Zero(t, scanned.Cmp(d))
+	}
+	var scanned Decimal
+	require.NoError(t, scanned.Scan(int64(7)))
+	require.Equal(t, "7", scanned.String())
+	require.Error(t, scanned.Scan(true))
+}
+
+func TestDecimalField(t *testing.T) {
+	schema := WithFields(
+		DecimalField("amount", 6, 2, func(d Decimal) error {
+			if d.Rat().Sign() < 0 {
+				return validator.ErrNotOneOf
+			}
+			return nil
+		}),
+		DecimalField("rates", 3, 3).AsArray().Optional(),
+	)
+	tests := []struct {
+		name    string
+		json    string
+		want    string
+		wantErr error
+	}{
+		{name: "number", json: `{"amount":12.5}`, want: "12.50"},
+		{name: "string", json: `{"amount":"1234.56"}`, want: "1234.56"},
+		{name: "no float drift", json: `{"amount":0.29}`, want: "0.29"},
+		{name: "array", json: `{"amount":1,"rates":[0.125,"0.5"]}`, want: "1.00"},
+		{name: "too many decimals", json: `{"amount":12.505}`, wantErr: ErrDecimalRange},
+		{name: "too many digits", json: `{"amount":12345.6}`, wantErr: ErrDecimalRange},
+		{name: "array element out of range", json: `{"amount":1,"rates":[1.5]}`, wantErr: ErrDecimalRange},
+		{name: "not a number", json: `{"amount":"12,50"}`, wantErr: validator.ErrTypeMismatch},
+		{name: "bool", json: `{"amount":true}`, wantErr: validator.ErrTypeMismatch},
+		{name: "validator", json: `{"amount":-1}`, wantErr: validator.ErrNotOneOf},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := schema.Validate(tt.json)
+			if tt.wantErr != nil {
+				require.Error(t, res.Error())
+				require.ErrorIs(t, firstError(t, res.Error()), tt.wantErr)
+				return
+			}
+			require.NoError(t, res.Error())
+			require.Equal(t, tt.want, Value[Decimal](res.MustGet(), "amount").MustGet().String())
+		})
+	}
+
+	t.Run("form", func(t *testing.T) {
+		res := schema.ValidateForm(url.Values{"amount": {"9.9"}, "rates": {"0.1", "0.2"}})
+		require.NoError(t, res.Error())
+		rates := Value[[]Decimal](res.MustGet(), "rates").MustGet()
+		require.Equal(t, "0.100", rates[0].String())
+		data, err := json.Marshal(res.MustGet())
+		require.NoError(t, err)
+		require.JSONEq(t, `{"amount":"9.90","rates":["0.100","0.200"]}`, string(data))
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		require.Equal(t, map[string]any{"type": "string", "format": "decimal", "pattern": `^-?[0-9]{1,4}(\.[0-9]{1,2})?$`},
+			DecimalField("amount", 6, 2).jsonSchema(nil))
+		require.Equal(t, `^-?[0-9]{1,5}$`, DecimalField("count", 5, 0).jsonSchema(nil)["pattern"])
+	})
+
+	require.Panics(t, func() { DecimalField("amount", 2, 3) })
+}
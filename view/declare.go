@@ -0,0 +1,353 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaDecl is the declarative form of a Schema, see ParseSchema.
+type SchemaDecl struct {
+	AllowUnknownFields bool        `json:"allowUnknownFields" yaml:"allowUnknownFields"`
+	Fields             []FieldDecl `json:"fields" yaml:"fields"`
+}
+
+// FieldDecl declares a field of a SchemaDecl.
+type FieldDecl struct {
+	Name string `json:"name" yaml:"name"`
+	// Type is one of string, bool, int, int8, int16, int32, int64, uint,
+	// uint8, uint16, uint32, uint64, float32, float64, time or object.
+	Type string `json:"type" yaml:"type"`
+	// Required defaults to true.
+	Required *bool `json:"required,omitempty" yaml:"required,omitempty"`
+	Array    bool  `json:"array,omitempty" yaml:"array,omitempty"`
+	Nullable bool  `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	// MinItems and MaxItems bound the length of array fields.
+	MinItems int `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems int `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	// Fields declares the nested fields of an object field.
+	Fields     []FieldDecl     `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Validators []ValidatorDecl `json:"validators,omitempty" yaml:"validators,omitempty"`
+}
+
+// ValidatorDecl declares a validator by its name, as reported by
+// validator.Describe, and its arguments in constructor order, e.g.
+// {name: length_between, args: [2, 10]}. Character set validators take the
+// set names lower, upper, number and special.
+type ValidatorDecl struct {
+	Name string `json:"name" yaml:"name"`
+	Args []any  `json:"args,omitempty" yaml:"args,omitempty"`
+}
+
+// ParseSchema builds a Schema from a YAML or JSON document, so schema
+// definitions can live in configuration or be shared with other services:
+//
+//	allowUnknownFields: false
+//	fields:
+//	  - name: email
+//	    type: string
+//	    validators:
+//	      - name: email
+//	  - name: age
+//	    type: int
+//	    required: false
+//	    validators:
+//	      - {name: between, args: [18, 99]}
+//	  - name: address
+//	    type: object
+//	    fields:
+//	      - {name: city, type: string}
+//
+// Every problem in the document is reported with the path of the offending
+// field.
+func ParseSchema(data []byte) (*Schema, error) {
+	var decl SchemaDecl
+	if err := yaml.Unmarshal(data, &decl); err != nil {
+		return nil, fmt.Errorf("view: parse schema: %w", err)
+	}
+	return decl.Schema()
+}
+
+// Schema builds the Schema declared by d.
+func (d SchemaDecl) Schema() (*Schema, error) {
+	schema, err := declSchema("", d.Fields)
+	if err != nil {
+		return nil, err
+	}
+	if d.AllowUnknownFields {
+		schema.AllowUnknownFields()
+	}
+	return schema, nil
+}
+
+func declSchema(prefix string, decls []FieldDecl) (schema *Schema, err error) {
+	where := "root schema"
+	if prefix != "" {
+		where = fmt.Sprintf("field '%s'", prefix)
+	}
+	if len(decls) == 0 {
+		return nil, fmt.Errorf("view: %s declares no fields", where)
+	}
+	var errs []error
+	fields := make([]ViewField, 0, len(decls))
+	for _, decl := range decls {
+		field, err := decl.field(prefix)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fields = append(fields, field)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	defer func() {
+		// WithFields panics on duplicated names
+		if r := recover(); r != nil {
+			schema, err = nil, fmt.Errorf("view: %s: %v", where, r)
+		}
+	}()
+	return WithFields(fields...), nil
+}
+
+func (d FieldDecl) field(prefix string) (field ViewField, err error) {
+	path := joinPath(prefix, d.Name)
+	defer func() {
+		// field constructors panic on invalid names or duplicated validators
+		if r := recover(); r != nil {
+			field, err = nil, fmt.Errorf("view: field '%s': %v", path, r)
+		}
+	}()
+	if d.Name == "" {
+		return nil, fmt.Errorf("view: field without name in '%s'", prefix)
+	}
+	if d.Type == "object" {
+		if len(d.Validators) > 0 {
+			return nil, fmt.Errorf("view: field '%s': object fields take no validators", path)
+		}
+		nested, err := declSchema(path, d.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return declOptions(d, trait[string](d.Name, d.Array, true, nested)), nil
+	}
+	if len(d.Fields) > 0 {
+		return nil, fmt.Errorf("view: field '%s': only object fields declare fields", path)
+	}
+	build, ok := declTypes[d.Type]
+	if !ok {
+		return nil, fmt.Errorf("view: field '%s': unknown type '%s'", path, d.Type)
+	}
+	if field, err = build(d); err != nil {
+		return nil, fmt.Errorf("view: field '%s': %w", path, err)
+	}
+	return field, nil
+}
+
+// declOptions applies the options of d shared by every field type to f.
+func declOptions[T validator.FieldType](d FieldDecl, f *JSONField[T]) *JSONField[T] {
+	if d.Required != nil && !*d.Required {
+		f.Optional()
+	}
+	if d.Nullable {
+		f.Nullable()
+	}
+	if d.MinItems > 0 {
+		f.MinItems(d.MinItems)
+	}
+	if d.MaxItems > 0 {
+		f.MaxItems(d.MaxItems)
+	}
+	return f
+}
+
+// validatorLookup resolves a declared validator for T.
+type validatorLookup[T validator.FieldType] func(v ValidatorDecl) (validator.ValidateFunc[T], error)
+
+// declTypes builds a field of each declarable type.
+var declTypes = map[string]func(FieldDecl) (ViewField, error){
+	"string":  declField(stringValidators),
+	"bool":    declField(boolValidators),
+	"int":     declField(orderedValidators[int]),
+	"int8":    declField(orderedValidators[int8]),
+	"int16":   declField(orderedValidators[int16]),
+	"int32":   declField(orderedValidators[int32]),
+	"int64":   declField(orderedValidators[int64]),
+	"uint":    declField(orderedValidators[uint]),
+	"uint8":   declField(orderedValidators[uint8]),
+	"uint16":  declField(orderedValidators[uint16]),
+	"uint32":  declField(orderedValidators[uint32]),
+	"uint64":  declField(orderedValidators[uint64]),
+	"float32": declField(orderedValidators[float32]),
+	"float64": declField(orderedValidators[float64]),
+	"time":    declField(orderedValidators[time.Time]),
+}
+
+func declField[T validator.FieldType](lookup validatorLookup[T]) func(FieldDecl) (ViewField, error) {
+	return func(d FieldDecl) (ViewField, error) {
+		vfs := make([]validator.ValidateFunc[T], 0, len(d.Validators))
+		for _, v := range d.Validators {
+			vf, err := lookup(v)
+			if err != nil {
+				return nil, err
+			}
+			vfs = append(vfs, vf)
+		}
+		return declOptions(d, trait[T](d.Name, d.Array, false, nil, vfs...)), nil
+	}
+}
+
+// declArg converts a declared argument to T.
+func declArg[T validator.FieldType](v any) (T, error) {
+	if t, ok := v.(T); ok {
+		return t, nil
+	}
+	return validator.ParseStringTo[T](fmt.Sprint(v))
+}
+
+// declArgs converts the arguments of v to T, requiring n of them, or at
+// least one when n is negative.
+func declArgs[T validator.FieldType](v ValidatorDecl, n int) ([]T, error) {
+	if (n >= 0 && len(v.Args) != n) || (n < 0 && len(v.Args) == 0) {
+		want := fmt.Sprint(n)
+		if n < 0 {
+			want = "at least one"
+		}
+		return nil, fmt.Errorf("validator '%s' takes %s argument(s), got %d", v.Name, want, len(v.Args))
+	}
+	out := make([]T, 0, len(v.Args))
+	for i, arg := range v.Args {
+		t, err := declArg[T](arg)
+		if err != nil {
+			return nil, fmt.Errorf("validator '%s' argument %d: %w", v.Name, i+1, err)
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func unknownValidator(v ValidatorDecl, typ string) error {
+	return fmt.Errorf("unknown validator '%s' for type %s", v.Name, typ)
+}
+
+func stringValidators(v ValidatorDecl) (validator.ValidateFunc[string], error) {
+	switch v.Name {
+	case "min_length", "max_length", "exact_length", "length_between":
+		args, err := declArgs[int](v, lo.Ternary(v.Name == "length_between", 2, 1))
+		if err != nil {
+			return nil, err
+		}
+		switch v.Name {
+		case "min_length":
+			return validator.MinLength(args[0]), nil
+		case "max_length":
+			return validator.MaxLength(args[0]), nil
+		case "exact_length":
+			return validator.ExactLength(args[0]), nil
+		default:
+			return validator.LengthBetween(args[0], args[1]), nil
+		}
+	case "only_contains", "contains_any", "contains_all", "not_contains":
+		names, err := declArgs[string](v, -1)
+		if err != nil {
+			return nil, err
+		}
+		sets, err := charSets(names, validator.LowerCaseChar, validator.UpperCaseChar, validator.NumberChar, validator.SpecialChar)
+		if err != nil {
+			return nil, fmt.Errorf("validator '%s': %w", v.Name, err)
+		}
+		switch v.Name {
+		case "only_contains":
+			return validator.CharSetOnly(sets...), nil
+		case "contains_any":
+			return validator.CharSetAny(sets...), nil
+		case "contains_all":
+			return validator.CharSetAll(sets...), nil
+		default:
+			return validator.CharSetNo(sets...), nil
+		}
+	case "match":
+		args, err := declArgs[string](v, 1)
+		if err != nil {
+			return nil, err
+		}
+		return validator.Match(args[0]), nil
+	case "email", "url":
+		if _, err := declArgs[string](v, 0); err != nil {
+			return nil, err
+		}
+		return lo.Ternary(v.Name == "email", validator.Email(), validator.URL()), nil
+	case "one_of":
+		args, err := declArgs[string](v, -1)
+		if err != nil {
+			return nil, err
+		}
+		return validator.OneOf(args...), nil
+	}
+	return nil, unknownValidator(v, "string")
+}
+
+// charSets resolves character set names to the validator character sets,
+// passed in as lower, upper, number and special.
+func charSets[C any](names []string, lower, upper, number, special C) ([]C, error) {
+	sets := make([]C, 0, len(names))
+	for _, name := range names {
+		set, ok := map[string]C{"lower": lower, "upper": upper, "number": number, "special": special}[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown character set '%s'", name)
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+func boolValidators(v ValidatorDecl) (validator.ValidateFunc[bool], error) {
+	switch v.Name {
+	case "be_true", "be_false":
+		if _, err := declArgs[bool](v, 0); err != nil {
+			return nil, err
+		}
+		return lo.Ternary(v.Name == "be_true", validator.BeTrue(), validator.BeFalse()), nil
+	case "one_of":
+		args, err := declArgs[bool](v, -1)
+		if err != nil {
+			return nil, err
+		}
+		return validator.OneOf(args...), nil
+	}
+	return nil, unknownValidator(v, "bool")
+}
+
+func orderedValidators[T validator.Number | time.Time](v ValidatorDecl) (validator.ValidateFunc[T], error) {
+	switch v.Name {
+	case "gt", "gte", "lt", "lte":
+		args, err := declArgs[T](v, 1)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]func(T) validator.ValidateFunc[T]{
+			"gt":  validator.Gt[T],
+			"gte": validator.Gte[T],
+			"lt":  validator.Lt[T],
+			"lte": validator.Lte[T],
+		}[v.Name](args[0]), nil
+	case "between":
+		args, err := declArgs[T](v, 2)
+		if err != nil {
+			return nil, err
+		}
+		return validator.Between(args[0], args[1]), nil
+	case "one_of":
+		args, err := declArgs[T](v, -1)
+		if err != nil {
+			return nil, err
+		}
+		return validator.OneOf(args...), nil
+	}
+	var zero T
+	return nil, unknownValidator(v, fmt.Sprintf("%T", zero))
+}
@@ -0,0 +1,104 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchema(t *testing.T) {
+	doc := `
+fields:
+  - name: email
+    type: string
+    validators:
+      - name: email
+  - name: password
+    type: string
+    validators:
+      - {name: length_between, args: [8, 32]}
+      - {name: contains_all, args: [upper, number]}
+  - name: age
+    type: int
+    required: false
+    validators:
+      - {name: between, args: [18, 99]}
+  - name: since
+    type: time
+    required: false
+    validators:
+      - {name: gte, args: ["2020-01-01"]}
+  - name: tags
+    type: string
+    array: true
+    required: false
+    validators:
+      - {name: one_of, args: [go, sql]}
+  - name: nickname
+    type: string
+    nullable: true
+    required: false
+  - name: address
+    type: object
+    fields:
+      - {name: city, type: string}
+      - {name: zip, type: uint32}
+`
+	schema, err := ParseSchema([]byte(doc))
+	require.NoError(t, err)
+
+	res := schema.Validate(`{"email":"a@x.io","password":"Secret123","age":30,"since":"2021-05-01","tags":["go"],"nickname":null,"address":{"city":"Paris","zip":75001}}`)
+	require.NoError(t, res.Error())
+	vo := res.MustGet()
+	require.Equal(t, 30, vo.MstInt("age"))
+	require.Equal(t, time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), vo.MstTime("since"))
+	require.Equal(t, uint32(75001), vo.Get("address.zip").MustGet())
+	require.True(t, vo.IsNull("nickname"))
+
+	invalid := []string{
+		`{"email":"nope","password":"Secret123","address":{"city":"a","zip":1}}`,
+		`{"email":"a@x.io","password":"secret123","address":{"city":"a","zip":1}}`,
+		`{"email":"a@x.io","password":"Secret123","age":17,"address":{"city":"a","zip":1}}`,
+		`{"email":"a@x.io","password":"Secret123","since":"2019-01-01","address":{"city":"a","zip":1}}`,
+		`{"email":"a@x.io","password":"Secret123","tags":["js"],"address":{"city":"a","zip":1}}`,
+		`{"email":"a@x.io","password":"Secret123","address":{"zip":1}}`,
+		`{"email":"a@x.io","password":"Secret123","address":{"city":"a","zip":1},"extra":1}`,
+	}
+	for _, payload := range invalid {
+		require.Error(t, schema.Validate(payload).Error(), payload)
+	}
+
+	t.Run("json document", func(t *testing.T) {
+		schema, err := ParseSchema([]byte(`{"allowUnknownFields":true,"fields":[{"name":"id","type":"int64","validators":[{"name":"gt","args":[0]}]}]}`))
+		require.NoError(t, err)
+		require.NoError(t, schema.Validate(`{"id":1,"extra":true}`).Error())
+		require.Error(t, schema.Validate(`{"id":0}`).Error())
+	})
+}
+
+func TestParseSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{name: "syntax", doc: `fields: [`, want: "parse schema"},
+		{name: "no fields", doc: `fields: []`, want: "root schema declares no fields"},
+		{name: "unknown type", doc: `fields: [{name: a, type: uuid}]`, want: "field 'a': unknown type 'uuid'"},
+		{name: "unknown validator", doc: `fields: [{name: a, type: int, validators: [{name: email}]}]`, want: "unknown validator 'email' for type int"},
+		{name: "argument count", doc: `fields: [{name: a, type: string, validators: [{name: min_length}]}]`, want: "takes 1 argument(s), got 0"},
+		{name: "argument type", doc: `fields: [{name: a, type: int, validators: [{name: gt, args: [x]}]}]`, want: "validator 'gt' argument 1"},
+		{name: "char set", doc: `fields: [{name: a, type: string, validators: [{name: contains_any, args: [emoji]}]}]`, want: "unknown character set 'emoji'"},
+		{name: "nested path", doc: `fields: [{name: o, type: object, fields: [{name: b, type: nope}]}]`, want: "field 'o.b': unknown type 'nope'"},
+		{name: "empty object", doc: `fields: [{name: o, type: object}]`, want: "field 'o' declares no fields"},
+		{name: "duplicated field", doc: `fields: [{name: a, type: int}, {name: a, type: int}]`, want: "duplicate field name 'a'"},
+		{name: "invalid name", doc: `fields: [{name: a.b, type: int}]`, want: "cannot contain"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseSchema([]byte(tc.doc))
+			require.ErrorContains(t, err, tc.want)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// Deprecated marks a field clients should stop sending. Validation still
+// accepts it, but a ValueObject holding it reports a warning with message,
+// see ValueObject.Warnings, so APIs can emit Deprecation or Sunset notices
+// while old clients migrate:
+//
+//	Field[string]("account_id").Deprecated("use customer_id instead").Optional()
+//
+// JSON Schema exports the field with `"deprecated": true`.
+func (f *JSONField[T]) Deprecated(message string) *JSONField[T] {
+	lo.Assertf(message != "", "xql: Deprecated requires a message for field '%s'", f.Name())
+	f.deprecated = message
+	return f
+}
+
+func (f *JSONField[T]) deprecation() string {
+	return f.deprecated
+}
+
+// Warnings returns the warnings of the validation that produced vo, such as
+// the use of Deprecated fields, keyed by field path like
+// ValidationError.Errors, or nil when there are none.
+func (vo valueObject) Warnings() map[string]string {
+	return vo.warnings
+}
+
+// deprecationOf returns the Deprecated message of field, "" if it is not
+// deprecated.
+func deprecationOf(field ViewField) string {
+	if df, ok := field.(interface{ deprecation() string }); ok {
+		return df.deprecation()
+	}
+	return ""
+}
+
+// addWarnings adds to warnings those of the value val validated for
+// field: the field's deprecation and the warnings of embedded objects.
+func addWarnings(warnings map[string]string, field ViewField, val any) map[string]string {
+	add := func(path, message string) {
+		if warnings == nil {
+			warnings = map[string]string{}
+		}
+		warnings[path] = message
+	}
+	if message := deprecationOf(field); message != "" {
+		add(field.Name(), fmt.Sprintf("field '%s' is deprecated: %s", field.Name(), message))
+	}
+	switch v := val.(type) {
+	case valueObject:
+		for path, message := range v.warnings {
+			add(field.Name()+"."+path, message)
+		}
+	case []ValueObject:
+		for i, item := range v {
+			for path, message := range item.(valueObject).warnings {
+				add(fmt.Sprintf("%s[%d].%s", field.Name(), i, path), message)
+			}
+		}
+	}
+	return warnings
+}
+
+// styledWarnings renders the warning paths of a validation result in the
+// schema's PathStyle.
+func (s *Schema) styledWarnings(res mo.Result[ValueObject]) mo.Result[ValueObject] {
+	vo, err := res.Get()
+	if err != nil || s.pathStyle == PathBracket {
+		return res
+	}
+	v, isVO := vo.(valueObject)
+	if !isVO || v.warnings == nil {
+		return res
+	}
+	v.warnings = lo.MapKeys(v.warnings, func(_ string, path string) string {
+		return s.pathStyle.render(path)
+	})
+	return mo.Ok[ValueObject](v)
+}
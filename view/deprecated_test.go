@@ -0,0 +1,74 @@
+package view
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONField_Deprecated(t *testing.T) {
+	line := WithFields(
+		Field[string]("sku"),
+		Field[string]("code").Deprecated("use sku instead").Optional(),
+	)
+	schema := WithFields(
+		Field[string]("customer_id").Optional(),
+		Field[string]("account_id").Deprecated("use customer_id instead").Optional(),
+		ObjectField("main", line).Optional(),
+		ArrayOfObjectField("lines", line).Optional(),
+	)
+	tests := []struct {
+		name string
+		json string
+		want map[string]string
+	}{
+		{name: "none", json: `{"customer_id":"c"}`},
+		{
+			name: "deprecated field",
+			json: `{"account_id":"a"}`,
+			want: map[string]string{"account_id": "field 'account_id' is deprecated: use customer_id instead"},
+		},
+		{
+			name: "nested",
+			json: `{"main":{"sku":"s","code":"c"},"lines":[{"sku":"s"},{"sku":"t","code":"c"}]}`,
+			want: map[string]string{
+				"main.code":     "field 'code' is deprecated: use sku instead",
+				"lines[1].code": "field 'code' is deprecated: use sku instead",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := schema.Validate(tt.json)
+			require.NoError(t, res.Error())
+			require.Equal(t, tt.want, res.MustGet().Warnings())
+		})
+	}
+
+	t.Run("url parameter and form", func(t *testing.T) {
+		res := schema.Validate(`{}`, map[string]string{"account_id": "a"})
+		require.Contains(t, res.MustGet().Warnings(), "account_id")
+		res = schema.ValidateForm(url.Values{"account_id": {"a"}})
+		require.Contains(t, res.MustGet().Warnings(), "account_id")
+	})
+
+	t.Run("path style", func(t *testing.T) {
+		s := WithFields(ArrayOfObjectField("lines", line)).ErrorPaths(PathPointer)
+		res := s.Validate(`{"lines":[{"sku":"s","code":"c"}]}`)
+		require.Equal(t, map[string]string{"/lines/0/code": "field 'code' is deprecated: use sku instead"}, res.MustGet().Warnings())
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		data, err := schema.JSONSchema()
+		require.NoError(t, err)
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(data, &doc))
+		props := doc["properties"].(map[string]any)
+		require.Equal(t, true, props["account_id"].(map[string]any)["deprecated"])
+		require.NotContains(t, props["customer_id"], "deprecated")
+	})
+
+	require.Panics(t, func() { Field[string]("a").Deprecated("") })
+}
@@ -4,12 +4,12 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/url"
 	"sync"
 
 	"github.com/kcmvp/xql/internal"
 	"github.com/kcmvp/xql/view"
 	"github.com/labstack/echo/v4"
-	"github.com/samber/lo"
 	"github.com/samber/mo"
 )
 
@@ -34,19 +34,15 @@ func SetGlobalEnricher(enrich EnrichFunc) {
 	})
 }
 
-func urlParams(ctx echo.Context) map[string]string {
-	params := lo.Associate(ctx.ParamNames(), func(name string) (string, string) {
-		return name, ctx.Param(name)
-	})
-	// Add query parameters
-	for name, values := range ctx.QueryParams() {
-		lo.Assertf(len(values) == 1, "query parameter '%s' has multiple values, which is not supported", name)
-		_, ok := params[name]
-		lo.Assertf(!ok, "path parameter and query parameter have conflicting names: '%s'", name)
-		params[name] = values[0]
+// urlParams returns the path parameters and the query string of ctx. A
+// repeated query parameter feeds an ArrayField, and a name supplied by both
+// is reported as a conflict, see view.Schema.ValidateValues.
+func urlParams(ctx echo.Context) []url.Values {
+	path := url.Values{}
+	for _, name := range ctx.ParamNames() {
+		path.Set(name, ctx.Param(name))
 	}
-	return params
-
+	return []url.Values{path, ctx.QueryParams()}
 }
 
 // Bind returns an Echo middleware function that validates incoming JSON request bodies
@@ -65,13 +61,11 @@ func Bind(schema *view.Schema) echo.MiddlewareFunc {
 			}
 			body := string(btsResult.MustGet())
 			// validate the JSON body against the Schema schema.
-			result := schema.Validate(body, urlParams(c))
+			result := schema.ValidateValues(body, urlParams(c)...)
 			if result.IsError() {
-				// If validation fails, return a 400 Bad Request with a structured error message
-				// containing the details of the validation failures.
-				return c.JSON(http.StatusBadRequest, map[string]string{
-					"error": result.Error().Error(),
-				})
+				// If validation fails, return a 400 Bad Request listing the message and
+				// code of every invalid field.
+				return c.JSON(http.StatusBadRequest, view.ErrorBody(result.Error()))
 			}
 			data := result.MustGet()
 
@@ -31,6 +31,7 @@ var orderVO = view.WithFields(
 	view.Field[time.Time]("registered_date").Optional(),     // From query parameter
 	view.Field[bool]("received").Optional(),                 // From query parameter
 	view.Field[float64]("minim_price").Optional(),           // From query parameter
+	view.ArrayField[string]("tags").Optional(),              // From repeated query parameters
 )
 
 type MiddlewareTestSuite struct {
@@ -148,7 +149,7 @@ func (suite *MiddlewareTestSuite) TestBindingWithParamsAndEnricher() {
 		inputFile      string
 		expectedStatus int
 		expectedValues map[string]any // Expected values from URL params to merge into the final JSON
-		shouldPanic    bool
+		invalidField   string         // The field reported by a 400 response
 	}{
 		{
 			name:           "Valid request with basic path and query parameters",
@@ -172,10 +173,25 @@ func (suite *MiddlewareTestSuite) TestBindingWithParamsAndEnricher() {
 			},
 		},
 		{
-			name:        "Request with multiple values for a query parameter should panic",
-			url:         "/enriched_orders/order-fail-case?source=web&source=api",
-			inputFile:   "testdata/valid_order_optional.json",
-			shouldPanic: true,
+			name:           "Repeated query parameter feeds an array field",
+			url:            "/enriched_orders/order-abc-123?tags=a&tags=b",
+			inputFile:      "testdata/valid_order_optional.json",
+			expectedStatus: http.StatusOK,
+			expectedValues: map[string]any{"ordId": "order-abc-123", "tags": []string{"a", "b"}},
+		},
+		{
+			name:           "Repeated query parameter for a single value field is rejected",
+			url:            "/enriched_orders/order-fail-case?source=web&source=api",
+			inputFile:      "testdata/valid_order_optional.json",
+			expectedStatus: http.StatusBadRequest,
+			invalidField:   "source",
+		},
+		{
+			name:           "Path and query parameters with the same name conflict",
+			url:            "/enriched_orders/order-abc-123?ordId=other",
+			inputFile:      "testdata/valid_order_optional.json",
+			expectedStatus: http.StatusBadRequest,
+			invalidField:   "ordId",
 		},
 	}
 
@@ -188,13 +204,16 @@ func (suite *MiddlewareTestSuite) TestBindingWithParamsAndEnricher() {
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 
-			if tc.shouldPanic {
-				assert.Panics(suite.T(), func() { suite.srv.ServeHTTP(rec, req) })
-				return
-			}
-
 			suite.srv.ServeHTTP(rec, req)
 			require.Equalf(suite.T(), tc.expectedStatus, rec.Code, rec.Body.String())
+			if tc.expectedStatus != http.StatusOK {
+				// the response lists the message and code of every invalid field
+				var body map[string]map[string]string
+				require.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Contains(suite.T(), body["errors"], tc.invalidField)
+				assert.Contains(suite.T(), body["codes"], tc.invalidField)
+				return
+			}
 
 			var expectedMap map[string]any
 			err = json.Unmarshal(payloadBytes, &expectedMap)
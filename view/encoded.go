@@ -0,0 +1,75 @@
+package view
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// StringEncoded accepts an int64 or uint64 value as a JSON string holding
+// the number, as sent by JavaScript clients that would lose precision
+// above 2^53, besides a JSON number. Symmetrically, marshaling the
+// ValueObject renders the value as a JSON string:
+//
+//	view.Field[int64]("id").StringEncoded() // accepts {"id":"9007199254740993"}
+//
+// The value is stored as the number, so getters and persistence are not
+// affected.
+func (f *JSONField[T]) StringEncoded() *JSONField[T] {
+	switch any(*new(T)).(type) {
+	case int64, uint64:
+	default:
+		lo.Assertf(false, "xql: StringEncoded is only supported by int64 and uint64 fields, field '%s'", f.Name())
+	}
+	f.stringEncoded = true
+	return f
+}
+
+func (f *JSONField[T]) isStringEncoded() bool {
+	return f.stringEncoded
+}
+
+// encodedPaths returns the storage keys of the StringEncoded fields of s.
+func (s *Schema) encodedPaths() []string {
+	var paths []string
+	for _, field := range s.fields {
+		if ef, ok := field.(interface{ isStringEncoded() bool }); ok && ef.isStringEncoded() {
+			paths = append(paths, field.UniqueName())
+		}
+	}
+	return paths
+}
+
+// encodeNumbers renders the values at the StringEncoded keys of vo as strings.
+func (vo valueObject) encodeNumbers() valueObject {
+	data := vo.Data
+	for _, path := range vo.encoded {
+		data = rewrite(data, strings.Split(path, "."), func(v any) (any, bool) {
+			switch v := v.(type) {
+			case int64:
+				return strconv.FormatInt(v, 10), true
+			case uint64:
+				return strconv.FormatUint(v, 10), true
+			case []int64:
+				return lo.Map(v, func(n int64, _ int) string { return strconv.FormatInt(n, 10) }), true
+			case []uint64:
+				return lo.Map(v, func(n uint64, _ int) string { return strconv.FormatUint(n, 10) }), true
+			default:
+				return v, true
+			}
+		})
+	}
+	return valueObject{Data: data}
+}
+
+// MarshalJSON ensures the valueObject is serialized as the underlying map
+// (i.e. the embedded Data) instead of as a struct with a "Data" field.
+// StringEncoded fields are rendered as JSON strings.
+func (vo valueObject) MarshalJSON() ([]byte, error) {
+	if len(vo.encoded) > 0 {
+		return json.Marshal(vo.encodeNumbers().Data)
+	}
+	return json.Marshal(vo.Data)
+}
@@ -0,0 +1,75 @@
+package view
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONField_StringEncoded(t *testing.T) {
+	item := WithFields(Field[uint64]("sku").StringEncoded())
+	schema := WithFields(
+		Field[int64]("id").StringEncoded(),
+		ArrayField[int64]("refs").StringEncoded().Optional(),
+		Field[int64]("count").Optional(),
+		ObjectField("item", item).Optional(),
+	).StrictNumbers()
+	tests := []struct {
+		name    string
+		json    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "quoted numbers",
+			json: `{"id":"9007199254740993","refs":["-1","2"],"item":{"sku":"18446744073709551615"}}`,
+			want: `{"id":"9007199254740993","refs":["-1","2"],"item":{"sku":"18446744073709551615"}}`,
+		},
+		{
+			name: "plain numbers",
+			json: `{"id":42,"refs":[1],"count":7}`,
+			want: `{"id":"42","refs":["1"],"count":7}`,
+		},
+		{name: "not a number", json: `{"id":"4x2"}`, wantErr: validator.ErrTypeMismatch},
+		{name: "overflow", json: `{"id":"1","item":{"sku":"-1"}}`},
+		{name: "other fields stay strict", json: `{"id":"1","count":"7"}`, wantErr: validator.ErrTypeMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := schema.Validate(tt.json)
+			if tt.want == "" {
+				require.Error(t, res.Error())
+				if tt.wantErr != nil {
+					require.ErrorIs(t, firstError(t, res.Error()), tt.wantErr)
+				}
+				return
+			}
+			require.NoError(t, res.Error())
+			vo := res.MustGet()
+			data, err := json.Marshal(vo)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, string(data))
+			_, isInt := vo.Get("id").MustGet().(int64)
+			require.True(t, isInt)
+		})
+	}
+
+	t.Run("output and merge keep encoding", func(t *testing.T) {
+		vo := schema.Validate(`{"id":5}`).MustGet()
+		for _, v := range []ValueObject{schema.Output(vo), vo.Merge(nil), vo.Merge(vo), vo.Sanitized()} {
+			data, err := json.Marshal(v)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"id":"5"}`, string(data))
+		}
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		require.Equal(t, map[string]any{"type": "string", "format": "int64", "pattern": `^-?[0-9]+$`},
+			Field[int64]("id").StringEncoded().jsonSchema(nil))
+		require.Equal(t, "uint64", Field[uint64]("id").StringEncoded().jsonSchema(nil)["format"])
+	})
+
+	require.Panics(t, func() { Field[int]("id").StringEncoded() })
+}
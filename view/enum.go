@@ -0,0 +1,169 @@
+package view
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+)
+
+// ChoiceField is the ViewField of an enumeration, see EnumField.
+type ChoiceField[T ~string | ~int] struct {
+	name     string
+	required bool
+	array    bool
+	allowed  []T
+}
+
+var _ ViewField = (*ChoiceField[string])(nil)
+
+// EnumField creates a required field accepting only the allowed values of
+// the enumeration T. The value is stored as T, so it reads back typed with
+// Value:
+//
+//	type Status string
+//	const (Active Status = "active"; Closed Status = "closed")
+//
+//	schema := view.WithFields(view.EnumField("status", Active, Closed))
+//	status := view.Value[Status](vo, "status")
+//
+// String enumerations take JSON strings and integer ones JSON integers. The
+// allowed values are exported as the JSON Schema `enum` keyword.
+func EnumField[T ~string | ~int](name string, allowed ...T) *ChoiceField[T] {
+	if strings.ContainsAny(name, ".#") {
+		panic(fmt.Sprintf("xql: field name '%s' cannot contain '.' or '#'", name))
+	}
+	lo.Assertf(len(allowed) > 0, "xql: EnumField '%s' requires at least one allowed value", name)
+	return &ChoiceField[T]{name: name, required: true, allowed: allowed}
+}
+
+// Optional marks the field as optional.
+func (f *ChoiceField[T]) Optional() *ChoiceField[T] {
+	f.required = false
+	return f
+}
+
+// AsArray accepts an array of T.
+func (f *ChoiceField[T]) AsArray() *ChoiceField[T] {
+	f.array = true
+	return f
+}
+
+// Allowed returns the allowed values, in declaration order.
+func (f *ChoiceField[T]) Allowed() []T {
+	return append([]T(nil), f.allowed...)
+}
+
+func (f *ChoiceField[T]) Scope() string         { return "" }
+func (f *ChoiceField[T]) QualifiedName() string { return f.name }
+func (f *ChoiceField[T]) Name() string          { return f.name }
+func (f *ChoiceField[T]) UniqueName() string    { return f.name }
+func (f *ChoiceField[T]) IsArray() bool         { return f.array }
+func (f *ChoiceField[T]) IsObject() bool        { return false }
+func (f *ChoiceField[T]) Required() bool        { return f.required }
+
+// numeric reports whether T is an integer enumeration.
+func (f *ChoiceField[T]) numeric() bool {
+	return reflect.TypeFor[T]().Kind() == reflect.Int
+}
+
+// parse converts s to T and checks it is allowed.
+func (f *ChoiceField[T]) parse(s string) (T, error) {
+	var v T
+	if f.numeric() {
+		n, err := strconv.ParseInt(s, 10, strconv.IntSize)
+		if err != nil {
+			return v, fmt.Errorf("field '%s': could not parse '%s' as int: %w", f.name, s, err)
+		}
+		reflect.ValueOf(&v).Elem().SetInt(n)
+	} else {
+		reflect.ValueOf(&v).Elem().SetString(s)
+	}
+	if !lo.Contains(f.allowed, v) {
+		return v, fmt.Errorf("field '%s': %w", f.name, &ruleError{
+			rule: validator.Rule{Name: "one_of", Params: map[string]any{"values": f.allowed}},
+			err:  fmt.Errorf("%w:%v", validator.ErrNotOneOf, f.allowed),
+		})
+	}
+	return v, nil
+}
+
+// token returns the string of a JSON node of the enumeration's JSON type.
+func (f *ChoiceField[T]) token(node gjson.Result) (string, error) {
+	if f.numeric() && node.Type == gjson.Number && !strings.ContainsAny(node.Raw, ".eE") {
+		return node.Raw, nil
+	}
+	if !f.numeric() && node.Type == gjson.String {
+		return node.Str, nil
+	}
+	return "", fmt.Errorf("field '%s': %w: expected %s but got raw type %s", f.name, validator.ErrTypeMismatch,
+		lo.Ternary(f.numeric(), "an integer", "a string"), node.Type)
+}
+
+func (f *ChoiceField[T]) validate(node gjson.Result, _ walkState) mo.Result[any] {
+	if !f.array {
+		s, err := f.token(node)
+		if err != nil {
+			return mo.Err[any](err)
+		}
+		return f.validateRaw(s)
+	}
+	if !node.IsArray() {
+		return mo.Err[any](fmt.Errorf("field '%s': expected an array", f.name))
+	}
+	errs := &validationError{}
+	values := make([]T, 0, len(node.Array()))
+	for i, el := range node.Array() {
+		s, err := f.token(el)
+		if err == nil {
+			var v T
+			v, err = f.parse(s)
+			values = append(values, v)
+		}
+		errs.add(fmt.Sprintf("%s[%d]", f.name, i), err)
+	}
+	return lo.Ternary(errs.err() != nil, mo.Err[any](errs.err()), mo.Ok[any](values))
+}
+
+func (f *ChoiceField[T]) validateRaw(s string) mo.Result[any] {
+	v, err := f.parse(s)
+	return lo.Ternary(err != nil, mo.Err[any](err), mo.Ok[any](v))
+}
+
+func (f *ChoiceField[T]) validateRawArray(vs []string) mo.Result[any] {
+	errs := &validationError{}
+	values := make([]T, 0, len(vs))
+	for i, s := range vs {
+		v, err := f.parse(s)
+		errs.add(fmt.Sprintf("%s[%d]", f.name, i), err)
+		values = append(values, v)
+	}
+	return lo.Ternary(errs.err() != nil, mo.Err[any](errs.err()), mo.Ok[any](values))
+}
+
+func (f *ChoiceField[T]) optional(map[*Schema]*Schema) ViewField {
+	cp := *f
+	cp.required = false
+	return &cp
+}
+
+func (f *ChoiceField[T]) embeddedObject() mo.Option[*Schema] {
+	return mo.None[*Schema]()
+}
+
+func (f *ChoiceField[T]) jsonSchema(map[*Schema]string) map[string]any {
+	node := map[string]any{"type": lo.Ternary(f.numeric(), "integer", "string"), "enum": f.Allowed()}
+	if f.array {
+		return map[string]any{"type": "array", "items": node}
+	}
+	return node
+}
+
+func (f *ChoiceField[T]) storedType() reflect.Type {
+	return sliceIf(reflect.TypeFor[T](), f.array)
+}
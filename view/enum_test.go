@@ -0,0 +1,87 @@
+package view
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+type testStatus string
+
+type testLevel int
+
+const (
+	statusActive testStatus = "active"
+	statusClosed testStatus = "closed"
+
+	levelLow  testLevel = 1
+	levelHigh testLevel = 3
+)
+
+func TestEnumField(t *testing.T) {
+	schema := WithFields(
+		EnumField("status", statusActive, statusClosed),
+		EnumField("level", levelLow, levelHigh).Optional(),
+		EnumField("tags", statusActive, statusClosed).AsArray().Optional(),
+	)
+	tests := []struct {
+		name    string
+		json    string
+		check   func(t *testing.T, vo ValueObject)
+		wantErr error
+	}{
+		{
+			name: "typed values",
+			json: `{"status":"closed","level":3,"tags":["active","closed"]}`,
+			check: func(t *testing.T, vo ValueObject) {
+				require.Equal(t, statusClosed, Value[testStatus](vo, "status").MustGet())
+				require.Equal(t, levelHigh, Value[testLevel](vo, "level").MustGet())
+				require.Equal(t, []testStatus{statusActive, statusClosed}, Value[[]testStatus](vo, "tags").MustGet())
+			},
+		},
+		{name: "not allowed", json: `{"status":"open"}`, wantErr: validator.ErrNotOneOf},
+		{name: "integer not allowed", json: `{"status":"active","level":2}`, wantErr: validator.ErrNotOneOf},
+		{name: "element not allowed", json: `{"status":"active","tags":["active","open"]}`, wantErr: validator.ErrNotOneOf},
+		{name: "number for string enum", json: `{"status":1}`, wantErr: validator.ErrTypeMismatch},
+		{name: "string for integer enum", json: `{"status":"active","level":"3"}`, wantErr: validator.ErrTypeMismatch},
+		{name: "float for integer enum", json: `{"status":"active","level":1.5}`, wantErr: validator.ErrTypeMismatch},
+		{name: "missing", json: `{}`, wantErr: validator.ErrRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := schema.Validate(tt.json)
+			if tt.wantErr != nil {
+				require.Error(t, res.Error())
+				require.ErrorIs(t, firstError(t, res.Error()), tt.wantErr)
+				return
+			}
+			require.NoError(t, res.Error())
+			tt.check(t, res.MustGet())
+		})
+	}
+}
+
+func TestEnumField_Form(t *testing.T) {
+	schema := WithFields(
+		EnumField("level", levelLow, levelHigh),
+		EnumField("tags", statusActive, statusClosed).AsArray(),
+	)
+	res := schema.ValidateForm(url.Values{"level": {"1"}, "tags": {"closed", "active"}})
+	require.NoError(t, res.Error())
+	require.Equal(t, levelLow, Value[testLevel](res.MustGet(), "level").MustGet())
+	require.Equal(t, []testStatus{statusClosed, statusActive}, Value[[]testStatus](res.MustGet(), "tags").MustGet())
+
+	res = schema.ValidateForm(url.Values{"level": {"low"}, "tags": {"active"}})
+	require.Error(t, res.Error())
+}
+
+func TestEnumField_JSONSchema(t *testing.T) {
+	f := EnumField("status", statusActive, statusClosed)
+	require.Equal(t, []testStatus{statusActive, statusClosed}, f.Allowed())
+	require.Equal(t, map[string]any{"type": "string", "enum": []testStatus{statusActive, statusClosed}}, f.jsonSchema(nil))
+	require.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "integer", "enum": []testLevel{levelLow, levelHigh}}},
+		EnumField("levels", levelLow, levelHigh).AsArray().jsonSchema(nil))
+	require.Panics(t, func() { EnumField[testStatus]("status") })
+}
@@ -0,0 +1,98 @@
+package view
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationError_Errors(t *testing.T) {
+	item := WithFields(Field[int]("id", validator.Gt(0)), Field[string]("name"))
+	schema := WithFields(
+		Field[int]("age", validator.Gt(18)),
+		Field[string]("email"),
+		ObjectField("address", WithFields(Field[string]("city", validator.MinLength(2)))),
+		ArrayOfObjectField("items", item),
+		ArrayField[string]("tags", validator.MinLength(2)),
+	)
+	res := schema.Validate(`{"age":10,"address":{"city":"x"},"items":[{"id":1,"name":"a"},{"id":0,"name":"b"},{"id":0}],"tags":["go","x"]}`)
+	var verr ValidationError
+	require.True(t, errors.As(res.Error(), &verr))
+	require.Equal(t, map[string]string{
+		"age":           "must be greater than 18",
+		"email":         "email is required but not found",
+		"address.city":  "length must be at least 2",
+		"items[1].id":   "must be greater than 0",
+		"items[2].id":   "must be greater than 0",
+		"items[2].name": "name is required but not found",
+		"tags[1]":       "length must be at least 2",
+	}, verr.Errors())
+
+	require.Equal(t, map[string]string{
+		"age":           "number.gt",
+		"email":         validator.CodeRequired,
+		"address.city":  "length.min",
+		"items[1].id":   "number.gt",
+		"items[2].id":   "number.gt",
+		"items[2].name": validator.CodeRequired,
+		"tags[1]":       "length.min",
+	}, verr.Codes())
+
+	data, err := json.Marshal(verr)
+	require.NoError(t, err)
+	var doc map[string]map[string]string
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Equal(t, verr.Errors(), doc["errors"])
+	require.Equal(t, verr.Codes(), doc["codes"])
+}
+
+func TestValidationError_Codes(t *testing.T) {
+	schema := WithFields(
+		Field[int8]("small"),
+		Field[int]("count"),
+		Field[string]("kind", validator.OneOf("a", "b")),
+		Field[string]("custom", func(...*validator.Rule) (string, validator.Validator[string]) {
+			return "no_spaces", func(string) error { return errors.New("has spaces") }
+		}),
+	)
+	var verr ValidationError
+	require.True(t, errors.As(schema.Validate(`{"small":300,"count":"x","kind":"c","custom":"a b"}`).Error(), &verr))
+	require.Equal(t, map[string]string{
+		"small":  validator.CodeOverflow,
+		"count":  validator.CodeTypeMismatch,
+		"kind":   "value.one_of",
+		"custom": "no_spaces",
+	}, verr.Codes())
+}
+
+func TestValidationError_Violations(t *testing.T) {
+	schema := WithFields(
+		Field[string]("password", validator.MinLength(8), validator.CharSetAny(validator.NumberChar), validator.CharSetAll(validator.UpperCaseChar)),
+		ArrayField[string]("tags", validator.MinLength(2), validator.OneOf("go", "sql")),
+		MapField[string]("labels", validator.MinLength(2), validator.OneOf("go", "sql")).Optional(),
+	)
+	res := schema.Validate(`{"password":"abc","tags":["go","x"],"labels":{"a":"x"}}`)
+	require.Error(t, res.Error())
+	require.Contains(t, res.Error().Error(), "- password: field 'password': length must be at least 8")
+	require.Contains(t, res.Error().Error(), "- password: field 'password': must contain at least one character from")
+
+	var verr ValidationError
+	require.True(t, errors.As(res.Error(), &verr))
+	violations := verr.Violations()
+	require.Len(t, violations["password"], 3)
+	require.Len(t, violations["tags[1]"], 2)
+	require.Len(t, violations["labels.a"], 2)
+	require.Equal(t, violations["password"][0], verr.Errors()["password"])
+	require.Equal(t, "length.min", verr.Codes()["password"])
+}
+
+func TestErrorBody(t *testing.T) {
+	require.Equal(t, map[string]string{"error": "boom"}, ErrorBody(errors.New("boom")))
+	err := WithFields(Field[int]("age", validator.Gt(18))).Validate(`{"age":10}`).Error()
+	data, jerr := json.Marshal(ErrorBody(err))
+	require.NoError(t, jerr)
+	require.JSONEq(t, `{"errors":{"age":"must be greater than 18"},"codes":{"age":"number.gt"}}`, string(data))
+}
@@ -1,12 +1,12 @@
 package vom
 
 import (
+	"net/url"
 	"sync"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/kcmvp/xql/internal"
 	"github.com/kcmvp/xql/view"
-	"github.com/samber/lo"
 )
 
 // EnrichFunc is a function type that can be used to enrich the validated data
@@ -28,22 +28,19 @@ func SetGlobalEnricher(enrich EnrichFunc) {
 	})
 }
 
-func urlParams(ctx fiber.Ctx) map[string]string {
-	params := lo.Associate(ctx.Route().Params, func(name string) (string, string) {
-		return name, ctx.Params(name)
-	})
-	// Iterate over all query parameters and merge them into the params map.
-	// The logic here enforces two rules:
-	// 1. A query parameter key cannot be a duplicate.
-	// 2. A query parameter key cannot conflict with a path parameter key.
-	ctx.Request().URI().QueryArgs().All()(func(key, value []byte) bool {
-		k := string(key)
-		_, ok := params[k]
-		lo.Assertf(!ok, "path and query parameters have a conflicting name or query parameter is duplicated: '%s'", k)
-		params[k] = string(value)
-		return true
-	})
-	return params
+// urlParams returns the path parameters and the query string of ctx. A
+// repeated query parameter feeds an ArrayField, and a name supplied by both
+// is reported as a conflict, see view.Schema.ValidateValues.
+func urlParams(ctx fiber.Ctx) []url.Values {
+	path := url.Values{}
+	for _, name := range ctx.Route().Params {
+		path.Set(name, ctx.Params(name))
+	}
+	query := url.Values{}
+	for key, value := range ctx.Request().URI().QueryArgs().All() {
+		query.Add(string(key), string(value))
+	}
+	return []url.Values{path, query}
 }
 
 // Bind creates a new fiber middleware to bind and validate the schema.
@@ -53,10 +50,10 @@ func Bind(schema *view.Schema) fiber.Handler {
 		// Get a fresh Schema instance for this request.
 		body := string(c.Body())
 		// The validate method is defined in the internal/core package.
-		result := schema.Validate(body, urlParams(c))
+		result := schema.ValidateValues(body, urlParams(c)...)
 		// The validate method is defined in the internal/core package.
 		if result.IsError() {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": result.Error().Error()})
+			return c.Status(fiber.StatusBadRequest).JSON(view.ErrorBody(result.Error()))
 		}
 		data := result.MustGet()
 		if _enrich != nil {
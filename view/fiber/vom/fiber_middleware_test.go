@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
-	"net/http/httptest"
 	"os"
 	"strings"
 	"sync"
@@ -12,7 +11,6 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v3"
-	"github.com/gofiber/fiber/v3/middleware/adaptor"
 	"github.com/kcmvp/xql/validator"
 	"github.com/kcmvp/xql/view"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +32,7 @@ var orderVO = view.WithFields(
 	view.Field[time.Time]("registered_date").Optional(),     // From query parameter
 	view.Field[bool]("received").Optional(),                 // From query parameter
 	view.Field[float64]("minim_price").Optional(),           // From query parameter
+	view.ArrayField[string]("tags").Optional(),              // From repeated query parameters
 )
 
 type MiddlewareTestSuite struct {
@@ -152,6 +151,7 @@ func (suite *MiddlewareTestSuite) TestBindingWithParamsAndEnricher() {
 		inputFile      string
 		expectedStatus int
 		expectedValues map[string]any // Expected values from URL params to merge into the final JSON
+		invalidField   string         // The field reported by a 400 response
 	}{
 		{
 			name:           "Valid request with basic path and query parameters",
@@ -174,6 +174,27 @@ func (suite *MiddlewareTestSuite) TestBindingWithParamsAndEnricher() {
 				"minim_price":     99.99,
 			},
 		},
+		{
+			name:           "Repeated query parameter feeds an array field",
+			url:            "/enriched_orders/order-abc-123?tags=a&tags=b",
+			inputFile:      "testdata/valid_order_optional.json",
+			expectedStatus: http.StatusOK,
+			expectedValues: map[string]any{"ordId": "order-abc-123", "tags": []string{"a", "b"}},
+		},
+		{
+			name:           "Repeated query parameter for a single value field is rejected",
+			url:            "/enriched_orders/order-fail-case?source=web&source=api",
+			inputFile:      "testdata/valid_order_optional.json",
+			expectedStatus: http.StatusBadRequest,
+			invalidField:   "source",
+		},
+		{
+			name:           "Path and query parameters with the same name conflict",
+			url:            "/enriched_orders/order-abc-123?ordId=other",
+			inputFile:      "testdata/valid_order_optional.json",
+			expectedStatus: http.StatusBadRequest,
+			invalidField:   "ordId",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -188,6 +209,14 @@ func (suite *MiddlewareTestSuite) TestBindingWithParamsAndEnricher() {
 			require.NoError(suite.T(), err)
 			bodyBytes, _ := io.ReadAll(res.Body)
 			require.Equalf(suite.T(), tc.expectedStatus, res.StatusCode, "Response body: %s", string(bodyBytes))
+			if tc.expectedStatus != http.StatusOK {
+				// the response lists the message and code of every invalid field
+				var body map[string]map[string]string
+				require.NoError(suite.T(), json.Unmarshal(bodyBytes, &body))
+				assert.Contains(suite.T(), body["errors"], tc.invalidField)
+				assert.Contains(suite.T(), body["codes"], tc.invalidField)
+				return
+			}
 
 			var expectedMap map[string]any
 			err = json.Unmarshal(payloadBytes, &expectedMap)
@@ -205,37 +234,3 @@ func (suite *MiddlewareTestSuite) TestBindingWithParamsAndEnricher() {
 		})
 	}
 }
-
-// TestPanicOnDuplicateQueryParam tests the specific case where the middleware should panic.
-// It is a separate test function to ensure complete isolation from the test suite's shared app instance,
-// which can cause routing issues when using the net/http adaptor.
-func TestPanicOnDuplicateQueryParam(t *testing.T) {
-	// 1. Define the ViewObject needed for this test
-	vo := view.WithFields(
-		view.Field[string]("ordId"),
-		view.Field[string]("source"),
-	)
-
-	// 2. Create a new, isolated Fiber app and register the route
-	// We disable the default recovery middleware so that we can test for the panic itself.
-	// We provide a custom ErrorHandler that will re-panic, allowing assert.Panics to catch it.
-	app := fiber.New(fiber.Config{
-		ErrorHandler: func(c fiber.Ctx, err error) error {
-			panic(err)
-		},
-	})
-	app.Post("/enriched_orders/:ordId", Bind(vo), orderHandler)
-
-	// 3. Create the request that should trigger the panic
-	url := "/enriched_orders/order-fail-case?source=web&source=api"
-	req := httptest.NewRequest(http.MethodPost, url, nil)
-	req.Header.Set("Content-Type", "application/json")
-
-	// 4. Use the adaptor to make the call synchronous. Since recovery is disabled,
-	// the panic will propagate and can be caught by assert.Panics.
-	handler := adaptor.FiberApp(app)
-	rec := httptest.NewRecorder()
-	assert.Panics(t, func() {
-		handler.ServeHTTP(rec, req)
-	})
-}
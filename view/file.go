@@ -0,0 +1,197 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"path"
+	"strings"
+
+	"github.com/kcmvp/xql/internal"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/match"
+)
+
+var (
+	ErrFileTooLarge    = errors.New("file size must be at most")
+	ErrFileContentType = errors.New("file content type must be one of")
+	ErrFileName        = errors.New("file name does not match pattern")
+)
+
+// FileValidator validates an uploaded file.
+type FileValidator func(fh *multipart.FileHeader) error
+
+// MaxFileSize validates that the uploaded file is at most n bytes.
+func MaxFileSize(n int64) FileValidator {
+	return func(fh *multipart.FileHeader) error {
+		return lo.Ternary(fh.Size > n, fmt.Errorf("%w %d bytes", ErrFileTooLarge, n), nil)
+	}
+}
+
+// FileContentType validates the Content-Type of the uploaded part against
+// the allowed media types. Parameters such as charset are ignored and a
+// subtype wildcard like "image/*" matches any subtype.
+func FileContentType(types ...string) FileValidator {
+	lo.Assertf(len(types) > 0, "FileContentType requires at least one media type")
+	return func(fh *multipart.FileHeader) error {
+		mediaType, _, _ := mime.ParseMediaType(fh.Header.Get("Content-Type"))
+		for _, t := range types {
+			if strings.EqualFold(t, mediaType) {
+				return nil
+			}
+			if prefix, ok := strings.CutSuffix(t, "/*"); ok && strings.HasPrefix(strings.ToLower(mediaType), strings.ToLower(prefix)+"/") {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w %v", ErrFileContentType, types)
+	}
+}
+
+// FileNameMatch validates the base name of the uploaded file against a
+// wildcard pattern, with the same syntax as validator.Match (e.g. "*.png").
+func FileNameMatch(pattern string) FileValidator {
+	lo.Assertf(match.IsPattern(pattern), "invalid pattern `%s`: `?` stands for one character, `*` stands for any number of characters", pattern)
+	return func(fh *multipart.FileHeader) error {
+		return lo.Ternary(!match.Match(path.Base(fh.Filename), pattern), fmt.Errorf("%w %s", ErrFileName, pattern), nil)
+	}
+}
+
+// FormFileField is the ViewField of an uploaded file. It only accepts
+// values through ValidateMultipart; the validated value is the
+// *multipart.FileHeader (or []*multipart.FileHeader for AsArray), see File
+// and Files.
+type FormFileField struct {
+	name       string
+	required   bool
+	array      bool
+	validators []FileValidator
+}
+
+var _ ViewField = (*FormFileField)(nil)
+
+// FileField creates a required file field validated by the given file
+// validators:
+//
+//	schema := view.WithFields(
+//		view.Field[string]("title"),
+//		view.FileField("avatar", view.MaxFileSize(1<<20), view.FileContentType("image/*")),
+//	)
+//	res := schema.ValidateMultipart(r.MultipartForm)
+func FileField(name string, validators ...FileValidator) *FormFileField {
+	if strings.ContainsAny(name, ".#") {
+		panic(fmt.Sprintf("xql: field name '%s' cannot contain '.' or '#'", name))
+	}
+	return &FormFileField{name: name, required: true, validators: validators}
+}
+
+// Optional marks the file as optional.
+func (f *FormFileField) Optional() *FormFileField {
+	f.required = false
+	return f
+}
+
+// AsArray accepts several files under the same name.
+func (f *FormFileField) AsArray() *FormFileField {
+	f.array = true
+	return f
+}
+
+func (f *FormFileField) Scope() string         { return "" }
+func (f *FormFileField) QualifiedName() string { return f.name }
+func (f *FormFileField) Name() string          { return f.name }
+func (f *FormFileField) UniqueName() string    { return f.name }
+func (f *FormFileField) IsArray() bool         { return f.array }
+func (f *FormFileField) IsObject() bool        { return false }
+func (f *FormFileField) Required() bool        { return f.required }
+
+func (f *FormFileField) validate(gjson.Result, walkState) mo.Result[any] {
+	return mo.Err[any](fmt.Errorf("field '%s': file fields require a multipart form", f.name))
+}
+
+func (f *FormFileField) validateRaw(string) mo.Result[any] {
+	return f.validate(gjson.Result{}, walkState{})
+}
+
+func (f *FormFileField) validateRawArray([]string) mo.Result[any] {
+	return f.validate(gjson.Result{}, walkState{})
+}
+
+func (f *FormFileField) optional(map[*Schema]*Schema) ViewField {
+	cp := *f
+	cp.required = false
+	return &cp
+}
+
+func (f *FormFileField) embeddedObject() mo.Option[*Schema] {
+	return mo.None[*Schema]()
+}
+
+func (f *FormFileField) jsonSchema(map[*Schema]string) map[string]any {
+	node := map[string]any{"type": "string", "format": "binary"}
+	if f.array {
+		return map[string]any{"type": "array", "items": node}
+	}
+	return node
+}
+
+// validateFiles runs the validators over the uploaded files.
+func (f *FormFileField) validateFiles(files []*multipart.FileHeader) mo.Result[any] {
+	if !f.array && len(files) > 1 {
+		return mo.Err[any](fmt.Errorf("field '%s': expected a single file but got %d", f.name, len(files)))
+	}
+	errs := &validationError{}
+	for i, fh := range files {
+		key := lo.Ternary(f.array, fmt.Sprintf("%s[%d]", f.name, i), f.name)
+		for _, v := range f.validators {
+			if err := v(fh); err != nil {
+				errs.add(key, fmt.Errorf("field '%s': %w", key, err))
+				break
+			}
+		}
+	}
+	if errs.err() != nil {
+		return mo.Err[any](errs.err())
+	}
+	return lo.Ternary[mo.Result[any]](f.array, mo.Ok[any](files), mo.Ok[any](files[0]))
+}
+
+// ValidateMultipart validates a parsed multipart form, typically
+// http.Request.MultipartForm after ParseMultipartForm. Regular values are
+// validated as by ValidateForm; FileField fields are validated against the
+// uploaded files.
+func (s *Schema) ValidateMultipart(form *multipart.Form) mo.Result[ValueObject] {
+	if form == nil {
+		return mo.Err[ValueObject](errors.New("multipart form is nil"))
+	}
+	return s.styled(s.checkCtx(context.Background(), s.validateForm(form.Value, form.File)))
+}
+
+// File returns the uploaded file validated by a FileField.
+func File(vo ValueObject, name string) mo.Option[*multipart.FileHeader] {
+	return internal.Get[*multipart.FileHeader](asData(vo), name)
+}
+
+// Files returns the uploaded files validated by a FileField marked AsArray.
+func Files(vo ValueObject, name string) mo.Option[[]*multipart.FileHeader] {
+	return internal.Get[[]*multipart.FileHeader](asData(vo), name)
+}
+
+// asData returns the map backing vo.
+func asData(vo ValueObject) internal.Data {
+	if v, ok := vo.(valueObject); ok {
+		return v.Data
+	}
+	data := internal.Data{}
+	for _, k := range vo.Fields() {
+		if vo.IsNull(k) {
+			data[k] = internal.Null
+		} else if v, ok := vo.Get(k).Get(); ok {
+			data[k] = v
+		}
+	}
+	return data
+}
@@ -0,0 +1,113 @@
+package view
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type upload struct {
+	field, filename, contentType, body string
+}
+
+func multipartForm(t *testing.T, values map[string]string, files ...upload) *multipart.Form {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range values {
+		require.NoError(t, w.WriteField(k, v))
+	}
+	for _, f := range files {
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="`+f.field+`"; filename="`+f.filename+`"`)
+		h.Set("Content-Type", f.contentType)
+		part, err := w.CreatePart(h)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(f.body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	r := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	require.NoError(t, r.ParseMultipartForm(1<<20))
+	return r.MultipartForm
+}
+
+func TestFileValidators(t *testing.T) {
+	fh := &multipart.FileHeader{Filename: "dir/avatar.PNG", Size: 10, Header: textproto.MIMEHeader{"Content-Type": {"image/png; charset=binary"}}}
+	tests := []struct {
+		name    string
+		v       FileValidator
+		wantErr error
+	}{
+		{"size ok", MaxFileSize(10), nil},
+		{"size too large", MaxFileSize(9), ErrFileTooLarge},
+		{"content type exact", FileContentType("text/plain", "image/png"), nil},
+		{"content type wildcard", FileContentType("image/*"), nil},
+		{"content type mismatch", FileContentType("application/pdf", "imagex/*"), ErrFileContentType},
+		{"name match", FileNameMatch("*.PNG"), nil},
+		{"name mismatch", FileNameMatch("*.jpg"), ErrFileName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.v(fh)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSchema_ValidateMultipart(t *testing.T) {
+	schema := WithFields(
+		Field[string]("title"),
+		FileField("avatar", MaxFileSize(16), FileContentType("image/*")),
+		FileField("docs", FileNameMatch("*.pdf")).AsArray().Optional(),
+	)
+
+	t.Run("valid", func(t *testing.T) {
+		form := multipartForm(t, map[string]string{"title": "me"},
+			upload{"avatar", "me.png", "image/png", "png-bytes"},
+			upload{"docs", "a.pdf", "application/pdf", "a"},
+			upload{"docs", "b.pdf", "application/pdf", "b"},
+		)
+		res := schema.ValidateMultipart(form)
+		require.NoError(t, res.Error())
+		vo := res.MustGet()
+		require.Equal(t, "me", vo.MstString("title"))
+		require.Equal(t, "me.png", File(vo, "avatar").MustGet().Filename)
+		docs := Files(vo, "docs").MustGet()
+		require.Len(t, docs, 2)
+		require.Equal(t, "b.pdf", docs[1].Filename)
+	})
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		files   []upload
+		wantErr string
+	}{
+		{"missing file", map[string]string{"title": "me"}, nil, "avatar is required"},
+		{"too large", map[string]string{"title": "me"}, []upload{{"avatar", "me.png", "image/png", "0123456789abcdefg"}}, "file size must be at most 16"},
+		{"content type", map[string]string{"title": "me"}, []upload{{"avatar", "me.txt", "text/plain", "x"}}, "file content type must be one of"},
+		{"array element", map[string]string{"title": "me"}, []upload{{"avatar", "me.png", "image/png", "x"}, {"docs", "a.exe", "application/pdf", "x"}}, "docs[0]"},
+		{"two files for single field", map[string]string{"title": "me"}, []upload{{"avatar", "a.png", "image/png", "x"}, {"avatar", "b.png", "image/png", "x"}}, "expected a single file but got 2"},
+		{"text value for file", map[string]string{"title": "me", "avatar": "x"}, nil, "expects a file upload"},
+		{"unknown file", map[string]string{"title": "me"}, []upload{{"avatar", "a.png", "image/png", "x"}, {"other", "a.png", "image/png", "x"}}, "unknown form file 'other'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := schema.ValidateMultipart(multipartForm(t, tt.values, tt.files...))
+			require.ErrorContains(t, res.Error(), tt.wantErr)
+		})
+	}
+
+	require.Error(t, schema.ValidateMultipart(nil).Error())
+	require.ErrorContains(t, WithFields(FileField("avatar")).Validate(`{"avatar":"x"}`).Error(), "file fields require a multipart form")
+}
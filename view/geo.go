@@ -0,0 +1,25 @@
+package view
+
+import "github.com/kcmvp/xql/validator"
+
+// GeoPoint returns the schema of a location in decimal degrees,
+// {"lat": 48.8566, "lng": 2.3522}, with the latitude and longitude required
+// and range checked. Every call returns a new schema, so it may be
+// customized, e.g. with AllowUnknownFields.
+func GeoPoint() *Schema {
+	return WithFields(
+		Field[float64]("lat", validator.Latitude[float64]()),
+		Field[float64]("lng", validator.Longitude[float64]()),
+	)
+}
+
+// GeoPointField is an ObjectField of GeoPoint:
+//
+//	schema := view.WithFields(
+//		view.Field[string]("name"),
+//		view.GeoPointField("location"),
+//	)
+//	lat := schema.Validate(body).MustGet().MstFloat64("location.lat")
+func GeoPointField(name string) *JSONField[string] {
+	return ObjectField(name, GeoPoint())
+}
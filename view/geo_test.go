@@ -0,0 +1,41 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoPointField(t *testing.T) {
+	schema := WithFields(Field[string]("name"), GeoPointField("location"))
+	vo := schema.Validate(`{"name":"Paris","location":{"lat":48.8566,"lng":2.3522}}`).MustGet()
+	require.Equal(t, 48.8566, vo.MstFloat64("location.lat"))
+	require.Equal(t, 2.3522, vo.MstFloat64("location.lng"))
+
+	res := schema.Validate(`{"name":"Nowhere","location":{"lat":91,"lng":-181}}`)
+	require.Equal(t, map[string]string{
+		"location.lat": "geo.latitude",
+		"location.lng": "geo.longitude",
+	}, res.Error().(*validationError).Codes())
+
+	res = schema.Validate(`{"name":"Paris","location":{"lat":48.8566}}`)
+	require.Equal(t, map[string]string{"location.lng": "required"}, res.Error().(*validationError).Codes())
+
+	doc, err := WithFields(GeoPointField("location")).JSONSchema()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["location"],
+		"properties": {"location": {
+			"type": "object",
+			"additionalProperties": false,
+			"required": ["lat", "lng"],
+			"properties": {
+				"lat": {"type": "number", "minimum": -90, "maximum": 90},
+				"lng": {"type": "number", "minimum": -180, "maximum": 180}
+			}
+		}}
+	}`, string(doc))
+}
@@ -4,12 +4,12 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/url"
 	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kcmvp/xql/internal"
 	"github.com/kcmvp/xql/view"
-	"github.com/samber/lo"
 	"github.com/samber/mo"
 )
 
@@ -27,22 +27,21 @@ func SetGlobalEnricher(enrich EnrichFunc) {
 	})
 }
 
-func urlParams(ctx *gin.Context) map[string]string {
-	params := lo.Associate(ctx.Params, func(item gin.Param) (string, string) {
-		return item.Key, item.Value
-	})
-	for name, values := range ctx.Request.URL.Query() {
-		lo.Assertf(len(values) == 1, "query parameter '%s' has multiple values, which is not supported", name)
-		_, ok := params[name]
-		lo.Assertf(!ok, "path parameter and query parameter have conflicting names: '%s'", name)
-		params[name] = values[0]
+// urlParams returns the path parameters and the query string of ctx. A
+// repeated query parameter feeds an ArrayField, and a name supplied by both
+// is reported as a conflict, see view.Schema.ValidateValues.
+func urlParams(ctx *gin.Context) []url.Values {
+	path := url.Values{}
+	for _, p := range ctx.Params {
+		path.Set(p.Key, p.Value)
 	}
-	return params
+	return []url.Values{path, ctx.Request.URL.Query()}
 }
 
 // Bind creates a Gin middleware that validates the request body against a dvo.Schema.
 // If validation is successful, the validated data is stored in the request context.
-// If validation fails, it aborts the request with a 400 Bad Request status and an error message.
+// If validation fails, it aborts the request with a 400 Bad Request status and a JSON body
+// listing the message and code of every invalid field, see view.ErrorBody.
 // It also allows for enriching the validated data using a previously set EnrichFunc function.
 func Bind(schema *view.Schema) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -53,9 +52,9 @@ func Bind(schema *view.Schema) gin.HandlerFunc {
 			return
 		}
 		body := string(bts.MustGet())
-		result := schema.Validate(body, urlParams(ctx))
+		result := schema.ValidateValues(body, urlParams(ctx)...)
 		if result.IsError() {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": result.Error().Error()})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, view.ErrorBody(result.Error()))
 			return
 		}
 		data := result.MustGet()
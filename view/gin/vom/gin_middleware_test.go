@@ -32,6 +32,7 @@ var orderVO = view.WithFields(
 	view.Field[time.Time]("registered_date").Optional(),     // From query parameter
 	view.Field[bool]("received").Optional(),                 // From query parameter
 	view.Field[float64]("minim_price").Optional(),           // From query parameter
+	view.ArrayField[string]("tags").Optional(),              // From repeated query parameters
 )
 
 type MiddlewareTestSuite struct {
@@ -149,7 +150,7 @@ func (suite *MiddlewareTestSuite) TestBindingWithParamsAndEnricher() {
 		inputFile      string
 		expectedStatus int
 		expectedValues map[string]any // Expected values from URL params to merge into the final JSON
-		shouldPanic    bool
+		invalidField   string         // The field reported by a 400 response
 	}{
 		{
 			name:           "Valid request with basic path and query parameters",
@@ -173,10 +174,25 @@ func (suite *MiddlewareTestSuite) TestBindingWithParamsAndEnricher() {
 			},
 		},
 		{
-			name:        "Request with multiple values for a query parameter should panic",
-			url:         "/enriched_orders/order-fail-case?source=web&source=api",
-			inputFile:   "testdata/valid_order_optional.json",
-			shouldPanic: true,
+			name:           "Repeated query parameter feeds an array field",
+			url:            "/enriched_orders/order-abc-123?tags=a&tags=b",
+			inputFile:      "testdata/valid_order_optional.json",
+			expectedStatus: http.StatusOK,
+			expectedValues: map[string]any{"ordId": "order-abc-123", "tags": []string{"a", "b"}},
+		},
+		{
+			name:           "Repeated query parameter for a single value field is rejected",
+			url:            "/enriched_orders/order-fail-case?source=web&source=api",
+			inputFile:      "testdata/valid_order_optional.json",
+			expectedStatus: http.StatusBadRequest,
+			invalidField:   "source",
+		},
+		{
+			name:           "Path and query parameters with the same name conflict",
+			url:            "/enriched_orders/order-abc-123?ordId=other",
+			inputFile:      "testdata/valid_order_optional.json",
+			expectedStatus: http.StatusBadRequest,
+			invalidField:   "ordId",
 		},
 	}
 
@@ -189,16 +205,16 @@ func (suite *MiddlewareTestSuite) TestBindingWithParamsAndEnricher() {
 			req.Header.Set("Content-Type", "application/json")
 			rec := httptest.NewRecorder()
 
-			if tc.shouldPanic {
-				// The default Gin recovery middleware will catch the panic and return a 500 error.
-				// So, we assert the status code instead of the panic itself.
-				suite.srv.ServeHTTP(rec, req)
-				assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
-				return
-			}
-
 			suite.srv.ServeHTTP(rec, req)
 			require.Equalf(suite.T(), tc.expectedStatus, rec.Code, rec.Body.String())
+			if tc.expectedStatus != http.StatusOK {
+				// the response lists the message and code of every invalid field
+				var body map[string]map[string]string
+				require.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Contains(suite.T(), body["errors"], tc.invalidField)
+				assert.Contains(suite.T(), body["codes"], tc.invalidField)
+				return
+			}
 
 			var expectedMap map[string]any
 			err = json.Unmarshal(payloadBytes, &expectedMap)
@@ -0,0 +1,56 @@
+package view
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+)
+
+// HeaderField creates a field whose value is read from the HTTP header
+// `header` by ValidateRequest, parsed with T and checked by the given
+// validators like any other field:
+//
+//	schema := view.WithFields(
+//		view.Field[string]("name"),
+//		view.HeaderField[string]("X-Request-ID", validator.ExactLength(36)).As("requestId"),
+//	)
+//	res := schema.ValidateRequest(r) // res.MustGet().MstString("requestId")
+//
+// The validated value is stored under the header name, or under the key set
+// with As. Header fields are never read from the JSON payload or URL
+// parameters, so a required header field only validates through
+// ValidateRequest. Use AsArray to accept a repeated header.
+func HeaderField[T validator.FieldType](header string, vfs ...validator.ValidateFunc[T]) *JSONField[T] {
+	header = strings.TrimSpace(header)
+	lo.Assertf(header != "", "xql: header name is required for HeaderField")
+	f := trait[T](header, false, false, nil, vfs...)
+	f.header = http.CanonicalHeaderKey(header)
+	return f
+}
+
+// As sets the key a HeaderField is stored under in the validated
+// ValueObject. It panics for fields not created by HeaderField.
+func (f *JSONField[T]) As(key string) *JSONField[T] {
+	lo.Assertf(f.header != "", "xql: As is only supported by HeaderField, field '%s'", f.Name())
+	if strings.ContainsAny(key, ".#") || key == "" {
+		panic(fmt.Sprintf("xql: field name '%s' cannot be empty or contain '.' or '#'", key))
+	}
+	f.qualifiedName = key
+	return f
+}
+
+// headerOf returns the HTTP header a field reads from, or "" for fields
+// that are not header fields.
+func headerOf(field ViewField) string {
+	if h, ok := field.(interface{ headerName() string }); ok {
+		return h.headerName()
+	}
+	return ""
+}
+
+func (f *JSONField[T]) headerName() string {
+	return f.header
+}
@@ -0,0 +1,78 @@
+package view
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderField(t *testing.T) {
+	schema := WithFields(
+		Field[string]("name"),
+		HeaderField[string]("x-request-id", validator.ExactLength(8)).As("requestId"),
+		HeaderField[int]("X-Retry").Optional(),
+		HeaderField[string]("Accept-Language").AsArray().Optional(),
+	)
+	request := func(headers map[string][]string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"jack"}`))
+		for k, vs := range headers {
+			for _, v := range vs {
+				r.Header.Add(k, v)
+			}
+		}
+		return r
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		res := schema.ValidateRequest(request(map[string][]string{
+			"X-Request-Id":    {"abcd1234"},
+			"X-Retry":         {"3"},
+			"Accept-Language": {"en", "fr"},
+		}))
+		require.NoError(t, res.Error())
+		vo := res.MustGet()
+		require.Equal(t, "jack", vo.MstString("name"))
+		require.Equal(t, "abcd1234", vo.MstString("requestId"))
+		require.Equal(t, 3, vo.MstInt("X-Retry"))
+		require.Equal(t, []string{"en", "fr"}, vo.MstStringArray("Accept-Language"))
+	})
+
+	tests := []struct {
+		name    string
+		headers map[string][]string
+		wantErr string
+	}{
+		{"missing required", nil, "header 'X-Request-Id' is required"},
+		{"validator", map[string][]string{"X-Request-Id": {"short"}}, "length must be exactly 8"},
+		{"type", map[string][]string{"X-Request-Id": {"abcd1234"}, "X-Retry": {"x"}}, "field 'X-Retry'"},
+		{"repeated scalar", map[string][]string{"X-Request-Id": {"abcd1234", "abcd1234"}}, "header 'X-Request-Id' has multiple values"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorContains(t, schema.ValidateRequest(request(tt.headers)).Error(), tt.wantErr)
+		})
+	}
+
+	t.Run("not read from payload", func(t *testing.T) {
+		res := schema.Validate(`{"name":"jack","requestId":"abcd1234"}`)
+		require.ErrorContains(t, res.Error(), "unknown json field 'requestId'")
+		res = schema.ValidateValues(`{"name":"jack"}`, url.Values{"requestId": {"abcd1234"}})
+		require.ErrorContains(t, res.Error(), "unknown url parameter 'requestId'")
+		res = schema.ValidateForm(url.Values{"name": {"jack"}})
+		require.ErrorContains(t, res.Error(), "header 'X-Request-Id' is required")
+	})
+
+	t.Run("json schema skips headers", func(t *testing.T) {
+		doc, err := schema.JSONSchema()
+		require.NoError(t, err)
+		require.NotContains(t, string(doc), "requestId")
+	})
+
+	require.Panics(t, func() { Field[string]("name").As("other") })
+	require.Panics(t, func() { HeaderField[string]("X-Id").As("a.b") })
+}
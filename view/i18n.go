@@ -0,0 +1,156 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/mo"
+)
+
+// Message keys of the catalog entries that are not validator names.
+const (
+	MsgRequired     = "required"
+	MsgTypeMismatch = "type_mismatch"
+	MsgOverflow     = "integer_overflow"
+)
+
+var (
+	catalogMu sync.RWMutex
+	catalogs  = map[string]map[string]string{}
+)
+
+// RegisterMessages adds message templates for locale (e.g. "fr" or
+// "pt-BR"). Templates are keyed by validator name, as reported by
+// validator.Describe ("min_length", "gt", "email", ...), or by MsgRequired,
+// MsgTypeMismatch and MsgOverflow. A template may reference the field path
+// as {field}, the validator parameters by name, e.g. {min} and {max}, and
+// the rejected value or its length as {actual} when the validator reports a
+// validator.ConstraintError:
+//
+//	view.RegisterMessages("fr", map[string]string{
+//		view.MsgRequired: "{field} est obligatoire",
+//		"min_length":     "doit contenir au moins {min} caractères",
+//	})
+//
+// Later registrations for the same locale add to or replace earlier ones.
+func RegisterMessages(locale string, messages map[string]string) {
+	locale = normalizeLocale(locale)
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if catalogs[locale] == nil {
+		catalogs[locale] = map[string]string{}
+	}
+	maps.Copy(catalogs[locale], messages)
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// template returns the template of key for locale, falling back from a
+// regional locale ("fr-ca") to its language ("fr").
+func template(locale, key string) (string, bool) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	for locale != "" {
+		if tpl, ok := catalogs[locale][key]; ok {
+			return tpl, true
+		}
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	return "", false
+}
+
+// ValidateLocalized is Validate with the error messages rendered in locale,
+// see Localize.
+func (s *Schema) ValidateLocalized(json, locale string, urlParams ...map[string]string) mo.Result[ValueObject] {
+	res := s.Validate(json, urlParams...)
+	if res.IsError() {
+		return mo.Err[ValueObject](Localize(res.Error(), locale))
+	}
+	return res
+}
+
+// Localize renders the messages of a validation error in locale using the
+// templates added with RegisterMessages. Messages without a template for the
+// locale are kept as they are, and errors other than a ValidationError are
+// returned unchanged. The result still supports errors.Is and errors.As on
+// the original errors.
+func Localize(err error, locale string) error {
+	var verr *validationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+	return verr.localize("", normalizeLocale(locale))
+}
+
+// localizedError is an error whose message was rendered from a template.
+type localizedError struct {
+	msg string
+	err error
+}
+
+func (e *localizedError) Error() string { return e.msg }
+func (e *localizedError) Unwrap() error { return e.err }
+
+func (e *validationError) localize(prefix, locale string) *validationError {
+	out := &validationError{style: e.style}
+	for key, errs := range e.errors {
+		path := joinPath(prefix, key)
+		for _, err := range errs {
+			var nested *validationError
+			var fe *fieldError
+			switch {
+			case errors.As(err, &nested) && nested != e:
+				out.add(key, nested.localize(path, locale))
+			case errors.As(err, &fe):
+				out.add(key, &fieldError{path: fe.path, err: localizeOne(fe.err, joinPath(path, fe.path), locale)})
+			default:
+				out.add(key, localizeOne(err, path, locale))
+			}
+		}
+	}
+	return out
+}
+
+// localizeOne renders a single field error, or returns it unchanged when
+// there is no matching template.
+func localizeOne(err error, path, locale string) error {
+	var key string
+	params := map[string]any{}
+	var re *ruleError
+	switch {
+	case errors.As(err, &re):
+		key = re.rule.Name
+		params = re.rule.Params
+	case errors.Is(err, validator.ErrRequired):
+		key = MsgRequired
+	case errors.Is(err, validator.ErrTypeMismatch):
+		key = MsgTypeMismatch
+	case errors.Is(err, validator.ErrIntegerOverflow):
+		key = MsgOverflow
+	default:
+		return err
+	}
+	tpl, ok := template(locale, key)
+	if !ok {
+		return err
+	}
+	pairs := []string{"{field}", path}
+	for k, v := range params {
+		pairs = append(pairs, "{"+k+"}", fmt.Sprint(v))
+	}
+	var ce *validator.ConstraintError
+	if errors.As(err, &ce) {
+		pairs = append(pairs, "{actual}", fmt.Sprint(ce.Actual))
+	}
+	return &localizedError{msg: strings.NewReplacer(pairs...).Replace(tpl), err: err}
+}
@@ -0,0 +1,91 @@
+package view
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLocalized(t *testing.T) {
+	RegisterMessages("fr", map[string]string{
+		MsgRequired:     "{field} est obligatoire",
+		MsgTypeMismatch: "{field} a un type invalide",
+		"min_length":    "doit contenir au moins {min} caractères",
+		"between":       "doit être entre {min} et {max}",
+	})
+	RegisterMessages("fr_CA", map[string]string{
+		MsgRequired: "{field} est requis",
+	})
+	schema := WithFields(
+		Field[string]("name", validator.MinLength(3)),
+		Field[int]("age", validator.Between(18, 99)),
+		Field[string]("email", validator.Email()),
+		ArrayOfObjectField("items", WithFields(Field[int]("id"), Field[string]("sku"))).Optional(),
+	)
+	payload := `{"name":"jo","age":10,"items":[{"id":"x"}]}`
+
+	tests := []struct {
+		locale string
+		want   map[string]string
+	}{
+		{locale: "fr", want: map[string]string{
+			"name":         "doit contenir au moins 3 caractères",
+			"age":          "doit être entre 18 et 99",
+			"email":        "email est obligatoire",
+			"items[0].id":  "items[0].id a un type invalide",
+			"items[0].sku": "items[0].sku est obligatoire",
+		}},
+		{locale: "fr-CA", want: map[string]string{
+			"name":         "doit contenir au moins 3 caractères",
+			"age":          "doit être entre 18 et 99",
+			"email":        "email est requis",
+			"items[0].id":  "items[0].id a un type invalide",
+			"items[0].sku": "items[0].sku est requis",
+		}},
+		{locale: "de", want: map[string]string{
+			"name":         "length must be at least 3",
+			"age":          "must be between 18 and 99",
+			"email":        "email is required but not found",
+			"items[0].id":  "type mismatch: expected int but got raw type String",
+			"items[0].sku": "sku is required but not found",
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.locale, func(t *testing.T) {
+			res := schema.ValidateLocalized(payload, tc.locale)
+			var verr ValidationError
+			require.True(t, errors.As(res.Error(), &verr))
+			require.Equal(t, tc.want, verr.Errors())
+		})
+	}
+
+	t.Run("sentinels preserved", func(t *testing.T) {
+		err := Localize(schema.Validate(`{"name":"joe","age":20}`).Error(), "fr")
+		var verr *validationError
+		require.ErrorAs(t, err, &verr)
+		require.ErrorIs(t, verr.first("email"), validator.ErrRequired)
+		require.Equal(t, "email est obligatoire", verr.first("email").Error())
+	})
+
+	t.Run("other errors unchanged", func(t *testing.T) {
+		err := errors.New("boom")
+		require.Equal(t, err, Localize(err, "fr"))
+	})
+}
+
+func TestLocalize_Actual(t *testing.T) {
+	RegisterMessages("es", map[string]string{
+		"max_runes": "{field} tiene {actual} caracteres, el máximo es {max}",
+	})
+	schema := WithFields(Field[string]("nick", validator.MaxRunes(4)))
+	res := schema.ValidateLocalized(`{"nick":"señorita"}`, "es")
+	var verr ValidationError
+	require.True(t, errors.As(res.Error(), &verr))
+	require.Equal(t, map[string]string{"nick": "nick tiene 8 caracteres, el máximo es 4"}, verr.Errors())
+
+	var ce *validator.ConstraintError
+	require.ErrorAs(t, verr.(*validationError).first("nick"), &ce)
+	require.Equal(t, "length.max_runes", ce.Code)
+}
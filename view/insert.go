@@ -0,0 +1,47 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kcmvp/xql"
+	"github.com/kcmvp/xql/entity"
+	"github.com/kcmvp/xql/sqlx"
+)
+
+// ToInsert converts vo, validated by a schema of persistent fields (see
+// WithXQLFields), into an sqlx INSERT of T in one call:
+//
+//	res := view.WithXQLFields(account.Email, account.Nickname).Validate(body)
+//	exec, err := view.ToInsert[Account](res.MustGet(), account.Email, account.Nickname)
+//	_, err = exec.Execute(ctx, db)
+//
+// Every value of vo must be held by a persistent field of T's table. notNull
+// lists the NOT NULL columns without a database default: ToInsert fails with
+// sqlx.ErrMissingColumn when one of them is absent or null, before any SQL is
+// built.
+func ToInsert[T entity.Entity](vo ValueObject, notNull ...xql.Field) (sqlx.InsertExecutor, error) {
+	if vo == nil {
+		return nil, errors.New("view: ToInsert requires a ValueObject")
+	}
+	table := (*new(T)).Table()
+	values := vo.FlatMap()
+	for key := range values {
+		if strings.Count(key, ".") < 2 {
+			return nil, fmt.Errorf("view: field '%s' is not a persistent field", key)
+		}
+		if !strings.HasPrefix(key, table+".") {
+			return nil, fmt.Errorf("view: field '%s': %w, expected %q", key, sqlx.ErrCrossTableField, table)
+		}
+	}
+	for _, f := range notNull {
+		if values[f.QualifiedName()] == nil {
+			return nil, fmt.Errorf("view: %w: %s", sqlx.ErrMissingColumn, f.QualifiedName())
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("view: %w", sqlx.ErrNoValues)
+	}
+	return sqlx.Insert[T](sqlx.MapValueObject(values), notNull...), nil
+}
@@ -0,0 +1,76 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kcmvp/xql"
+	. "github.com/kcmvp/xql/sample/entity"
+	acct "github.com/kcmvp/xql/sample/gen/field/account"
+	ord "github.com/kcmvp/xql/sample/gen/field/order"
+	"github.com/kcmvp/xql/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToInsert(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec("CREATE TABLE accounts (id INTEGER PRIMARY KEY, email TEXT NOT NULL, nick_name TEXT NOT NULL)")
+	require.NoError(t, err)
+
+	schema := WithXQLFields(acct.Email, acct.Nickname)
+	res := schema.Validate(`{"Email":"a@b.c","Nickname":"bob"}`)
+	require.NoError(t, res.Error())
+
+	exec, err := ToInsert[Account](res.MustGet(), acct.Email, acct.Nickname)
+	require.NoError(t, err)
+	_, err = exec.Execute(context.Background(), db)
+	require.NoError(t, err)
+	var email, nick string
+	require.NoError(t, db.QueryRow("SELECT email, nick_name FROM accounts").Scan(&email, &nick))
+	require.Equal(t, "a@b.c", email)
+	require.Equal(t, "bob", nick)
+
+	t.Run("missing not null column", func(t *testing.T) {
+		res := WithXQLFields(acct.Email).Validate(`{"Email":"a@b.c"}`)
+		require.NoError(t, res.Error())
+		_, err := ToInsert[Account](res.MustGet(), acct.Email, acct.Nickname)
+		require.ErrorIs(t, err, sqlx.ErrMissingColumn)
+	})
+
+	t.Run("nullable column", func(t *testing.T) {
+		_, err := db.Exec("DELETE FROM accounts")
+		require.NoError(t, err)
+		_, err = db.Exec("ALTER TABLE accounts ADD COLUMN bio TEXT")
+		require.NoError(t, err)
+		bio := xql.NewField[Account, string]("bio", "Bio").Nullable()
+		res := WithXQLFields(acct.Email, acct.Nickname, bio).Validate(`{"Email":"a@b.c","Nickname":"bob","Bio":null}`)
+		require.NoError(t, res.Error())
+		require.True(t, res.MustGet().IsNull(bio.QualifiedName()))
+		exec, err := ToInsert[Account](res.MustGet(), acct.Email, acct.Nickname)
+		require.NoError(t, err)
+		_, err = exec.Execute(context.Background(), db)
+		require.NoError(t, err)
+		var got sql.NullString
+		require.NoError(t, db.QueryRow("SELECT bio FROM accounts").Scan(&got))
+		require.False(t, got.Valid)
+	})
+
+	t.Run("other table", func(t *testing.T) {
+		res := WithXQLFields(acct.Email, ord.Amount).Validate(`{"Email":"a@b.c","Amount":1.5}`)
+		require.NoError(t, res.Error())
+		_, err := ToInsert[Account](res.MustGet())
+		require.ErrorIs(t, err, sqlx.ErrCrossTableField)
+	})
+
+	t.Run("view only field", func(t *testing.T) {
+		res := WithFields(Field[string]("email")).Validate(`{"email":"a@b.c"}`)
+		require.NoError(t, res.Error())
+		_, err := ToInsert[Account](res.MustGet())
+		require.EqualError(t, err, "view: field 'email' is not a persistent field")
+	})
+}
@@ -0,0 +1,61 @@
+package view
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONField_Items(t *testing.T) {
+	schema := WithFields(
+		ArrayField[string]("tags").NonEmpty().MaxItems(3),
+		ArrayOfObjectField("items", WithFields(Field[int]("id"))).MinItems(2).Optional(),
+	)
+	tests := []struct {
+		name string
+		json string
+		code string
+	}{
+		{name: "valid", json: `{"tags":["a"],"items":[{"id":1},{"id":2}]}`},
+		{name: "empty", json: `{"tags":[]}`, code: "min_items"},
+		{name: "too many", json: `{"tags":["a","b","c","d"]}`, code: "max_items"},
+		{name: "too many fails before elements", json: `{"tags":[1,2,3,4]}`, code: "max_items"},
+		{name: "object array too short", json: `{"tags":["a"],"items":[{"id":1}]}`, code: "min_items"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := schema.Validate(tc.json)
+			if tc.code == "" {
+				require.NoError(t, res.Error())
+				return
+			}
+			var verr ValidationError
+			require.ErrorAs(t, res.Error(), &verr)
+			require.Len(t, verr.Codes(), 1)
+			for _, code := range verr.Codes() {
+				require.Equal(t, tc.code, code)
+			}
+		})
+	}
+
+	t.Run("url params", func(t *testing.T) {
+		res := schema.ValidateValues(`{}`, url.Values{"tags": {"a", "b", "c", "d"}})
+		require.ErrorContains(t, res.Error(), "must have at most 3 items")
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		data, err := schema.JSONSchema()
+		require.NoError(t, err)
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(data, &doc))
+		tags := doc["properties"].(map[string]any)["tags"].(map[string]any)
+		require.EqualValues(t, 1, tags["minItems"])
+		require.EqualValues(t, 3, tags["maxItems"])
+	})
+
+	t.Run("array only", func(t *testing.T) {
+		require.Panics(t, func() { Field[string]("a").MaxItems(1) })
+	})
+}
@@ -0,0 +1,281 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+)
+
+// jsonSchemaDialect is the `$schema` URI emitted by Schema.JSONSchema.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema returns a draft 2020-12 JSON Schema document describing the
+// payload accepted by s, for client-side validation and documentation.
+//
+// Field types map to JSON types (integers to "integer", floats to "number",
+// time.Time to a "date-time" string), Required fields are listed in
+// "required", nested objects and arrays become nested schemas, and unknown
+// properties are rejected unless AllowUnknownFields was set. Validators from
+// the validator package translate to keywords where JSON Schema has an
+// equivalent:
+//
+//	min_length, max_length, exact_length, length_between -> minLength / maxLength
+//	min_runes, max_runes, runes_between, max_bytes       -> minLength / maxLength
+//	password                                             -> minLength
+//	gt, gte, lt, lte, between                            -> exclusiveMinimum / minimum / exclusiveMaximum / maximum
+//	latitude, longitude, port                            -> minimum / maximum
+//	multiple_of                                          -> multipleOf
+//	one_of                                               -> enum
+//	email, url                                           -> format
+//	time_format with an RFC 3339 date, time or date-time -> format
+//	numeric, alphanumeric                                -> pattern
+//	slug, hex                                            -> pattern / minLength / maxLength
+//	hex_color                                            -> pattern
+//	base64, base64url                                    -> contentEncoding
+//	be_true, be_false                                    -> const
+//	validators inverted by validator.Not                 -> not
+//	any_of, all_of                                       -> anyOf / allOf
+//
+// Nullable fields also accept null: "null" joins their type, and nodes whose
+// values cannot list it (`$ref`, enum, const) are wrapped in an anyOf with
+// {"type": "null"}.
+//
+// Constraints declared on persistent fields translate the same way. Other
+// validators (character sets, wildcard patterns, comparisons on time.Time,
+// rules applied by validator.When) are still enforced by Validate but have no
+// keyword in the document; custom exporters can read them from
+// JSONField.Rules. HeaderField fields are not part of the payload and are
+// left out. Recursive schemas (see SelfRef) are
+// referenced with `$ref`, to the document root or to an entry of `$defs`.
+func (s *Schema) JSONSchema() ([]byte, error) {
+	refs := map[*Schema]string{}
+	defs := schemaDefs(s, refs)
+	doc := s.jsonSchema(refs)
+	if len(defs) > 0 {
+		doc["$defs"] = defs
+	}
+	doc["$schema"] = jsonSchemaDialect
+	return json.Marshal(doc)
+}
+
+// jsonSchema returns the object schema for s without the `$schema` keyword,
+// so it can be embedded for nested objects. Nested schemas found in refs are
+// emitted as `$ref` to the given URI instead of inline.
+func (s *Schema) jsonSchema(refs map[*Schema]string) map[string]any {
+	properties := make(map[string]any, len(s.fields))
+	var required []string
+	for _, field := range s.fields {
+		if headerOf(field) != "" {
+			// headers are not part of the payload
+			continue
+		}
+		prop := field.jsonSchema(refs)
+		switch accessOf(field) {
+		case readOnly:
+			prop["readOnly"] = true
+		case writeOnly:
+			prop["writeOnly"] = true
+		}
+		if deprecationOf(field) != "" {
+			prop["deprecated"] = true
+		}
+		properties[field.Name()] = prop
+		if field.Required() {
+			required = append(required, field.Name())
+		}
+	}
+	node := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		node["required"] = required
+	}
+	if !s.allowUnknownFields {
+		node["additionalProperties"] = false
+	}
+	return node
+}
+
+func (f *JSONField[T]) jsonSchema(refs map[*Schema]string) map[string]any {
+	var node map[string]any
+	if f.embedded == nil {
+		node = jsonType[T]()
+		if f.layouts != nil {
+			// custom layouts are not RFC 3339 date-times
+			delete(node, "format")
+		}
+		for _, rule := range f.rules {
+			ruleKeywords[T](rule, node)
+		}
+		if f.stringEncoded {
+			// the protobuf JSON mapping of 64-bit integers
+			node["type"], node["format"] = "string", reflect.TypeFor[T]().Name()
+			node["pattern"] = lo.Ternary(node["format"] == "int64", `^-?[0-9]+$`, `^[0-9]+$`)
+		}
+	} else if ref, ok := refs[f.embedded]; ok {
+		node = map[string]any{"$ref": ref}
+	} else {
+		node = f.embedded.jsonSchema(refs)
+	}
+	if f.IsArray() {
+		array := map[string]any{"type": "array", "items": node}
+		if f.minItems > 0 {
+			array["minItems"] = f.minItems
+		}
+		if f.maxItems > 0 {
+			array["maxItems"] = f.maxItems
+		}
+		if f.unique && len(f.uniqueBy) == 0 {
+			array["uniqueItems"] = true
+		}
+		node = array
+	}
+	if f.nullable {
+		return nullableNode(node)
+	}
+	return node
+}
+
+// nullableNode widens node to also accept JSON null, see Nullable.
+func nullableNode(node map[string]any) map[string]any {
+	t, typed := node["type"].(string)
+	_, isEnum := node["enum"]
+	_, isConst := node["const"]
+	if !typed || isEnum || isConst {
+		return map[string]any{"anyOf": []any{node, map[string]any{"type": "null"}}}
+	}
+	node["type"] = []string{t, "null"}
+	return node
+}
+
+// jsonType returns the JSON Schema type keywords for T.
+func jsonType[T validator.FieldType]() map[string]any {
+	var zero T
+	if _, ok := any(zero).(time.Time); ok {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch reflect.TypeOf(zero).Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	default:
+		return map[string]any{"type": "integer"}
+	}
+}
+
+// ruleKeywords adds the JSON Schema keywords equivalent to rule to node.
+func ruleKeywords[T validator.FieldType](rule validator.Rule, node map[string]any) {
+	var zero T
+	_, isTime := any(zero).(time.Time)
+	p := rule.Params
+	if p["conditional"] == true {
+		// rules applied by validator.When only hold for some values
+		return
+	}
+	switch rule.Name {
+	case "min_length", "min_runes":
+		node["minLength"] = p["min"]
+	case "max_length", "max_runes", "max_bytes":
+		node["maxLength"] = p["max"]
+	case "password":
+		node["minLength"] = p["min"]
+	case "exact_length":
+		node["minLength"], node["maxLength"] = p["length"], p["length"]
+	case "length_between", "runes_between":
+		node["minLength"], node["maxLength"] = p["min"], p["max"]
+	case "gt":
+		if !isTime {
+			node["exclusiveMinimum"] = p["min"]
+		}
+	case "gte":
+		if !isTime {
+			node["minimum"] = p["min"]
+		}
+	case "lt":
+		if !isTime {
+			node["exclusiveMaximum"] = p["max"]
+		}
+	case "lte":
+		if !isTime {
+			node["maximum"] = p["max"]
+		}
+	case "between":
+		if !isTime {
+			node["minimum"], node["maximum"] = p["min"], p["max"]
+		}
+	case "latitude", "longitude", "port":
+		node["minimum"], node["maximum"] = p["min"], p["max"]
+	case "multiple_of":
+		node["multipleOf"] = p["step"]
+	case "one_of":
+		node["enum"] = p["values"]
+	case "email":
+		node["format"] = "email"
+	case "url":
+		node["format"] = "uri"
+	case "time_format":
+		if format, ok := map[any]string{time.RFC3339: "date-time", time.DateOnly: "date", time.TimeOnly: "time"}[p["layout"]]; ok {
+			node["format"] = format
+		}
+	case "numeric":
+		node["pattern"] = `^[0-9]*$`
+	case "alphanumeric":
+		node["pattern"] = `^[A-Za-z0-9]*$`
+	case "slug":
+		class := "a-z0-9" + regexp.QuoteMeta(p["chars"].(string))
+		node["pattern"] = fmt.Sprintf("^[%s]+(-[%s]+)*$", class, class)
+		if p["max"].(int) > 0 {
+			node["maxLength"] = p["max"]
+		}
+	case "hex":
+		node["pattern"] = `^([0-9a-fA-F]{2})*$`
+		if max, ok := p["max"].(int); ok {
+			node["minLength"], node["maxLength"] = 2*p["min"].(int), 2*max
+		}
+	case "hex_color":
+		node["pattern"] = `^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`
+	case "base64", "base64url":
+		node["contentEncoding"] = rule.Name
+	case "be_true":
+		node["const"] = true
+	case "be_false":
+		node["const"] = false
+	case "any_of", "all_of":
+		var subs []map[string]any
+		for _, inner := range p["rules"].([]validator.Rule) {
+			sub := map[string]any{}
+			ruleKeywords[T](inner, sub)
+			if len(sub) == 0 && rule.Name == "any_of" {
+				// an alternative without keywords accepts anything
+				return
+			}
+			if len(sub) > 0 {
+				subs = append(subs, sub)
+			}
+		}
+		if len(subs) > 0 {
+			node[lo.Ternary(rule.Name == "any_of", "anyOf", "allOf")] = subs
+		}
+	default:
+		// validator.Not keeps the inverted rule
+		if inner, ok := p["rule"].(validator.Rule); ok && strings.HasPrefix(rule.Name, "not_") {
+			sub := map[string]any{}
+			ruleKeywords[T](inner, sub)
+			if len(sub) > 0 {
+				node["not"] = sub
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kcmvp/xql"
+	"github.com/kcmvp/xql/sample/entity"
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_JSONSchema(t *testing.T) {
+	address := WithFields(
+		Field[string]("city", validator.LengthBetween(2, 40)),
+		Field[string]("zip").Optional(),
+	).AllowUnknownFields()
+	schema := WithFields(
+		Field[string]("name", validator.MinLength(3), validator.MaxLength(20)),
+		Field[string]("email", validator.Email()),
+		Field[string]("homepage", validator.When(func(s string) bool { return s != "" }, validator.URL())).Optional(),
+		Field[int]("age", validator.Between(18, 120)),
+		Field[string]("bio", validator.RunesBetween(1, 140)).Optional(),
+		Field[uint16]("port", validator.Port[uint16]()).Optional(),
+		Field[string]("day", validator.TimeFormat(time.DateOnly)).Optional(),
+		Field[uint8]("level", validator.MultipleOf[uint8](5)).Optional(),
+		Field[float64]("score", validator.Gt(0.0), validator.Lte(5.0)),
+		Field[bool]("terms", validator.BeTrue()),
+		Field[string]("role", validator.OneOf("admin", "user")),
+		Field[string]("nick", validator.Not(validator.OneOf("root"))).Optional(),
+		Field[string]("ref", validator.AnyOf(validator.NumericString(), validator.Email())).Optional(),
+		Field[string]("code", validator.AllOf(validator.MinLength(2), validator.Match("X*"))).Optional(),
+		Field[string]("pin", validator.NumericString()).Optional(),
+		Field[string]("token", validator.Base64()).Optional(),
+		Field[string]("jwt", validator.Base64URL()).Optional(),
+		Field[string]("digest", validator.Hex(32, 32)).Optional(),
+		Field[string]("slug", validator.Slug(validator.SlugMaxLength(32), validator.SlugChars("_"))).Optional(),
+		Field[time.Time]("birthday", validator.Lt(time.Now())).Optional(),
+		ArrayField[string]("tags", validator.MinLength(1)),
+		ObjectField("address", address),
+		ArrayOfObjectField("history", address).Optional(),
+	)
+	doc, err := schema.JSONSchema()
+	require.NoError(t, err)
+	addressSchema := `{
+		"type": "object",
+		"properties": {
+			"city": {"type": "string", "minLength": 2, "maxLength": 40},
+			"zip":  {"type": "string"}
+		},
+		"required": ["city"]
+	}`
+	require.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["name", "email", "age", "score", "terms", "role", "tags", "address"],
+		"properties": {
+			"name":     {"type": "string", "minLength": 3, "maxLength": 20},
+			"email":    {"type": "string", "format": "email"},
+			"homepage": {"type": "string"},
+			"age":      {"type": "integer", "minimum": 18, "maximum": 120},
+			"bio":      {"type": "string", "minLength": 1, "maxLength": 140},
+			"port":     {"type": "integer", "minimum": 1, "maximum": 65535},
+			"day":      {"type": "string", "format": "date"},
+			"level":    {"type": "integer", "minimum": 0, "multipleOf": 5},
+			"score":    {"type": "number", "exclusiveMinimum": 0, "maximum": 5},
+			"terms":    {"type": "boolean", "const": true},
+			"role":     {"type": "string", "enum": ["admin", "user"]},
+			"nick":     {"type": "string", "not": {"enum": ["root"]}},
+			"ref":      {"type": "string", "anyOf": [{"pattern": "^[0-9]*$"}, {"format": "email"}]},
+			"code":     {"type": "string", "allOf": [{"minLength": 2}]},
+			"pin":      {"type": "string", "pattern": "^[0-9]*$"},
+			"token":    {"type": "string", "contentEncoding": "base64"},
+			"jwt":      {"type": "string", "contentEncoding": "base64url"},
+			"digest":   {"type": "string", "pattern": "^([0-9a-fA-F]{2})*$", "minLength": 64, "maxLength": 64},
+			"slug":     {"type": "string", "pattern": "^[a-z0-9_]+(-[a-z0-9_]+)*$", "maxLength": 32},
+			"birthday": {"type": "string", "format": "date-time"},
+			"tags":     {"type": "array", "items": {"type": "string", "minLength": 1}},
+			"address":  `+addressSchema+`,
+			"history":  {"type": "array", "items": `+addressSchema+`}
+		}
+	}`, string(doc))
+}
+
+func TestSchema_JSONSchemaPersistentConstraints(t *testing.T) {
+	email := xql.NewField[entity.Account, string]("email", "Email", xql.MaxLength(64), xql.Email())
+	age := xql.NewField[entity.Account, int]("age", "Age", xql.Between(18, 120))
+	schema := WithFields(PersistentField(email, validator.MinLength(3)), PersistentField(age))
+
+	require.Equal(t, []validator.Rule{
+		{Name: "max_length", Params: map[string]any{"max": 64}},
+		{Name: "email", Params: map[string]any{}},
+		{Name: "min_length", Params: map[string]any{"min": 3}},
+	}, PersistentField(email, validator.MinLength(3)).Rules())
+
+	doc, err := schema.JSONSchema()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["Email", "Age"],
+		"properties": {
+			"Email": {"type": "string", "format": "email", "minLength": 3, "maxLength": 64},
+			"Age":   {"type": "integer", "minimum": 18, "maximum": 120}
+		}
+	}`, string(doc))
+
+	// persistent rules carry their parameters into localized messages
+	RegisterMessages("it", map[string]string{"max_length": "al massimo {max} caratteri"})
+	res := schema.ValidateLocalized(`{"Email":"`+strings.Repeat("a", 60)+`@example.com","Age":30}`, "it")
+	require.Equal(t, map[string]string{"Email": "al massimo 64 caratteri"}, res.Error().(*validationError).Errors())
+}
@@ -0,0 +1,49 @@
+package view
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// MaxErrors stops validation once n errors are found, for large payloads
+// where collecting every error is wasteful. The returned validation error
+// is partial: it holds at most n errors, the first ones by path when more
+// were found at once. It returns the same Schema pointer for chaining.
+func (s *Schema) MaxErrors(n int) *Schema {
+	lo.Assertf(n > 0, "xql: MaxErrors must be positive")
+	s.maxErrors = n
+	return s
+}
+
+// FailFast stops validation at the first error, it is MaxErrors(1).
+func (s *Schema) FailFast() *Schema {
+	return s.MaxErrors(1)
+}
+
+// full reports whether errs reached the error limit of the run.
+func (st walkState) full(errs *validationError) bool {
+	return st.maxErrors > 0 && len(errs.errors) >= st.maxErrors
+}
+
+// truncate returns e limited to its first n errors by path, e itself when
+// it holds no more.
+func (e *validationError) truncate(n int) *validationError {
+	type leaf struct {
+		path string
+		err  error
+	}
+	var leaves []leaf
+	plain := *e
+	plain.style = PathBracket
+	plain.walk("", func(path string, err error) { leaves = append(leaves, leaf{path, err}) })
+	if len(leaves) <= n {
+		return e
+	}
+	sort.SliceStable(leaves, func(i, j int) bool { return leaves[i].path < leaves[j].path })
+	out := &validationError{style: e.style}
+	for _, l := range leaves[:n] {
+		out.add(l.path, l.err)
+	}
+	return out
+}
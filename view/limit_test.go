@@ -0,0 +1,90 @@
+package view
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_MaxErrors(t *testing.T) {
+	item := WithFields(Field[string]("sku", validator.MinLength(3)))
+	fields := func() []ViewField {
+		return []ViewField{
+			Field[string]("a"),
+			Field[string]("b"),
+			Field[int]("c"),
+			ArrayField[int]("nums").Optional(),
+			ArrayOfObjectField("items", item).Optional(),
+		}
+	}
+	tests := []struct {
+		name   string
+		schema *Schema
+		json   string
+		want   map[string]string
+	}{
+		{
+			name:   "all errors",
+			schema: WithFields(fields()...),
+			json:   `{"c":"x"}`,
+			want: map[string]string{
+				"a": "a is required but not found",
+				"b": "b is required but not found",
+				"c": "type mismatch: expected int but got raw type String",
+			},
+		},
+		{
+			name:   "fail fast",
+			schema: WithFields(fields()...).FailFast(),
+			json:   `{"c":"x"}`,
+			want:   map[string]string{"a": "a is required but not found"},
+		},
+		{
+			name:   "max errors",
+			schema: WithFields(fields()...).MaxErrors(2),
+			json:   `{"c":"x"}`,
+			want: map[string]string{
+				"a": "a is required but not found",
+				"b": "b is required but not found",
+			},
+		},
+		{
+			name:   "stops inside arrays",
+			schema: WithFields(fields()...).MaxErrors(2),
+			json:   `{"a":"a","b":"b","c":1,"nums":["x","y","z"],"items":[{"sku":"1"}]}`,
+			want: map[string]string{
+				"nums[0]": "type mismatch: expected int but got raw type String",
+				"nums[1]": "type mismatch: expected int but got raw type String",
+			},
+		},
+		{
+			name:   "nested limit",
+			schema: WithFields(fields()...).FailFast(),
+			json:   `{"a":"a","b":"b","c":1,"items":[{"sku":"1"},{"sku":"2"}]}`,
+			want:   map[string]string{"items[0].sku": "length must be at least 3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verr *validationError
+			require.ErrorAs(t, tt.schema.Validate(tt.json).Error(), &verr)
+			require.Equal(t, tt.want, verr.Errors())
+		})
+	}
+
+	t.Run("form", func(t *testing.T) {
+		var verr *validationError
+		require.ErrorAs(t, WithFields(fields()...).FailFast().ValidateForm(url.Values{}).Error(), &verr)
+		require.Len(t, verr.Errors(), 1)
+	})
+
+	t.Run("array", func(t *testing.T) {
+		var verr *validationError
+		require.ErrorAs(t, WithFields(Field[string]("sku")).MaxErrors(2).ValidateArray(`[{},{},{},{}]`, 0, 0).Error(), &verr)
+		require.Equal(t, map[string]string{"[0].sku": "sku is required but not found", "[1].sku": "sku is required but not found"}, verr.Errors())
+	})
+
+	require.Panics(t, func() { WithFields(Field[string]("a")).MaxErrors(0) })
+}
@@ -0,0 +1,134 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+)
+
+// DictField is the ViewField of a free-form JSON object whose values share
+// one type, see MapField.
+type DictField[V validator.FieldType] struct {
+	// value parses and validates each entry of the object.
+	value    *JSONField[V]
+	required bool
+	minKeys  int
+	maxKeys  int
+}
+
+var _ ViewField = (*DictField[string])(nil)
+
+// MapField creates a required field holding a JSON object with arbitrary
+// keys and values of type V, such as labels or metadata; every value is
+// parsed as V and checked by the given validators:
+//
+//	view.MapField[string]("labels", validator.MaxLength(63)).MaxKeys(64)
+//
+// The validated value is stored as map[string]V. Errors are reported per
+// entry under "name.key". Map fields only accept JSON, not URL parameters.
+func MapField[V validator.FieldType](name string, vfs ...validator.ValidateFunc[V]) *DictField[V] {
+	return &DictField[V]{value: trait[V](name, false, false, nil, vfs...), required: true}
+}
+
+// Optional marks the field as optional.
+func (f *DictField[V]) Optional() *DictField[V] {
+	f.required = false
+	return f
+}
+
+// MinKeys requires the object to have at least n keys.
+func (f *DictField[V]) MinKeys(n int) *DictField[V] {
+	lo.Assertf(n >= 0, "xql: MinKeys must not be negative for field '%s'", f.Name())
+	f.minKeys = n
+	return f
+}
+
+// MaxKeys limits the object to at most n keys.
+func (f *DictField[V]) MaxKeys(n int) *DictField[V] {
+	lo.Assertf(n > 0, "xql: MaxKeys must be positive for field '%s'", f.Name())
+	f.maxKeys = n
+	return f
+}
+
+func (f *DictField[V]) Scope() string         { return "" }
+func (f *DictField[V]) QualifiedName() string { return f.value.QualifiedName() }
+func (f *DictField[V]) Name() string          { return f.value.Name() }
+func (f *DictField[V]) UniqueName() string    { return f.value.UniqueName() }
+func (f *DictField[V]) IsArray() bool         { return false }
+func (f *DictField[V]) IsObject() bool        { return true }
+func (f *DictField[V]) Required() bool        { return f.required }
+
+func (f *DictField[V]) validate(node gjson.Result, st walkState) mo.Result[any] {
+	if !node.IsObject() {
+		return mo.Err[any](fmt.Errorf("field '%s': expected a JSON object", f.Name()))
+	}
+	entries := node.Map()
+	switch {
+	case len(entries) < f.minKeys:
+		return mo.Err[any](fmt.Errorf("field '%s': must have at least %d keys", f.Name(), f.minKeys))
+	case f.maxKeys > 0 && len(entries) > f.maxKeys:
+		return mo.Err[any](fmt.Errorf("field '%s': must have at most %d keys", f.Name(), f.maxKeys))
+	}
+	errs := &validationError{}
+	values := make(map[string]V, len(entries))
+	for key, entry := range entries {
+		if st.full(errs) {
+			break
+		}
+		if entry.IsObject() || entry.IsArray() {
+			errs.add(key, fmt.Errorf("field '%s': expected a single value for key '%s'", f.Name(), key))
+			continue
+		}
+		rs := f.value.validate(entry, st)
+		if rs.IsError() {
+			var nested *validationError
+			if errors.As(rs.Error(), &nested) {
+				// several validators failed for the value
+				for _, err := range nested.errors[f.Name()] {
+					errs.add(key, err)
+				}
+			} else {
+				errs.add(key, rs.Error())
+			}
+			continue
+		}
+		values[key] = rs.MustGet().(V)
+	}
+	return lo.Ternary(errs.err() != nil, mo.Err[any](errs.err()), mo.Ok[any](values))
+}
+
+func (f *DictField[V]) validateRaw(string) mo.Result[any] {
+	return mo.Err[any](fmt.Errorf("field '%s': map fields only accept a JSON object", f.Name()))
+}
+
+func (f *DictField[V]) validateRawArray([]string) mo.Result[any] {
+	return f.validateRaw("")
+}
+
+func (f *DictField[V]) optional(map[*Schema]*Schema) ViewField {
+	cp := *f
+	cp.required = false
+	return &cp
+}
+
+func (f *DictField[V]) embeddedObject() mo.Option[*Schema] {
+	return mo.None[*Schema]()
+}
+
+func (f *DictField[V]) jsonSchema(refs map[*Schema]string) map[string]any {
+	node := map[string]any{
+		"type":                 "object",
+		"additionalProperties": f.value.jsonSchema(refs),
+	}
+	if f.minKeys > 0 {
+		node["minProperties"] = f.minKeys
+	}
+	if f.maxKeys > 0 {
+		node["maxProperties"] = f.maxKeys
+	}
+	return node
+}
@@ -0,0 +1,71 @@
+package view
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapField(t *testing.T) {
+	schema := WithFields(
+		MapField[string]("labels", validator.MaxLength(5)).MinKeys(1).MaxKeys(2),
+		MapField[int]("quotas", validator.Gte(0)).Optional(),
+	)
+	tests := []struct {
+		name   string
+		json   string
+		errKey string
+	}{
+		{name: "valid", json: `{"labels":{"env":"prod","team":"core"},"quotas":{"cpu":4}}`},
+		{name: "too many keys", json: `{"labels":{"a":"1","b":"2","c":"3"}}`, errKey: "labels"},
+		{name: "too few keys", json: `{"labels":{}}`, errKey: "labels"},
+		{name: "value validator", json: `{"labels":{"env":"production"}}`, errKey: "labels.env"},
+		{name: "value type", json: `{"labels":{"env":"prod"},"quotas":{"cpu":"4"}}`, errKey: "quotas.cpu"},
+		{name: "nested value", json: `{"labels":{"env":{"a":"b"}}}`, errKey: "labels.env"},
+		{name: "not an object", json: `{"labels":["a"]}`, errKey: "labels"},
+		{name: "required", json: `{}`, errKey: "labels"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := schema.Validate(tc.json)
+			if tc.errKey == "" {
+				require.NoError(t, res.Error())
+				return
+			}
+			var verr *validationError
+			require.ErrorAs(t, res.Error(), &verr)
+			require.Contains(t, verr.errors, tc.errKey)
+		})
+	}
+
+	t.Run("values", func(t *testing.T) {
+		res := schema.Validate(`{"labels":{"env":"prod"},"quotas":{"cpu":4}}`)
+		require.NoError(t, res.Error())
+		require.Equal(t, map[string]string{"env": "prod"}, res.MustGet().Get("labels").MustGet())
+		require.Equal(t, map[string]int{"cpu": 4}, res.MustGet().Get("quotas").MustGet())
+	})
+
+	t.Run("coerce", func(t *testing.T) {
+		res := WithFields(MapField[int]("quotas")).Coerce().Validate(`{"quotas":{"cpu":"4"}}`)
+		require.NoError(t, res.Error())
+	})
+
+	t.Run("url param", func(t *testing.T) {
+		res := schema.ValidateValues(`{}`, url.Values{"labels": {"a"}})
+		require.ErrorContains(t, res.Error(), "embedded object")
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		data, err := schema.JSONSchema()
+		require.NoError(t, err)
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(data, &doc))
+		labels := doc["properties"].(map[string]any)["labels"].(map[string]any)
+		require.Equal(t, "object", labels["type"])
+		require.EqualValues(t, 2, labels["maxProperties"])
+		require.EqualValues(t, 5, labels["additionalProperties"].(map[string]any)["maxLength"])
+	})
+}
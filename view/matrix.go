@@ -0,0 +1,131 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+)
+
+// GridField is the ViewField of an array of arrays, see MatrixField.
+type GridField[T validator.FieldType] struct {
+	// element parses and validates each element of the rows.
+	element   *JSONField[T]
+	required  bool
+	rowLength int
+}
+
+var _ ViewField = (*GridField[float64])(nil)
+
+// MatrixField creates a required field holding an array of arrays of T,
+// such as coordinates; every element is parsed as T and checked by the
+// given validators:
+//
+//	view.MatrixField[float64]("polygon", validator.Between(-180.0, 180.0)).RowLength(2)
+//
+// The validated value is stored as [][]T. Errors are reported per element
+// under "name[row][col]". Matrix fields only accept JSON, not URL
+// parameters.
+func MatrixField[T validator.FieldType](name string, vfs ...validator.ValidateFunc[T]) *GridField[T] {
+	return &GridField[T]{element: trait[T](name, false, false, nil, vfs...), required: true}
+}
+
+// Optional marks the field as optional.
+func (f *GridField[T]) Optional() *GridField[T] {
+	f.required = false
+	return f
+}
+
+// RowLength requires every row to have exactly n elements.
+func (f *GridField[T]) RowLength(n int) *GridField[T] {
+	lo.Assertf(n > 0, "xql: RowLength must be positive for field '%s'", f.Name())
+	f.rowLength = n
+	return f
+}
+
+func (f *GridField[T]) Scope() string         { return "" }
+func (f *GridField[T]) QualifiedName() string { return f.element.QualifiedName() }
+func (f *GridField[T]) Name() string          { return f.element.Name() }
+func (f *GridField[T]) UniqueName() string    { return f.element.UniqueName() }
+func (f *GridField[T]) IsArray() bool         { return true }
+func (f *GridField[T]) IsObject() bool        { return false }
+func (f *GridField[T]) Required() bool        { return f.required }
+
+func (f *GridField[T]) validate(node gjson.Result, st walkState) mo.Result[any] {
+	if !node.IsArray() {
+		return mo.Err[any](fmt.Errorf("field '%s': expected a JSON array of arrays", f.Name()))
+	}
+	errs := &validationError{}
+	var rows [][]T
+	for i, row := range node.Array() {
+		if st.full(errs) {
+			break
+		}
+		key := fmt.Sprintf("%s[%d]", f.Name(), i)
+		if !row.IsArray() {
+			errs.add(key, fmt.Errorf("field '%s': expected a JSON array", f.Name()))
+			continue
+		}
+		elements := row.Array()
+		if f.rowLength > 0 && len(elements) != f.rowLength {
+			errs.add(key, fmt.Errorf("field '%s': row must have exactly %d elements", f.Name(), f.rowLength))
+			continue
+		}
+		values := make([]T, 0, len(elements))
+		for j, el := range elements {
+			if st.full(errs) {
+				break
+			}
+			elKey := fmt.Sprintf("%s[%d]", key, j)
+			if el.IsArray() || el.IsObject() {
+				errs.add(elKey, fmt.Errorf("field '%s': expected a single value", f.Name()))
+				continue
+			}
+			rs := f.element.validate(el, st)
+			if rs.IsError() {
+				var nested *validationError
+				if errors.As(rs.Error(), &nested) {
+					// several validators failed for the element
+					for _, err := range nested.errors[f.Name()] {
+						errs.add(elKey, err)
+					}
+				} else {
+					errs.add(elKey, rs.Error())
+				}
+				continue
+			}
+			values = append(values, rs.MustGet().(T))
+		}
+		rows = append(rows, values)
+	}
+	return lo.Ternary(errs.err() != nil, mo.Err[any](errs.err()), mo.Ok[any](rows))
+}
+
+func (f *GridField[T]) validateRaw(string) mo.Result[any] {
+	return mo.Err[any](fmt.Errorf("field '%s': matrix fields only accept a JSON array of arrays", f.Name()))
+}
+
+func (f *GridField[T]) validateRawArray([]string) mo.Result[any] {
+	return f.validateRaw("")
+}
+
+func (f *GridField[T]) embeddedObject() mo.Option[*Schema] {
+	return mo.None[*Schema]()
+}
+
+func (f *GridField[T]) optional(map[*Schema]*Schema) ViewField {
+	cp := *f
+	cp.required = false
+	return &cp
+}
+
+func (f *GridField[T]) jsonSchema(refs map[*Schema]string) map[string]any {
+	row := map[string]any{"type": "array", "items": f.element.jsonSchema(refs)}
+	if f.rowLength > 0 {
+		row["minItems"], row["maxItems"] = f.rowLength, f.rowLength
+	}
+	return map[string]any{"type": "array", "items": row}
+}
@@ -0,0 +1,73 @@
+package view
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrixField(t *testing.T) {
+	schema := WithFields(
+		MatrixField[float64]("polygon", validator.Between(-180.0, 180.0)).RowLength(2),
+		MatrixField[int]("grid").Optional(),
+	)
+	tests := []struct {
+		name   string
+		json   string
+		errKey string
+	}{
+		{name: "valid", json: `{"polygon":[[1.5,2],[3,4]],"grid":[[1],[2,3],[]]}`},
+		{name: "element validator", json: `{"polygon":[[1,2],[3,400]]}`, errKey: "polygon[1][1]"},
+		{name: "element type", json: `{"polygon":[[1,"x"]]}`, errKey: "polygon[0][1]"},
+		{name: "row length", json: `{"polygon":[[1,2,3]]}`, errKey: "polygon[0]"},
+		{name: "row not an array", json: `{"polygon":[1]}`, errKey: "polygon[0]"},
+		{name: "nested too deep", json: `{"grid":[[[1]]],"polygon":[]}`, errKey: "grid[0][0]"},
+		{name: "not an array", json: `{"polygon":1}`, errKey: "polygon"},
+		{name: "required", json: `{}`, errKey: "polygon"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := schema.Validate(tc.json)
+			if tc.errKey == "" {
+				require.NoError(t, res.Error())
+				return
+			}
+			var verr ValidationError
+			require.ErrorAs(t, res.Error(), &verr)
+			require.Contains(t, verr.Errors(), tc.errKey)
+		})
+	}
+
+	t.Run("values", func(t *testing.T) {
+		res := schema.Validate(`{"polygon":[[1.5,2],[3,4]],"grid":[[1],[2,3]]}`)
+		require.NoError(t, res.Error())
+		require.Equal(t, [][]float64{{1.5, 2}, {3, 4}}, res.MustGet().Get("polygon").MustGet())
+		require.Equal(t, [][]int{{1}, {2, 3}}, res.MustGet().Get("grid").MustGet())
+	})
+
+	t.Run("bind", func(t *testing.T) {
+		var dst struct {
+			Polygon [][]float64 `json:"polygon"`
+		}
+		require.NoError(t, BindInto(schema.Validate(`{"polygon":[[1,2]]}`).MustGet(), &dst))
+		require.Equal(t, [][]float64{{1, 2}}, dst.Polygon)
+	})
+
+	t.Run("url param", func(t *testing.T) {
+		require.Error(t, schema.ValidateValues(`{}`, url.Values{"polygon": {"1"}}).Error())
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		data, err := schema.JSONSchema()
+		require.NoError(t, err)
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(data, &doc))
+		polygon := doc["properties"].(map[string]any)["polygon"].(map[string]any)
+		row := polygon["items"].(map[string]any)
+		require.EqualValues(t, 2, row["maxItems"])
+		require.Equal(t, "number", row["items"].(map[string]any)["type"])
+	})
+}
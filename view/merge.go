@@ -0,0 +1,98 @@
+package view
+
+import (
+	"github.com/kcmvp/xql/internal"
+	"github.com/samber/lo"
+)
+
+// Merge returns a copy of vo overlaid with the fields present in patch: a
+// value in patch replaces the field, and an explicit null (see Nullable)
+// removes it. Embedded objects are replaced as a whole; use
+// MergeDeep for JSON merge patch (RFC 7396) semantics. vo and patch are not
+// modified.
+//
+//	stored := schema.Validate(current).MustGet()
+//	patch := schema.Partial().Validate(body).MustGet()
+//	updated := stored.MergeDeep(patch)
+func (vo valueObject) Merge(patch ValueObject) ValueObject {
+	return vo.merge(patch, false)
+}
+
+// MergeDeep is Merge applied recursively: an embedded object of patch is
+// merged into the object of vo under the same key instead of replacing it,
+// following JSON merge patch (RFC 7396). Arrays are replaced as a whole.
+func (vo valueObject) MergeDeep(patch ValueObject) ValueObject {
+	return vo.merge(patch, true)
+}
+
+func (vo valueObject) merge(patch ValueObject, deep bool) ValueObject {
+	if patch == nil {
+		return vo
+	}
+	out := valueObject{Data: mergeData(vo.Data, asData(patch), deep), unknown: vo.unknown, sensitive: vo.sensitive, encoded: vo.encoded}
+	if p, ok := patch.(valueObject); ok {
+		out.sensitive = lo.Union(vo.sensitive, p.sensitive)
+		out.encoded = lo.Union(vo.encoded, p.encoded)
+	}
+	return out
+}
+
+// mergeData returns a copy of base overlaid with patch.
+func mergeData(base, patch internal.Data, deep bool) internal.Data {
+	out := make(internal.Data, len(base)+len(patch))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, pv := range patch {
+		if pv == internal.Null {
+			delete(out, k)
+			continue
+		}
+		// maps keyed by the segments of qualified names are merged at any depth
+		if bd, ok := out[k].(internal.Data); ok {
+			if pd, ok := pv.(internal.Data); ok {
+				out[k] = mergeData(bd, pd, deep)
+				continue
+			}
+		}
+		if deep {
+			if merged, ok := mergeObjects(out[k], pv); ok {
+				out[k] = merged
+				continue
+			}
+		}
+		out[k] = pv
+	}
+	return out
+}
+
+// mergeObjects deeply merges patch into base when both are objects, keeping
+// the representation of base.
+func mergeObjects(base, patch any) (any, bool) {
+	pd, ok := objectData(patch)
+	if !ok {
+		return nil, false
+	}
+	switch b := base.(type) {
+	case internal.Data:
+		return mergeData(b, pd, true), true
+	case valueObject:
+		return b.MergeDeep(valueObject{Data: pd}), true
+	case ValueObject:
+		return valueObject{Data: mergeData(asData(b), pd, true)}, true
+	default:
+		return nil, false
+	}
+}
+
+// objectData returns the fields of an embedded object value.
+func objectData(v any) (internal.Data, bool) {
+	switch v := v.(type) {
+	case internal.Data:
+		return v, true
+	case ValueObject:
+		return asData(v), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,70 @@
+package view
+
+import (
+	"encoding/json"
+	"testing"
+
+	acct "github.com/kcmvp/xql/sample/gen/field/account"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueObject_Merge(t *testing.T) {
+	schema := WithFields(
+		Field[string]("name"),
+		Field[string]("bio").Nullable().Optional(),
+		ArrayField[string]("tags").Optional(),
+		ObjectField("address", WithFields(
+			Field[string]("city"),
+			Field[string]("zip").Nullable().Optional(),
+		)),
+	).Partial()
+	base := schema.Validate(`{"name":"a","bio":"hello","tags":["x","y"],"address":{"city":"Paris","zip":"75001"}}`).MustGet()
+
+	tests := []struct {
+		name  string
+		patch string
+		deep  bool
+		want  string
+	}{
+		{name: "overlay", patch: `{"name":"b","tags":["z"]}`,
+			want: `{"name":"b","bio":"hello","tags":["z"],"address":{"city":"Paris","zip":"75001"}}`},
+		{name: "null removes", patch: `{"bio":null}`,
+			want: `{"name":"a","tags":["x","y"],"address":{"city":"Paris","zip":"75001"}}`},
+		{name: "object replaced", patch: `{"address":{"city":"Lyon"}}`,
+			want: `{"name":"a","bio":"hello","tags":["x","y"],"address":{"city":"Lyon"}}`},
+		{name: "object merged deep", patch: `{"address":{"city":"Lyon"}}`, deep: true,
+			want: `{"name":"a","bio":"hello","tags":["x","y"],"address":{"city":"Lyon","zip":"75001"}}`},
+		{name: "nested null removes deep", patch: `{"address":{"zip":null}}`, deep: true,
+			want: `{"name":"a","bio":"hello","tags":["x","y"],"address":{"city":"Paris"}}`},
+		{name: "empty patch", patch: `{}`, deep: true,
+			want: `{"name":"a","bio":"hello","tags":["x","y"],"address":{"city":"Paris","zip":"75001"}}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			patch := schema.Validate(tc.patch).MustGet()
+			merged := base.Merge(patch)
+			if tc.deep {
+				merged = base.MergeDeep(patch)
+			}
+			data, err := json.Marshal(merged)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.want, string(data))
+		})
+	}
+
+	t.Run("base is not modified", func(t *testing.T) {
+		base.MergeDeep(schema.Validate(`{"name":"b","address":{"zip":null}}`).MustGet())
+		require.Equal(t, "a", base.MstString("name"))
+		require.Equal(t, "75001", base.MstString("address.zip"))
+	})
+
+	t.Run("persistent fields", func(t *testing.T) {
+		schema := WithXQLFields(acct.Email, acct.Nickname)
+		base := schema.Validate(`{"Email":"a@b.c","Nickname":"bob"}`).MustGet()
+		patch := schema.Partial().Validate(`{"Nickname":"rob"}`).MustGet()
+		require.Equal(t, map[string]any{
+			acct.Email.QualifiedName():    "a@b.c",
+			acct.Nickname.QualifiedName(): "rob",
+		}, map[string]any(base.Merge(patch).FlatMap()))
+	})
+}
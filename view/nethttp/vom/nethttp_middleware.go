@@ -0,0 +1,83 @@
+package vom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/kcmvp/xql/internal"
+	"github.com/kcmvp/xql/view"
+)
+
+// EnrichFunc defines a function type for enriching the validated data.
+type EnrichFunc func(*http.Request) map[string]any
+
+// ParamsFunc extracts path parameters from a request, for routers that do
+// not populate http.Request.PathValue.
+type ParamsFunc func(*http.Request) map[string]string
+
+var _enrich EnrichFunc
+var once sync.Once
+
+// SetGlobalEnricher sets a function to be called for enriching the validated data
+// when validation is successful. This function is set only once.
+func SetGlobalEnricher(enrich EnrichFunc) {
+	once.Do(func() {
+		_enrich = enrich
+	})
+}
+
+// Bind creates a net/http middleware that validates the request against schema
+// with view.Schema.ValidateRequest: the JSON body, the query string and the
+// path parameters. It fits any router built on http.Handler middleware:
+//
+//	// net/http ServeMux (Go 1.22 patterns) and chi v5.1+ populate PathValue:
+//	mux.Handle("PUT /orders/{ordId}", vom.Bind(schema)(handler))
+//	r.With(vom.Bind(schema)).Put("/orders/{ordId}", handler) // chi
+//
+//	// routers that keep parameters elsewhere pass a ParamsFunc:
+//	vom.Bind(schema, func(r *http.Request) map[string]string { ... })
+//
+// If validation fails, it responds with 400 Bad Request without calling next.
+// The JSON body lists the message and code of every invalid field, see
+// view.ErrorBody. On success the validated object, enriched by the
+// EnrichFunc set with SetGlobalEnricher, is stored in the request context;
+// retrieve it with ValueObject.
+func Bind(schema *view.Schema, params ...ParamsFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			opts := []view.RequestOption{view.WithPathValues()}
+			for _, p := range params {
+				opts = append(opts, view.WithURLParams(p(r)))
+			}
+			result := schema.ValidateRequest(r, opts...)
+			if result.IsError() {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(view.ErrorBody(result.Error()))
+				return
+			}
+			data := result.MustGet()
+			if _enrich != nil {
+				for k, v := range _enrich(r) {
+					data.Add(k, v)
+				}
+			}
+			// Store the validated object in the request's context for the main handler to use.
+			ctx := context.WithValue(r.Context(), internal.ViewObjectKey, data)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ValueObject retrieves the validated ValueObject from the request context.
+// It returns nil if the object is not found.
+func ValueObject(r *http.Request) view.ValueObject {
+	if val := r.Context().Value(internal.ViewObjectKey); val != nil {
+		if vo, ok := val.(view.ValueObject); ok {
+			return vo
+		}
+	}
+	return nil
+}
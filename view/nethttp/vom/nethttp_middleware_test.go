@@ -0,0 +1,107 @@
+package vom
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/kcmvp/xql/view"
+	"github.com/stretchr/testify/require"
+)
+
+var orderVO = view.WithFields(
+	view.Field[string]("CustomerID"),                        // From JSON body
+	view.Field[float64]("Amount", validator.Gt[float64](0)), // From JSON body
+	view.Field[string]("ordId"),                             // From path parameter
+	view.Field[string]("source").Optional(),                 // From query parameter
+)
+
+func echoHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vo := ValueObject(r)
+		require.NotNil(t, vo)
+		_ = json.NewEncoder(w).Encode(vo)
+	})
+}
+
+func TestBind(t *testing.T) {
+	SetGlobalEnricher(func(r *http.Request) map[string]any {
+		return map[string]any{"traceId": "test-trace-id"}
+	})
+	t.Cleanup(func() {
+		once = sync.Once{}
+		_enrich = nil
+	})
+	mux := http.NewServeMux()
+	mux.Handle("POST /orders/{ordId}", Bind(orderVO)(echoHandler(t)))
+
+	tests := []struct {
+		name      string
+		url       string
+		body      string
+		wantCode  int
+		want      map[string]any
+		wantErr   map[string]string // message substring per invalid field
+		wantCodes map[string]string
+	}{
+		{
+			name:     "valid",
+			url:      "/orders/o-1?source=web",
+			body:     `{"CustomerID":"c-1","Amount":9.5}`,
+			wantCode: http.StatusOK,
+			want:     map[string]any{"CustomerID": "c-1", "Amount": 9.5, "ordId": "o-1", "source": "web", "traceId": "test-trace-id"},
+		},
+		{
+			name: "invalid amount", url: "/orders/o-1", body: `{"CustomerID":"c-1","Amount":0}`, wantCode: http.StatusBadRequest,
+			wantErr: map[string]string{"Amount": "must be greater than"}, wantCodes: map[string]string{"Amount": "number.gt"},
+		},
+		{
+			name: "unknown query", url: "/orders/o-1?foo=1", body: `{"CustomerID":"c-1","Amount":1}`, wantCode: http.StatusBadRequest,
+			wantErr: map[string]string{"foo": "unknown url parameter 'foo'"}, wantCodes: map[string]string{"foo": "invalid"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(tt.body)))
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+				var got struct {
+					Errors map[string]string `json:"errors"`
+					Codes  map[string]string `json:"codes"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				require.Len(t, got.Errors, len(tt.wantErr))
+				for field, msg := range tt.wantErr {
+					require.Contains(t, got.Errors[field], msg)
+				}
+				require.Equal(t, tt.wantCodes, got.Codes)
+				return
+			}
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBind_ParamsFunc(t *testing.T) {
+	// simulates a router keeping path parameters outside PathValue
+	params := func(r *http.Request) map[string]string {
+		return map[string]string{"ordId": strings.TrimPrefix(r.URL.Path, "/orders/")}
+	}
+	handler := Bind(orderVO, params)(echoHandler(t))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/orders/o-9", strings.NewReader(`{"CustomerID":"c-1","Amount":1}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"ordId":"o-9"`)
+}
+
+func TestValueObject_Missing(t *testing.T) {
+	require.Nil(t, ValueObject(httptest.NewRequest(http.MethodGet, "/", nil)))
+}
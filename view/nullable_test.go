@@ -0,0 +1,91 @@
+package view
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONField_Nullable(t *testing.T) {
+	address := WithFields(Field[string]("city").Nullable().Optional())
+	schema := WithFields(
+		Field[string]("name").Optional(),
+		Field[string]("nickname").Nullable().Optional(),
+		Field[int]("age").Nullable(),
+		ObjectField("address", address).Optional(),
+	)
+
+	t.Run("null is rejected by default", func(t *testing.T) {
+		require.Error(t, schema.Validate(`{"age":1,"name":null}`).Error())
+	})
+
+	t.Run("null versus absent", func(t *testing.T) {
+		res := schema.Validate(`{"nickname":null,"age":null,"address":{"city":null}}`)
+		require.NoError(t, res.Error())
+		vo := res.MustGet()
+		require.True(t, vo.IsNull("nickname"))
+		require.True(t, vo.IsNull("age"))
+		require.True(t, vo.IsNull("address.city"))
+		require.False(t, vo.IsNull("name"))
+		require.False(t, vo.String("nickname").IsPresent())
+		require.False(t, vo.Int("age").IsPresent())
+		require.Equal(t, []string{"address", "age", "nickname"}, vo.Fields())
+	})
+
+	t.Run("value", func(t *testing.T) {
+		res := schema.Validate(`{"nickname":"joe","age":3}`)
+		require.NoError(t, res.Error())
+		require.False(t, res.MustGet().IsNull("nickname"))
+		require.Equal(t, "joe", res.MustGet().MstString("nickname"))
+	})
+
+	t.Run("flat map and json", func(t *testing.T) {
+		vo := schema.Validate(`{"nickname":null,"age":2,"address":{"city":null}}`).MustGet()
+		flat := vo.FlatMap()
+		require.Contains(t, flat, "nickname")
+		require.Nil(t, flat["nickname"])
+		require.Contains(t, flat, "address.city")
+		require.Nil(t, flat["address.city"])
+		data, err := json.Marshal(vo)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"nickname":null,"age":2,"address":{"city":null}}`, string(data))
+	})
+
+	t.Run("bind clears", func(t *testing.T) {
+		type patch struct {
+			Nickname *string `json:"nickname"`
+			Age      int     `json:"age"`
+		}
+		nick := "old"
+		p := patch{Nickname: &nick, Age: 5}
+		require.NoError(t, BindInto(schema.Validate(`{"nickname":null,"age":null}`).MustGet(), &p))
+		require.Nil(t, p.Nickname)
+		require.Zero(t, p.Age)
+	})
+}
+
+func TestJSONField_NullableJSONSchema(t *testing.T) {
+	schema := WithFields(
+		Field[string]("nick").Nullable(),
+		Field[string]("role", validator.OneOf("admin", "user")).Nullable().Optional(),
+		ArrayField[int]("scores").Nullable().Optional(),
+		ObjectField("parent", SelfRef()).Nullable().Optional(),
+	)
+	require.NoError(t, schema.Validate(`{"nick":null,"role":null,"scores":null,"parent":null}`).Error())
+	doc, err := schema.JSONSchema()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["nick"],
+		"properties": {
+			"nick":   {"type": ["string", "null"]},
+			"role":   {"anyOf": [{"type": "string", "enum": ["admin", "user"]}, {"type": "null"}]},
+			"scores": {"type": ["array", "null"], "items": {"type": "integer"}},
+			"parent": {"anyOf": [{"$ref": "#"}, {"type": "null"}]}
+		}
+	}`, string(doc))
+}
@@ -0,0 +1,53 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// openAPIRefPrefix is the JSON pointer prefix of component schemas.
+const openAPIRefPrefix = "#/components/schemas/"
+
+// OpenAPIComponents returns an OpenAPI 3.1 Components Object holding one
+// component schema per entry of schemas, keyed by component name:
+//
+//	doc, err := view.OpenAPIComponents(map[string]*view.Schema{
+//		"Address": address,
+//		"Signup":  signup, // signup embeds address via ObjectField
+//	})
+//	// {"schemas":{"Address":{...},"Signup":{...,"address":{"$ref":"#/components/schemas/Address"}}}}
+//
+// OpenAPI 3.1 schema objects are JSON Schema 2020-12, so each component is
+// rendered as described by Schema.JSONSchema (without `$schema`). Nested
+// ObjectField/ArrayOfObjectField schemas that are themselves registered in
+// schemas are referenced with `$ref`; unregistered ones are inlined, which
+// is why every recursive schema (see SelfRef) must be registered. The
+// result is meant to be placed under the `components` key of an API document.
+func OpenAPIComponents(schemas map[string]*Schema) ([]byte, error) {
+	refs := make(map[*Schema]string, len(schemas))
+	for name, s := range schemas {
+		if s == nil {
+			return nil, fmt.Errorf("view: schema for component '%s' is nil", name)
+		}
+		if name == "" || strings.ContainsAny(name, "/~#") {
+			return nil, fmt.Errorf("view: invalid component name '%s'", name)
+		}
+		if other, ok := refs[s]; ok {
+			return nil, fmt.Errorf("view: schema registered as both '%s' and '%s'", strings.TrimPrefix(other, openAPIRefPrefix), name)
+		}
+		refs[s] = openAPIRefPrefix + name
+	}
+	for name, s := range schemas {
+		for _, r := range recursiveSchemas(s) {
+			if _, ok := refs[r]; !ok {
+				return nil, fmt.Errorf("view: component '%s' reaches a recursive schema that is not registered", name)
+			}
+		}
+	}
+	components := make(map[string]any, len(schemas))
+	for name, s := range schemas {
+		components[name] = s.jsonSchema(refs)
+	}
+	return json.Marshal(map[string]any{"schemas": components})
+}
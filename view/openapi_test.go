@@ -0,0 +1,68 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIComponents(t *testing.T) {
+	address := WithFields(Field[string]("city", validator.MinLength(2)))
+	phone := WithFields(Field[string]("number"))
+	signup := WithFields(
+		Field[string]("email", validator.Email()),
+		ObjectField("address", address),
+		ArrayOfObjectField("previous", address).Optional(),
+		ArrayOfObjectField("phones", phone).Optional(),
+	)
+
+	doc, err := OpenAPIComponents(map[string]*Schema{"Address": address, "Signup": signup})
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"schemas": {
+			"Address": {
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["city"],
+				"properties": {"city": {"type": "string", "minLength": 2}}
+			},
+			"Signup": {
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["email", "address"],
+				"properties": {
+					"email":    {"type": "string", "format": "email"},
+					"address":  {"$ref": "#/components/schemas/Address"},
+					"previous": {"type": "array", "items": {"$ref": "#/components/schemas/Address"}},
+					"phones":   {"type": "array", "items": {
+						"type": "object",
+						"additionalProperties": false,
+						"required": ["number"],
+						"properties": {"number": {"type": "string"}}
+					}}
+				}
+			}
+		}
+	}`, string(doc))
+}
+
+func TestOpenAPIComponents_Errors(t *testing.T) {
+	s := WithFields(Field[string]("name"))
+	tests := []struct {
+		name    string
+		schemas map[string]*Schema
+		wantErr string
+	}{
+		{"nil schema", map[string]*Schema{"A": nil}, "schema for component 'A' is nil"},
+		{"empty name", map[string]*Schema{"": s}, "invalid component name ''"},
+		{"slash in name", map[string]*Schema{"a/b": s}, "invalid component name 'a/b'"},
+		{"same schema twice", map[string]*Schema{"A": s, "B": s}, "registered as both"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := OpenAPIComponents(tt.schemas)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Partial(t *testing.T) {
+	address := WithFields(Field[string]("city"), Field[string]("zip", validator.ExactLength(5)))
+	create := WithFields(
+		Field[string]("name", validator.MinLength(2)),
+		Field[int]("age", validator.Gte(18)),
+		Field[string]("state").RequiredIf("name", "joe"),
+		ObjectField("address", address),
+		MapField[string]("labels"),
+	)
+	update := create.Partial()
+
+	require.Error(t, create.Validate(`{"name":"joe"}`).Error())
+
+	tests := []struct {
+		name string
+		json string
+		err  bool
+	}{
+		{name: "empty", json: `{}`},
+		{name: "single field", json: `{"age":20}`},
+		{name: "conditions dropped", json: `{"name":"joe"}`},
+		{name: "nested fields optional", json: `{"address":{"zip":"12345"}}`},
+		{name: "validators apply", json: `{"age":17}`, err: true},
+		{name: "nested validators apply", json: `{"address":{"zip":"1"}}`, err: true},
+		{name: "unknown field", json: `{"email":"a@x.io"}`, err: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := update.Validate(tc.json)
+			require.Equal(t, tc.err, res.IsError(), res.Error())
+		})
+	}
+
+	t.Run("original untouched", func(t *testing.T) {
+		for _, field := range create.fields {
+			// state is conditionally required
+			require.Equal(t, field.Name() != "state", field.Required(), field.Name())
+		}
+		require.Len(t, conditionsOf(create.fields[2]), 1)
+		require.True(t, address.fields[0].Required())
+	})
+}
@@ -0,0 +1,71 @@
+package view
+
+import (
+	"strings"
+)
+
+// PathStyle selects how the paths of validation errors are rendered, see
+// Schema.ErrorPaths.
+type PathStyle int
+
+const (
+	// PathBracket renders object keys dotted and array indexes in brackets,
+	// e.g. "items[1].id". It is the default.
+	PathBracket PathStyle = iota
+	// PathDotted renders every segment dotted, e.g. "items.1.id".
+	PathDotted
+	// PathPointer renders a JSON Pointer (RFC 6901), e.g. "/items/1/id".
+	PathPointer
+)
+
+// ErrorPaths sets the style of the paths reported by the validation errors
+// of the schema, so they match the conventions of the caller's clients:
+//
+//	schema.ErrorPaths(view.PathPointer).Validate(`{"items":[{"id":"x"}]}`)
+//	// errors: {"/items/0/id": "..."}
+//
+// It applies to ValidationError.Errors, Violations, Codes and the JSON
+// rendering of the error. It returns the same Schema pointer for chaining.
+func (s *Schema) ErrorPaths(style PathStyle) *Schema {
+	if s == nil {
+		return s
+	}
+	s.pathStyle = style
+	return s
+}
+
+// render converts a path in the bracket style to style.
+func (style PathStyle) render(path string) string {
+	if style == PathBracket {
+		return path
+	}
+	segments := pathSegments(path)
+	if style == PathDotted {
+		return strings.Join(segments, ".")
+	}
+	var b strings.Builder
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+	for _, seg := range segments {
+		b.WriteByte('/')
+		b.WriteString(escape.Replace(seg))
+	}
+	return b.String()
+}
+
+// pathSegments splits a bracket style path such as "items[1].id" into its
+// segments ["items", "1", "id"].
+func pathSegments(path string) []string {
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		if name != "" || rest == "" {
+			segments = append(segments, name)
+		}
+		for rest != "" {
+			index, tail, _ := strings.Cut(rest, "]")
+			segments = append(segments, index)
+			rest = strings.TrimPrefix(tail, "[")
+		}
+	}
+	return segments
+}
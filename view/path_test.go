@@ -0,0 +1,89 @@
+package view
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathStyle_render(t *testing.T) {
+	tests := []struct {
+		path    string
+		dotted  string
+		pointer string
+	}{
+		{"name", "name", "/name"},
+		{"items[1].id", "items.1.id", "/items/1/id"},
+		{"grid[0][2]", "grid.0.2", "/grid/0/2"},
+		{"labels.a/b~c", "labels.a/b~c", "/labels/a~1b~0c"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			require.Equal(t, tc.path, PathBracket.render(tc.path))
+			require.Equal(t, tc.dotted, PathDotted.render(tc.path))
+			require.Equal(t, tc.pointer, PathPointer.render(tc.path))
+		})
+	}
+}
+
+func TestSchema_ErrorPaths(t *testing.T) {
+	newSchema := func() *Schema {
+		return WithFields(
+			Field[string]("name"),
+			ObjectField("address", WithFields(Field[string]("city"))),
+			ArrayOfObjectField("items", WithFields(Field[int]("id"), Field[int]("qty"))),
+		)
+	}
+	payload := `{"address":{},"items":[{"id":1,"qty":1},{"id":"x","qty":"y"}]}`
+	tests := []struct {
+		style PathStyle
+		want  []string
+	}{
+		{PathBracket, []string{"name", "address.city", "items[1].id", "items[1].qty"}},
+		{PathDotted, []string{"name", "address.city", "items.1.id", "items.1.qty"}},
+		{PathPointer, []string{"/name", "/address/city", "/items/1/id", "/items/1/qty"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.want[0], func(t *testing.T) {
+			res := newSchema().ErrorPaths(tc.style).Validate(payload)
+			var verr ValidationError
+			require.ErrorAs(t, res.Error(), &verr)
+			require.ElementsMatch(t, tc.want, lo.Keys(verr.Errors()))
+			require.ElementsMatch(t, tc.want, lo.Keys(verr.Codes()))
+
+			data, err := json.Marshal(verr)
+			require.NoError(t, err)
+			require.Contains(t, string(data), `"`+tc.want[2]+`"`)
+
+			localized := Localize(res.Error(), "en")
+			require.ErrorAs(t, localized, &verr)
+			require.ElementsMatch(t, tc.want, lo.Keys(verr.Errors()))
+		})
+	}
+
+	t.Run("form", func(t *testing.T) {
+		schema := WithFields(ArrayField[int]("ids")).ErrorPaths(PathPointer)
+		res := schema.ValidateForm(url.Values{"ids": {"1", "x"}})
+		var verr ValidationError
+		require.ErrorAs(t, res.Error(), &verr)
+		require.Contains(t, verr.Errors(), "/ids/1")
+	})
+
+	t.Run("extend keeps the style", func(t *testing.T) {
+		schema := WithFields(Field[int]("a")).ErrorPaths(PathPointer).Extend(WithFields(Field[int]("b")))
+		var verr ValidationError
+		require.ErrorAs(t, schema.Validate(`{"a":1}`).Error(), &verr)
+		require.Contains(t, verr.Errors(), "/b")
+	})
+}
+
+func keysOf[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,134 @@
+package view
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kcmvp/xql"
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+)
+
+// ErrInvalidJSON is returned for a RawJSONField value that is not valid
+// JSON or not of the required shape.
+var ErrInvalidJSON = errors.New("value must be valid JSON")
+
+// DocumentField is the ViewField of a JSON document kept as is, see
+// RawJSONField.
+type DocumentField struct {
+	qualifiedName string
+	scope         string
+	required      bool
+	maxBytes      int
+	// shape is the required JSON type, "object" or "array"; empty accepts any.
+	shape string
+}
+
+var _ ViewField = (*DocumentField)(nil)
+
+// RawJSONField creates a required field accepting any JSON value of at
+// most maxBytes bytes, a non-positive maxBytes disabling the limit. The
+// value is not interpreted: its text is stored as a json.RawMessage, ready
+// for a json or jsonb column, and marshals back unchanged. URL and form
+// values are parsed as JSON text:
+//
+//	view.RawJSONField("settings", 4096).RequireObject()
+func RawJSONField(name string, maxBytes int) *DocumentField {
+	if strings.ContainsAny(name, ".#") {
+		panic(fmt.Sprintf("xql: field name '%s' cannot contain '.' or '#'", name))
+	}
+	return &DocumentField{qualifiedName: name, required: true, maxBytes: maxBytes}
+}
+
+// PersistentRawJSONField is RawJSONField for the json or jsonb column of a
+// persistent field, see PersistentBytesField.
+func PersistentRawJSONField(f xql.Field, maxBytes int) *DocumentField {
+	lo.Assertf(f != nil, "view: PersistentRawJSONField requires a non-nil xql.Field")
+	return &DocumentField{qualifiedName: f.QualifiedName(), scope: f.Scope(), required: true, maxBytes: maxBytes}
+}
+
+// Optional marks the field as optional.
+func (f *DocumentField) Optional() *DocumentField {
+	f.required = false
+	return f
+}
+
+// RequireObject only accepts a JSON object.
+func (f *DocumentField) RequireObject() *DocumentField {
+	f.shape = "object"
+	return f
+}
+
+// RequireArray only accepts a JSON array.
+func (f *DocumentField) RequireArray() *DocumentField {
+	f.shape = "array"
+	return f
+}
+
+func (f *DocumentField) Scope() string         { return f.scope }
+func (f *DocumentField) QualifiedName() string { return f.qualifiedName }
+func (f *DocumentField) UniqueName() string    { return f.qualifiedName }
+func (f *DocumentField) IsArray() bool         { return false }
+func (f *DocumentField) IsObject() bool        { return false }
+func (f *DocumentField) Required() bool        { return f.required }
+
+func (f *DocumentField) Name() string {
+	return f.qualifiedName[strings.LastIndex(f.qualifiedName, ".")+1:]
+}
+
+// check validates the JSON text raw and returns a copy of it.
+func (f *DocumentField) check(raw string) mo.Result[any] {
+	if f.maxBytes > 0 && len(raw) > f.maxBytes {
+		return mo.Err[any](fmt.Errorf("field '%s': %w", f.Name(), &ruleError{
+			rule: validator.Rule{Name: "max_bytes", Params: map[string]any{"max": f.maxBytes}},
+			err:  fmt.Errorf("must be at most %d bytes", f.maxBytes),
+		}))
+	}
+	if !gjson.Valid(raw) {
+		return mo.Err[any](fmt.Errorf("field '%s': %w", f.Name(), ErrInvalidJSON))
+	}
+	res := gjson.Parse(raw)
+	if (f.shape == "object" && !res.IsObject()) || (f.shape == "array" && !res.IsArray()) {
+		return mo.Err[any](fmt.Errorf("field '%s': %w: expected a JSON %s", f.Name(), ErrInvalidJSON, f.shape))
+	}
+	return mo.Ok[any](json.RawMessage(strings.TrimSpace(raw)))
+}
+
+func (f *DocumentField) validate(node gjson.Result, _ walkState) mo.Result[any] {
+	return f.check(node.Raw)
+}
+
+func (f *DocumentField) validateRaw(s string) mo.Result[any] {
+	return f.check(s)
+}
+
+func (f *DocumentField) validateRawArray([]string) mo.Result[any] {
+	return mo.Err[any](fmt.Errorf("field '%s' has multiple values", f.Name()))
+}
+
+func (f *DocumentField) optional(map[*Schema]*Schema) ViewField {
+	cp := *f
+	cp.required = false
+	return &cp
+}
+
+func (f *DocumentField) embeddedObject() mo.Option[*Schema] {
+	return mo.None[*Schema]()
+}
+
+// jsonSchema accepts any value unless a shape is required.
+func (f *DocumentField) jsonSchema(map[*Schema]string) map[string]any {
+	if f.shape == "" {
+		return map[string]any{}
+	}
+	return map[string]any{"type": f.shape}
+}
+
+func (f *DocumentField) storedType() reflect.Type {
+	return reflect.TypeFor[json.RawMessage]()
+}
@@ -0,0 +1,74 @@
+package view
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/kcmvp/xql"
+	. "github.com/kcmvp/xql/sample/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawJSONField(t *testing.T) {
+	schema := WithFields(
+		RawJSONField("settings", 32).RequireObject(),
+		RawJSONField("tags", 0).RequireArray().Optional(),
+		RawJSONField("extra", 0).Optional(),
+	)
+	tests := []struct {
+		name    string
+		json    string
+		want    string
+		wantErr error
+	}{
+		{name: "object", json: `{"settings": {"theme": "dark", "n": [1, 2]}}`, want: `{"theme": "dark", "n": [1, 2]}`},
+		{name: "any value", json: `{"settings":{},"tags":[],"extra":12.50}`, want: `{}`},
+		{name: "too large", json: `{"settings":{"theme":"a very very very long value"}}`},
+		{name: "not an object", json: `{"settings":[1]}`, wantErr: ErrInvalidJSON},
+		{name: "not an array", json: `{"settings":{},"tags":{}}`, wantErr: ErrInvalidJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := schema.Validate(tt.json)
+			if tt.want == "" {
+				require.Error(t, res.Error())
+				if tt.wantErr != nil {
+					require.ErrorIs(t, firstError(t, res.Error()), tt.wantErr)
+				}
+				return
+			}
+			require.NoError(t, res.Error())
+			require.Equal(t, json.RawMessage(tt.want), Value[json.RawMessage](res.MustGet(), "settings").MustGet())
+		})
+	}
+
+	t.Run("marshal unchanged", func(t *testing.T) {
+		vo := schema.Validate(`{"settings":{"b":1,"a":[true,null]},"extra":"x"}`).MustGet()
+		data, err := json.Marshal(vo)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"settings":{"b":1,"a":[true,null]},"extra":"x"}`, string(data))
+	})
+
+	t.Run("form", func(t *testing.T) {
+		res := schema.ValidateForm(url.Values{"settings": {` {"a":1} `}})
+		require.NoError(t, res.Error())
+		require.Equal(t, json.RawMessage(`{"a":1}`), Value[json.RawMessage](res.MustGet(), "settings").MustGet())
+		res = schema.ValidateForm(url.Values{"settings": {`{"a":`}})
+		require.ErrorIs(t, firstError(t, res.Error()), ErrInvalidJSON)
+	})
+
+	t.Run("persistent document", func(t *testing.T) {
+		settings := xql.NewDocumentField[Account]("settings", "Settings").Object()
+		s := WithXQLFields(settings)
+		res := s.Validate(`{"Settings":{"theme":"dark"}}`)
+		require.NoError(t, res.Error())
+		require.Equal(t, json.RawMessage(`{"theme":"dark"}`), Value[json.RawMessage](res.MustGet(), settings.QualifiedName()).MustGet())
+		require.ErrorIs(t, firstError(t, s.Validate(`{"Settings":[1]}`).Error()), ErrInvalidJSON)
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		require.Equal(t, map[string]any{"type": "object"}, RawJSONField("s", 0).RequireObject().jsonSchema(nil))
+		require.Empty(t, RawJSONField("s", 0).jsonSchema(nil))
+	})
+}
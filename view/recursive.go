@@ -0,0 +1,101 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+// SelfRef returns a placeholder for the Schema being defined, for
+// tree-shaped payloads such as comments with replies:
+//
+//	comment := view.WithFields(
+//		view.Field[string]("text"),
+//		view.ArrayOfObjectField("replies", view.SelfRef()).Optional(),
+//	)
+//
+// WithFields replaces the placeholder with the schema it builds. The
+// placeholder cannot be validated on its own. Recursive fields should be
+// optional, or no finite payload validates; nesting is bounded by MaxDepth.
+func SelfRef() *Schema {
+	return &Schema{self: true}
+}
+
+// MaxDepth limits the nesting of embedded objects accepted by Validate to n
+// levels below the root object (32 by default), which bounds the work spent
+// on deeply nested payloads of recursive schemas. It returns the same
+// Schema pointer for chaining.
+func (s *Schema) MaxDepth(n int) *Schema {
+	lo.Assertf(n > 0, "xql: MaxDepth must be positive")
+	s.maxDepth = n
+	return s
+}
+
+// resolveSelf points the fields of s embedding a SelfRef placeholder to s.
+func (s *Schema) resolveSelf() {
+	for _, field := range s.fields {
+		if f, ok := field.(interface{ resolve(*Schema) }); ok {
+			f.resolve(s)
+		}
+	}
+}
+
+func (f *JSONField[T]) resolve(s *Schema) {
+	if f.embedded != nil && f.embedded.self {
+		f.embedded = s
+	}
+}
+
+// recursiveSchemas returns the schemas reachable from root, root included,
+// that embed themselves directly or through other schemas, in the order
+// they are found.
+func recursiveSchemas(root *Schema) []*Schema {
+	var found []*Schema
+	onStack := map[*Schema]bool{}
+	visited := map[*Schema]bool{}
+	var visit func(s *Schema)
+	visit = func(s *Schema) {
+		if onStack[s] {
+			if !lo.Contains(found, s) {
+				found = append(found, s)
+			}
+			return
+		}
+		if visited[s] {
+			return
+		}
+		visited[s], onStack[s] = true, true
+		for _, field := range s.fields {
+			if nested, ok := field.embeddedObject().Get(); ok {
+				visit(nested)
+			}
+		}
+		onStack[s] = false
+	}
+	visit(root)
+	return found
+}
+
+// schemaDefs registers the recursive schemas reachable from root in refs,
+// root as "#" and the others under "#/$defs/", and returns their
+// definitions keyed by name. Schemas already in refs are left as they are.
+func schemaDefs(root *Schema, refs map[*Schema]string) map[string]any {
+	defs := map[string]any{}
+	var pending []*Schema
+	for _, s := range recursiveSchemas(root) {
+		if _, ok := refs[s]; ok {
+			continue
+		}
+		if s == root {
+			refs[s] = "#"
+			continue
+		}
+		name := fmt.Sprintf("schema%d", len(pending)+1)
+		refs[s] = "#/$defs/" + name
+		pending = append(pending, s)
+	}
+	for i, s := range pending {
+		defs[fmt.Sprintf("schema%d", i+1)] = s.jsonSchema(refs)
+	}
+	return defs
+}
@@ -0,0 +1,76 @@
+package view
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfRef(t *testing.T) {
+	comment := WithFields(
+		Field[string]("text", validator.MinLength(1)),
+		ArrayOfObjectField("replies", SelfRef()).Optional(),
+	)
+	category := WithFields(
+		Field[string]("name"),
+		ObjectField("parent", SelfRef()).Optional(),
+	)
+
+	t.Run("tree", func(t *testing.T) {
+		res := comment.Validate(`{"text":"a","replies":[{"text":"b","replies":[{"text":"c"}]},{"text":"d"}]}`)
+		require.NoError(t, res.Error())
+		require.Equal(t, "c", res.MustGet().MstString("replies.0.replies.0.text"))
+	})
+
+	t.Run("nested error", func(t *testing.T) {
+		res := category.Validate(`{"name":"a","parent":{"name":"b","parent":{"name":1}}}`)
+		var verr ValidationError
+		require.ErrorAs(t, res.Error(), &verr)
+		require.Contains(t, verr.Errors(), "parent.parent.name")
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		deep := func(n int) string {
+			return strings.Repeat(`{"name":"x","parent":`, n) + `{"name":"x"}` + strings.Repeat("}", n)
+		}
+		require.NoError(t, category.Validate(deep(defaultMaxDepth)).Error())
+		require.ErrorContains(t, category.Validate(deep(defaultMaxDepth+1)).Error(), "maximum depth of 32")
+		limited := WithFields(Field[string]("name"), ObjectField("parent", SelfRef()).Optional()).MaxDepth(2)
+		require.NoError(t, limited.Validate(deep(2)).Error())
+		require.ErrorContains(t, limited.Validate(deep(3)).Error(), "maximum depth of 2")
+	})
+
+	t.Run("partial keeps recursion", func(t *testing.T) {
+		partial := comment.Partial()
+		require.NoError(t, partial.Validate(`{"replies":[{"replies":[{}]}]}`).Error())
+		require.Error(t, partial.Validate(`{"replies":[{"text":""}]}`).Error())
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		data, err := comment.JSONSchema()
+		require.NoError(t, err)
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(data, &doc))
+		replies := doc["properties"].(map[string]any)["replies"].(map[string]any)
+		require.Equal(t, "#", replies["items"].(map[string]any)["$ref"])
+
+		// a recursive schema below the root goes to $defs
+		thread := WithFields(ObjectField("root", comment))
+		data, err = thread.JSONSchema()
+		require.NoError(t, err)
+		doc = map[string]any{}
+		require.NoError(t, json.Unmarshal(data, &doc))
+		require.Equal(t, "#/$defs/schema1", doc["properties"].(map[string]any)["root"].(map[string]any)["$ref"])
+		require.Contains(t, doc["$defs"], "schema1")
+	})
+
+	t.Run("openapi", func(t *testing.T) {
+		_, err := OpenAPIComponents(map[string]*Schema{"Comment": comment})
+		require.NoError(t, err)
+		_, err = OpenAPIComponents(map[string]*Schema{"Thread": WithFields(ObjectField("root", comment))})
+		require.ErrorContains(t, err, "recursive schema that is not registered")
+	})
+}
@@ -0,0 +1,245 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"unsafe"
+
+	"github.com/kcmvp/xql/internal"
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// ErrPayloadTooLarge is returned by ValidateReader when the payload exceeds
+// the configured size limit.
+var ErrPayloadTooLarge = errors.New("payload too large")
+
+// ValidateBytes validates a JSON payload held in a byte slice. It behaves
+// exactly like Validate, but parses data in place instead of copying it into
+// a string: validated string values may share memory with data, so the
+// caller must not modify the slice while the result is in use.
+func (s *Schema) ValidateBytes(data []byte, urlParams ...map[string]string) mo.Result[ValueObject] {
+	return s.validate(context.Background(), unsafe.String(unsafe.SliceData(data), len(data)), singleValues(urlParams), nil)
+}
+
+// ValidateReader reads a JSON payload from r and validates it like Validate.
+// At most maxBytes are read; a larger payload fails with ErrPayloadTooLarge
+// without being parsed. A non-positive maxBytes disables the limit.
+//
+// The payload is read into a buffer owned by this call and parsed in place,
+// so unlike `Validate(string(body))` no second copy is made.
+func (s *Schema) ValidateReader(r io.Reader, maxBytes int64, urlParams ...map[string]string) mo.Result[ValueObject] {
+	return s.validateReader(context.Background(), r, maxBytes, singleValues(urlParams), nil)
+}
+
+func (s *Schema) validateReader(ctx context.Context, r io.Reader, maxBytes int64, urlParams []url.Values, headers http.Header) mo.Result[ValueObject] {
+	if r == nil {
+		return s.validate(ctx, "", urlParams, headers)
+	}
+	if maxBytes > 0 {
+		r = io.LimitReader(r, maxBytes+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return mo.Err[ValueObject](fmt.Errorf("read payload: %w", err))
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return mo.Err[ValueObject](fmt.Errorf("%w: limit is %d bytes", ErrPayloadTooLarge, maxBytes))
+	}
+	// data is never exposed or modified after this point, so it can back the
+	// string directly.
+	return s.validate(ctx, unsafe.String(unsafe.SliceData(data), len(data)), urlParams, headers)
+}
+
+// RequestOption configures ValidateRequest.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	pathValues bool
+	headers    []string
+	maxBytes   int64
+	params     []map[string]string
+}
+
+// WithPathValues makes ValidateRequest read path wildcards matched by
+// net/http.ServeMux (http.Request.PathValue) for the top-level schema fields.
+func WithPathValues() RequestOption {
+	return func(o *requestOptions) { o.pathValues = true }
+}
+
+// WithHeaders makes ValidateRequest read the named request headers; each
+// header is validated by the schema field of the same name.
+func WithHeaders(names ...string) RequestOption {
+	return func(o *requestOptions) { o.headers = append(o.headers, names...) }
+}
+
+// WithURLParams adds URL parameters extracted by other means, typically the
+// path parameters of a third-party router.
+func WithURLParams(params map[string]string) RequestOption {
+	return func(o *requestOptions) { o.params = append(o.params, params) }
+}
+
+// WithMaxBytes bounds the request body size, see ValidateReader.
+func WithMaxBytes(n int64) RequestOption {
+	return func(o *requestOptions) { o.maxBytes = n }
+}
+
+// ValidateRequest validates an HTTP request end-to-end: the JSON body, the
+// query string and, when enabled through opts, path values and headers are
+// merged into a single ValueObject. Query, path and header values are passed
+// to Validate as URL parameters, so the usual rules apply: they are parsed
+// with the field type, unknown names are rejected unless AllowUnknownFields
+// is set, and a name supplied by more than one source is reported as a
+// conflict. Repeated query parameters feed ArrayField, see ValidateValues.
+//
+//	mux.HandleFunc("PUT /accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+//		res := schema.ValidateRequest(r, view.WithPathValues(), view.WithHeaders("X-Tenant"))
+//		...
+//	})
+func (s *Schema) ValidateRequest(r *http.Request, opts ...RequestOption) mo.Result[ValueObject] {
+	if r == nil {
+		return mo.Err[ValueObject](errors.New("request is nil"))
+	}
+	o := &requestOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	params := []url.Values{r.URL.Query()}
+	if o.pathValues {
+		path := url.Values{}
+		for _, field := range s.fields {
+			if v := r.PathValue(field.Name()); v != "" {
+				path.Set(field.Name(), v)
+			}
+		}
+		params = append(params, path)
+	}
+	params = append(params, singleValues(o.params)...)
+	if len(o.headers) > 0 {
+		headers := url.Values{}
+		for _, name := range o.headers {
+			if v := r.Header.Values(name); len(v) > 0 {
+				headers[name] = v
+			}
+		}
+		params = append(params, headers)
+	}
+	var body io.Reader
+	if r.Body != nil && r.Body != http.NoBody {
+		body = r.Body
+	}
+	return s.validateReader(r.Context(), body, o.maxBytes, params, r.Header)
+}
+
+// ValidateForm validates an application/x-www-form-urlencoded payload, e.g.
+// the result of http.Request.ParseForm or url.ParseQuery. Each value is
+// parsed with the field type and run through the field validators, as URL
+// parameters are by Validate. Repeated keys map to ArrayField: every value
+// becomes one element. A repeated key for a non-array field, or a key naming
+// an embedded object, is an error.
+func (s *Schema) ValidateForm(values url.Values) mo.Result[ValueObject] {
+	return s.styled(s.checkCtx(context.Background(), s.validateForm(values, nil)))
+}
+
+// validateForm validates form values and, for FileField fields, uploaded
+// files.
+func (s *Schema) validateForm(values url.Values, files map[string][]*multipart.FileHeader) mo.Result[ValueObject] {
+	object := internal.Data{}
+	errs := &validationError{}
+	known := s.knownFields()
+	var warnings map[string]string
+	if !s.allowUnknownFields {
+		for name := range values {
+			if _, ok := known[name]; !ok {
+				errs.add(name, fmt.Errorf("unknown form field '%s'", name))
+			}
+		}
+		for name := range files {
+			if _, ok := known[name]; !ok {
+				errs.add(name, fmt.Errorf("unknown form file '%s'", name))
+			}
+		}
+		if errs.err() != nil {
+			return mo.Err[ValueObject](errs.err())
+		}
+	}
+	st := walkState{maxErrors: s.maxErrors}
+	for _, field := range s.fields {
+		if st.full(errs) {
+			break
+		}
+		if header := headerOf(field); header != "" {
+			// forms carry no headers, see HeaderField
+			if field.Required() {
+				errs.add(field.Name(), fmt.Errorf("header '%s' %w", header, validator.ErrRequired))
+			}
+			continue
+		}
+		name, err := inputName(field, func(name string) bool { return len(values[name]) > 0 || len(files[name]) > 0 })
+		if err != nil {
+			errs.add(field.Name(), err)
+			continue
+		}
+		vs := values[name]
+		if len(vs) > 0 && accessOf(field) == readOnly {
+			errs.add(field.Name(), readOnlyError(field))
+			continue
+		}
+		fileField, isFile := field.(*FormFileField)
+		if isFile && len(vs) > 0 {
+			errs.add(field.Name(), fmt.Errorf("field '%s' expects a file upload", field.Name()))
+			continue
+		}
+		if len(vs) == 0 && (!isFile || len(files[name]) == 0) {
+			if field.Required() {
+				errs.add(field.Name(), fmt.Errorf("%s %w", field.Name(), validator.ErrRequired))
+			}
+			continue
+		}
+		var rs mo.Result[any]
+		switch {
+		case isFile:
+			rs = fileField.validateFiles(files[name])
+		case field.IsObject():
+			errs.add(field.Name(), fmt.Errorf("form field '%s' is mapped to a embedded object", field.Name()))
+			continue
+		case field.IsArray():
+			rs = field.validateRawArray(vs)
+		case len(vs) > 1:
+			errs.add(field.Name(), fmt.Errorf("form field '%s' has multiple values", field.Name()))
+			continue
+		default:
+			rs = field.validateRaw(vs[0])
+		}
+		if rs.IsError() {
+			var nestedErr *validationError
+			if errors.As(rs.Error(), &nestedErr) {
+				errs.merge("", nestedErr)
+			} else {
+				errs.add(field.Name(), rs.Error())
+			}
+			continue
+		}
+		setNestedField(object, field.UniqueName(), rs.MustGet())
+		warnings = addWarnings(warnings, field, rs.MustGet())
+	}
+	s.checkConditions(object, errs)
+	s.runChecks(object, errs)
+	var unknown map[string]any
+	if s.allowUnknownFields {
+		bucket := lo.Ternary(s.captureUnknown, map[string]any{}, map[string]any(object))
+		for name, vs := range values {
+			if _, ok := known[name]; !ok && len(vs) > 0 {
+				bucket[name] = lo.Ternary[any](len(vs) == 1, vs[0], vs)
+			}
+		}
+		unknown = lo.Ternary(s.captureUnknown, bucket, nil)
+	}
+	return lo.Ternary(errs.err() != nil, mo.Err[ValueObject](errs.err()), mo.Ok[ValueObject](valueObject{Data: object, unknown: unknown, sensitive: s.sensitivePaths(), encoded: s.encodedPaths(), warnings: warnings}))
+}
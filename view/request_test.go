@@ -0,0 +1,222 @@
+package view
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("boom") }
+
+func TestSchema_ValidateBytes(t *testing.T) {
+	schema := WithFields(Field[string]("name"), Field[int]("age"))
+	data := []byte(`{"name":"jack","age":30}`)
+	res := schema.ValidateBytes(data)
+	require.NoError(t, res.Error())
+	require.Equal(t, "jack", res.MustGet().MstString("name"))
+	require.Equal(t, 30, res.MustGet().MstInt("age"))
+
+	res = schema.ValidateBytes([]byte(`{"name":"jack"}`), map[string]string{"age": "12"})
+	require.NoError(t, res.Error())
+	require.Equal(t, 12, res.MustGet().MstInt("age"))
+
+	res = schema.ValidateBytes(nil)
+	require.Error(t, res.Error())
+}
+
+func TestSchema_ValidateReader(t *testing.T) {
+	schema := WithFields(Field[string]("name"))
+	body := `{"name":"jack"}`
+	tests := []struct {
+		name     string
+		maxBytes int64
+		wantErr  error
+	}{
+		{"within limit", int64(len(body)), nil},
+		{"no limit", 0, nil},
+		{"over limit", int64(len(body)) - 1, ErrPayloadTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := schema.ValidateReader(strings.NewReader(body), tt.maxBytes)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, res.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, res.Error())
+			require.Equal(t, "jack", res.MustGet().MstString("name"))
+		})
+	}
+
+	res := schema.ValidateReader(failingReader{}, 10)
+	require.ErrorContains(t, res.Error(), "read payload: boom")
+}
+
+func TestSchema_ValidateRequest(t *testing.T) {
+	schema := WithFields(
+		Field[int]("id"),
+		Field[string]("name"),
+		Field[bool]("notify").Optional(),
+		Field[string]("X-Tenant").Optional(),
+	)
+	serve := func(r *http.Request, opts ...RequestOption) mo.Result[ValueObject] {
+		var res mo.Result[ValueObject]
+		mux := http.NewServeMux()
+		mux.HandleFunc("PUT /accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+			res = schema.ValidateRequest(r, opts...)
+		})
+		mux.ServeHTTP(httptest.NewRecorder(), r)
+		return res
+	}
+
+	t.Run("body, query, path and header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/accounts/42?notify=true", strings.NewReader(`{"name":"jack"}`))
+		r.Header.Set("X-Tenant", "acme")
+		res := serve(r, WithPathValues(), WithHeaders("X-Tenant"))
+		require.NoError(t, res.Error())
+		vo := res.MustGet()
+		require.Equal(t, 42, vo.MstInt("id"))
+		require.Equal(t, "jack", vo.MstString("name"))
+		require.True(t, vo.MstBool("notify"))
+		require.Equal(t, "acme", vo.MstString("X-Tenant"))
+	})
+
+	t.Run("path values are opt-in", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/accounts/42", strings.NewReader(`{"name":"jack"}`))
+		res := serve(r)
+		require.ErrorContains(t, res.Error(), "id is required")
+	})
+
+	t.Run("conflicting sources", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/accounts/42?id=7", strings.NewReader(`{"name":"jack"}`))
+		res := serve(r, WithPathValues())
+		require.ErrorContains(t, res.Error(), "duplicated url parameter 'id'")
+	})
+
+	t.Run("multi-value query", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/accounts/42?name=a&name=b", nil)
+		res := serve(r, WithPathValues())
+		require.ErrorContains(t, res.Error(), "url parameter 'name' has multiple values")
+	})
+
+	t.Run("body limit", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/accounts/42", strings.NewReader(`{"name":"jack"}`))
+		res := serve(r, WithPathValues(), WithMaxBytes(4))
+		require.ErrorIs(t, res.Error(), ErrPayloadTooLarge)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		require.Error(t, schema.ValidateRequest(nil).Error())
+	})
+}
+
+func TestSchema_ValidateForm(t *testing.T) {
+	schema := WithFields(
+		Field[string]("name", validator.MinLength(2)),
+		Field[int]("age").Optional(),
+		ArrayField[int]("ids", validator.Gt(0)).Optional(),
+		ObjectField("address", WithFields(Field[string]("city"))).Optional(),
+	)
+	tests := []struct {
+		name    string
+		form    string
+		wantErr string
+		check   func(t *testing.T, vo ValueObject)
+	}{
+		{
+			name: "scalars and repeated keys",
+			form: "name=jack&age=30&ids=1&ids=2",
+			check: func(t *testing.T, vo ValueObject) {
+				require.Equal(t, "jack", vo.MstString("name"))
+				require.Equal(t, 30, vo.MstInt("age"))
+				require.Equal(t, []int{1, 2}, vo.MstIntArray("ids"))
+			},
+		},
+		{name: "single value array", form: "name=jack&ids=7", check: func(t *testing.T, vo ValueObject) {
+			require.Equal(t, []int{7}, vo.MstIntArray("ids"))
+		}},
+		{name: "required", form: "age=1", wantErr: "name is required"},
+		{name: "validator", form: "name=j", wantErr: "length must be at least 2"},
+		{name: "type", form: "name=jack&age=x", wantErr: "field 'age'"},
+		{name: "array element", form: "name=jack&ids=1&ids=0", wantErr: "ids[1]"},
+		{name: "repeated scalar", form: "name=a&name=b", wantErr: "form field 'name' has multiple values"},
+		{name: "object", form: "name=jack&address=x", wantErr: "mapped to a embedded object"},
+		{name: "unknown", form: "name=jack&foo=bar", wantErr: "unknown form field 'foo'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.form)
+			require.NoError(t, err)
+			res := schema.ValidateForm(values)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, res.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, res.Error())
+			tt.check(t, res.MustGet())
+		})
+	}
+
+	res := WithFields(Field[string]("name")).AllowUnknownFields().ValidateForm(url.Values{"name": {"jack"}, "tag": {"a", "b"}, "x": {"1"}})
+	require.NoError(t, res.Error())
+	require.Equal(t, []string{"a", "b"}, res.MustGet().MstStringArray("tag"))
+	require.Equal(t, "1", res.MustGet().MstString("x"))
+}
+
+func TestSchema_ValidateValues(t *testing.T) {
+	schema := WithFields(
+		Field[string]("name"),
+		ArrayField[string]("tags", validator.MinLength(2)).Optional(),
+		ArrayField[int]("ids").Optional(),
+		ObjectField("address", WithFields(Field[string]("city"))).Optional(),
+	)
+	tests := []struct {
+		name    string
+		json    string
+		params  []url.Values
+		wantErr string
+		check   func(t *testing.T, vo ValueObject)
+	}{
+		{
+			name:   "repeated values feed arrays",
+			json:   `{"name":"jack"}`,
+			params: []url.Values{{"tags": {"go", "sql"}, "ids": {"1", "2", "3"}}},
+			check: func(t *testing.T, vo ValueObject) {
+				require.Equal(t, []string{"go", "sql"}, vo.MstStringArray("tags"))
+				require.Equal(t, []int{1, 2, 3}, vo.MstIntArray("ids"))
+			},
+		},
+		{
+			name:   "single value array",
+			params: []url.Values{{"name": {"jack"}, "ids": {"7"}}},
+			check: func(t *testing.T, vo ValueObject) {
+				require.Equal(t, []int{7}, vo.MstIntArray("ids"))
+			},
+		},
+		{name: "element validation", json: `{"name":"jack"}`, params: []url.Values{{"tags": {"go", "x"}}}, wantErr: "tags[1]"},
+		{name: "element type", json: `{"name":"jack"}`, params: []url.Values{{"ids": {"1", "x"}}}, wantErr: "ids[1]"},
+		{name: "repeated scalar", params: []url.Values{{"name": {"a", "b"}}}, wantErr: "url parameter 'name' has multiple values"},
+		{name: "object", json: `{"name":"jack"}`, params: []url.Values{{"address": {"x"}}}, wantErr: "mapped to a embedded object"},
+		{name: "duplicate across sources", json: `{"name":"jack"}`, params: []url.Values{{"ids": {"1"}}, {"ids": {"2"}}}, wantErr: "duplicated url parameter 'ids'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := schema.ValidateValues(tt.json, tt.params...)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, res.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, res.Error())
+			tt.check(t, res.MustGet())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package view
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/kcmvp/xql/internal"
+	"github.com/samber/lo"
+)
+
+// Masked replaces the values of Sensitive fields in ValueObject.Sanitized.
+const Masked = "******"
+
+// Sensitive marks a field holding a secret such as a password or a token:
+// ValueObject.Sanitized masks its value, and so does logging the
+// ValueObject with log/slog. Validation is not affected.
+func (f *JSONField[T]) Sensitive() *JSONField[T] {
+	f.sensitive = true
+	return f
+}
+
+func (f *JSONField[T]) isSensitive() bool {
+	return f.sensitive
+}
+
+// sensitivePaths returns the storage keys of the Sensitive fields of s.
+func (s *Schema) sensitivePaths() []string {
+	var paths []string
+	for _, field := range s.fields {
+		if sf, ok := field.(interface{ isSensitive() bool }); ok && sf.isSensitive() {
+			paths = append(paths, field.UniqueName())
+		}
+	}
+	return paths
+}
+
+// Sanitized returns a copy of vo where the values of Sensitive fields,
+// including those of embedded objects, are replaced by Masked. Use it to
+// marshal or log a ValueObject without leaking secrets.
+func (vo valueObject) Sanitized() ValueObject {
+	data := sanitize(vo.Data)
+	for _, path := range vo.sensitive {
+		data = rewrite(data, strings.Split(path, "."), func(v any) (any, bool) {
+			return lo.Ternary[any](v == internal.Null, v, Masked), true
+		})
+	}
+	return valueObject{Data: data, unknown: vo.unknown, encoded: vo.encoded}
+}
+
+// LogValue implements slog.LogValuer, so logging a ValueObject with
+// log/slog masks its Sensitive fields, see Sanitized.
+func (vo valueObject) LogValue() slog.Value {
+	return slog.AnyValue(map[string]any(vo.Sanitized().(valueObject).Data))
+}
+
+// sanitize returns a copy of data with every embedded ValueObject sanitized.
+func sanitize(data internal.Data) internal.Data {
+	out := make(internal.Data, len(data))
+	for k, v := range data {
+		switch v := v.(type) {
+		case internal.Data:
+			out[k] = sanitize(v)
+		case ValueObject:
+			out[k] = v.Sanitized()
+		case []ValueObject:
+			out[k] = lo.Map(v, func(item ValueObject, _ int) ValueObject { return item.Sanitized() })
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,54 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueObject_Sanitized(t *testing.T) {
+	credentials := WithFields(
+		Field[string]("user"),
+		Field[string]("token").Sensitive(),
+	)
+	schema := WithFields(
+		Field[string]("name"),
+		Field[string]("password").Sensitive(),
+		Field[string]("hint").Sensitive().Nullable().Optional(),
+		ObjectField("login", credentials),
+		ArrayOfObjectField("keys", credentials),
+	)
+	res := schema.Validate(`{"name":"a","password":"p","hint":null,"login":{"user":"u","token":"t1"},"keys":[{"user":"k","token":"t2"}]}`)
+	require.NoError(t, res.Error())
+	vo := res.MustGet()
+	want := `{"name":"a","password":"******","hint":null,"login":{"user":"u","token":"******"},"keys":[{"user":"k","token":"******"}]}`
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(vo.Sanitized())
+		require.NoError(t, err)
+		require.JSONEq(t, want, string(data))
+		// the original keeps the secrets
+		require.Equal(t, "p", vo.MstString("password"))
+		require.Equal(t, "t1", vo.MstString("login.token"))
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		var buf bytes.Buffer
+		slog.New(slog.NewJSONHandler(&buf, nil)).Info("signup", "payload", vo)
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		data, err := json.Marshal(entry["payload"])
+		require.NoError(t, err)
+		require.JSONEq(t, want, string(data))
+	})
+
+	t.Run("form", func(t *testing.T) {
+		res := WithFields(Field[string]("pin").Sensitive()).ValidateForm(url.Values{"pin": {"1234"}})
+		require.NoError(t, res.Error())
+		require.Equal(t, Masked, res.MustGet().Sanitized().MstString("pin"))
+	})
+}
@@ -0,0 +1,85 @@
+package view
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/tidwall/gjson"
+)
+
+// Layouts replaces the time layouts accepted by a time.Time field, which
+// default to RFC 3339 and its date-only and zone-less forms. Layouts are
+// tried in order, for JSON values and URL or form parameters alike:
+//
+//	Field[time.Time]("ts").Layouts("02/01/2006 15:04", "02/01/2006")
+func (f *JSONField[T]) Layouts(layouts ...string) *JSONField[T] {
+	f.assertTime("Layouts")
+	lo.Assertf(len(layouts) > 0, "xql: Layouts requires at least one layout for field '%s'", f.Name())
+	f.layouts = layouts
+	return f
+}
+
+// Location interprets values without a zone offset in loc instead of UTC
+// and converts every parsed value to loc, so the ValueObject holds times
+// of a single timezone:
+//
+//	Field[time.Time]("ts").Layouts("02/01/2006 15:04").Location(paris)
+func (f *JSONField[T]) Location(loc *time.Location) *JSONField[T] {
+	f.assertTime("Location")
+	lo.Assertf(loc != nil, "xql: nil location for field '%s'", f.Name())
+	f.location = loc
+	return f
+}
+
+func (f *JSONField[T]) assertTime(option string) {
+	_, ok := any(*new(T)).(time.Time)
+	lo.Assertf(ok && !f.object, "xql: %s is only supported by time.Time fields, field '%s'", option, f.Name())
+}
+
+// timed reports whether the field parses times with its own layouts or location.
+func (f *JSONField[T]) timed() bool {
+	return f.layouts != nil || f.location != nil
+}
+
+// jsonTime parses a JSON string with the field's layouts and location.
+func (f *JSONField[T]) jsonTime(res gjson.Result) mo.Result[T] {
+	if res.Type != gjson.String {
+		return mismatch[T](res)
+	}
+	return f.stringTime(res.Str)
+}
+
+// stringTime parses s with the field's layouts and location.
+func (f *JSONField[T]) stringTime(s string) mo.Result[T] {
+	t, err := parseTime(s, lo.Ternary(f.layouts != nil, f.layouts, timeLayouts), f.location)
+	if err != nil {
+		return mo.Err[T](err)
+	}
+	return mo.Ok(any(t).(T))
+}
+
+// parseString converts a URL or form parameter to T.
+func (f *JSONField[T]) parseString(s string) mo.Result[T] {
+	if f.timed() {
+		return f.stringTime(s)
+	}
+	return typedString[T](s)
+}
+
+// parseTime parses s with the first matching layout. Values without a zone
+// offset are read in loc, UTC when nil; when loc is set, the result is
+// converted to it, otherwise the parsed offset is kept.
+func parseTime(s string, layouts []string, loc *time.Location) (time.Time, error) {
+	in := lo.Ternary(loc != nil, loc, time.UTC)
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, in); err == nil {
+			if loc != nil {
+				t = t.In(loc)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("incorrect date format for string '%s'", s)
+}
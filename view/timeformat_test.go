@@ -0,0 +1,116 @@
+package view
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONField_Layouts(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	tests := []struct {
+		name    string
+		field   *JSONField[time.Time]
+		json    string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "custom layout",
+			field: Field[time.Time]("ts").Layouts("02/01/2006"),
+			json:  `{"ts":"25/12/2024"}`,
+			want:  time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "second layout",
+			field: Field[time.Time]("ts").Layouts("02/01/2006 15:04", "02/01/2006"),
+			json:  `{"ts":"25/12/2024"}`,
+			want:  time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "default layouts replaced",
+			field:   Field[time.Time]("ts").Layouts("02/01/2006"),
+			json:    `{"ts":"2024-12-25"}`,
+			wantErr: true,
+		},
+		{
+			name:    "lenient does not bypass layouts",
+			field:   Field[time.Time]("ts").Layouts("02/01/2006").Lenient(),
+			json:    `{"ts":"2024-12-25"}`,
+			wantErr: true,
+		},
+		{
+			name:  "zone-less value read in location",
+			field: Field[time.Time]("ts").Layouts("02/01/2006 15:04").Location(paris),
+			json:  `{"ts":"25/12/2024 10:30"}`,
+			want:  time.Date(2024, 12, 25, 10, 30, 0, 0, paris),
+		},
+		{
+			name:  "zoned value converted to location",
+			field: Field[time.Time]("ts").Location(paris),
+			json:  `{"ts":"2024-12-25T09:30:00Z"}`,
+			want:  time.Date(2024, 12, 25, 10, 30, 0, 0, paris),
+		},
+		{
+			name:    "not a string",
+			field:   Field[time.Time]("ts").Layouts("02/01/2006"),
+			json:    `{"ts":20241225}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		for _, compiled := range []bool{false, true} {
+			t.Run(tt.name, func(t *testing.T) {
+				schema := WithFields(tt.field)
+				if compiled {
+					schema.Compile()
+				}
+				res := schema.Validate(tt.json)
+				if tt.wantErr {
+					require.Error(t, res.Error())
+					return
+				}
+				require.NoError(t, res.Error())
+				got := res.MustGet().Time("ts").MustGet()
+				require.True(t, tt.want.Equal(got), "got %v", got)
+				require.Equal(t, tt.want.Location(), got.Location())
+			})
+		}
+	}
+}
+
+func TestJSONField_LayoutsParams(t *testing.T) {
+	schema := WithFields(
+		Field[time.Time]("from").Layouts("02/01/2006"),
+		ArrayField[time.Time]("days").Layouts("02/01/2006").Optional(),
+	)
+	want := time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	res := schema.Validate(`{}`, map[string]string{"from": "25/12/2024"})
+	require.NoError(t, res.Error())
+	require.Equal(t, want, res.MustGet().Time("from").MustGet())
+
+	res = schema.ValidateForm(url.Values{"from": {"25/12/2024"}, "days": {"25/12/2024", "26/12/2024"}})
+	require.NoError(t, res.Error())
+	days := res.MustGet().Get("days").MustGet().([]time.Time)
+	require.Equal(t, []time.Time{want, want.AddDate(0, 0, 1)}, days)
+
+	require.Error(t, schema.ValidateForm(url.Values{"from": {"2024-12-25"}}).Error())
+}
+
+func TestJSONField_LayoutsJSONSchema(t *testing.T) {
+	node := Field[time.Time]("ts").Layouts("02/01/2006").jsonSchema(nil)
+	require.Equal(t, map[string]any{"type": "string"}, node)
+	node = Field[time.Time]("ts").Location(time.UTC).jsonSchema(nil)
+	require.Equal(t, "date-time", node["format"])
+}
+
+func TestJSONField_LayoutsPanics(t *testing.T) {
+	require.Panics(t, func() { Field[string]("name").Layouts("2006") })
+	require.Panics(t, func() { Field[int]("age").Location(time.UTC) })
+	require.Panics(t, func() { Field[time.Time]("ts").Layouts() })
+	require.Panics(t, func() { Field[time.Time]("ts").Location(nil) })
+}
@@ -0,0 +1,45 @@
+package view
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kcmvp/xql/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONField_Transform(t *testing.T) {
+	clamp := func(v int) int { return min(v, 100) }
+	schema := WithFields(
+		Field[string]("email", validator.Email(), validator.MaxLength(12)).Transform(strings.TrimSpace, strings.ToLower),
+		Field[int]("limit", validator.Lte(100)).Transform(clamp).Optional(),
+		ArrayField[string]("tags", validator.OneOf("go", "sql")).Transform(strings.ToLower).Optional(),
+	)
+
+	t.Run("json", func(t *testing.T) {
+		res := schema.Validate(`{"email":"  A@X.IO  ","limit":500,"tags":["GO","Sql"]}`)
+		require.NoError(t, res.Error())
+		vo := res.MustGet()
+		require.Equal(t, "a@x.io", vo.MstString("email"))
+		require.Equal(t, 100, vo.MstInt("limit"))
+		require.Equal(t, []string{"go", "sql"}, vo.MstStringArray("tags"))
+	})
+
+	t.Run("url params", func(t *testing.T) {
+		res := schema.ValidateValues(`{"email":"a@x.io"}`, url.Values{"limit": {"300"}, "tags": {"GO"}})
+		require.NoError(t, res.Error())
+		require.Equal(t, 100, res.MustGet().MstInt("limit"))
+		require.Equal(t, []string{"go"}, res.MustGet().MstStringArray("tags"))
+	})
+
+	t.Run("validators see the transformed value", func(t *testing.T) {
+		// the padded value would exceed MaxLength(12) without TrimSpace
+		res := schema.Validate(`{"email":"     a@x.io     "}`)
+		require.NoError(t, res.Error())
+		res = schema.Validate(`{"email":"abcdefgh@x.io"}`)
+		require.ErrorContains(t, res.Error(), "length must be at most")
+	})
+
+	require.Panics(t, func() { Field[string]("name").Transform(nil) })
+}
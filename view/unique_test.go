@@ -0,0 +1,77 @@
+package view
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONField_UniqueItems(t *testing.T) {
+	schema := WithFields(
+		ArrayField[string]("tags").UniqueItems().Optional(),
+		ArrayOfObjectField("items", WithFields(
+			Field[string]("sku"),
+			Field[int]("qty"),
+			Field[string]("lot").Optional(),
+		)).UniqueItems("sku", "lot").Optional(),
+	)
+	tests := []struct {
+		name string
+		json string
+		keys []string
+	}{
+		{name: "distinct tags", json: `{"tags":["a","b"]}`},
+		{name: "duplicate tags", json: `{"tags":["a","b","a","a"]}`, keys: []string{"tags[2]", "tags[3]"}},
+		{name: "distinct objects", json: `{"items":[{"sku":"x","qty":1,"lot":"1"},{"sku":"x","qty":1,"lot":"2"}]}`},
+		{name: "duplicate objects", json: `{"items":[{"sku":"x","qty":1,"lot":"1"},{"sku":"x","qty":2,"lot":"1"}]}`, keys: []string{"items[1]"}},
+		{name: "objects missing a key are not compared", json: `{"items":[{"sku":"x","qty":1},{"sku":"x","qty":2}]}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := schema.Validate(tc.json)
+			if len(tc.keys) == 0 {
+				require.NoError(t, res.Error())
+				return
+			}
+			var verr ValidationError
+			require.ErrorAs(t, res.Error(), &verr)
+			require.ElementsMatch(t, tc.keys, lo.Keys(verr.Codes()))
+			for _, code := range verr.Codes() {
+				require.Equal(t, "unique_items", code)
+			}
+		})
+	}
+
+	t.Run("url params", func(t *testing.T) {
+		res := schema.ValidateValues(`{}`, url.Values{"tags": {"a", "a"}})
+		require.ErrorContains(t, res.Error(), "duplicates element 0")
+	})
+
+	t.Run("element errors first", func(t *testing.T) {
+		res := WithFields(ArrayField[int]("ids").UniqueItems()).Validate(`{"ids":[1,"x",1]}`)
+		var verr ValidationError
+		require.ErrorAs(t, res.Error(), &verr)
+		require.Contains(t, verr.Errors(), "ids[1]")
+		require.NotContains(t, verr.Errors(), "ids[2]")
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		data, err := schema.JSONSchema()
+		require.NoError(t, err)
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(data, &doc))
+		props := doc["properties"].(map[string]any)
+		require.Equal(t, true, props["tags"].(map[string]any)["uniqueItems"])
+		require.NotContains(t, props["items"].(map[string]any), "uniqueItems")
+	})
+
+	t.Run("misuse", func(t *testing.T) {
+		require.Panics(t, func() { Field[string]("a").UniqueItems() })
+		require.Panics(t, func() { ArrayField[string]("a").UniqueItems("id") })
+		require.Panics(t, func() { ArrayOfObjectField("a", WithFields(Field[int]("id"))).UniqueItems() })
+		require.Panics(t, func() { ArrayOfObjectField("a", WithFields(Field[int]("id"))).UniqueItems("sku") })
+	})
+}
@@ -0,0 +1,52 @@
+package view
+
+import (
+	"github.com/samber/lo"
+	"github.com/tidwall/gjson"
+)
+
+// CaptureUnknown accepts unknown fields like AllowUnknownFields but keeps
+// them apart from the validated fields: unknown JSON keys, URL parameters
+// and form values are collected, unvalidated, into ValueObject.Unknown.
+// This suits pass-through proxies and extensible APIs:
+//
+//	res := schema.CaptureUnknown().Validate(`{"name":"a","x-trace":"1"}`)
+//	res.MustGet().Unknown() // map[x-trace:1]
+//
+// It applies to the schema it is set on; embedded schemas capture their
+// own unknown keys only when set on them as well. It returns the same
+// Schema pointer for chaining.
+func (s *Schema) CaptureUnknown() *Schema {
+	if s == nil {
+		return s
+	}
+	s.allowUnknownFields = true
+	s.captureUnknown = true
+	return s
+}
+
+// Unknown returns the unknown fields captured by a schema with
+// CaptureUnknown, or nil. JSON values are decoded as by encoding/json into
+// an any; URL parameters and form values are strings, or []string when
+// repeated.
+func (vo valueObject) Unknown() map[string]any {
+	return vo.unknown
+}
+
+// unknownValues collects the JSON keys and URL parameters of a payload that
+// are not fields of the schema. nodes holds the top-level members of the JSON
+// payload by key.
+func unknownValues(nodes map[string]gjson.Result, urlParams map[string][]string, known map[string]bool) map[string]any {
+	unknown := map[string]any{}
+	for k, node := range nodes {
+		if _, ok := known[k]; !ok {
+			unknown[k] = node.Value()
+		}
+	}
+	for k, v := range urlParams {
+		if _, ok := known[k]; !ok {
+			unknown[k] = lo.Ternary[any](len(v) == 1, v[0], v)
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,50 @@
+package view
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_CaptureUnknown(t *testing.T) {
+	newSchema := func() *Schema {
+		return WithFields(
+			Field[string]("name"),
+			ObjectField("meta", WithFields(Field[int]("version")).CaptureUnknown()).Optional(),
+		).CaptureUnknown()
+	}
+
+	t.Run("json and url", func(t *testing.T) {
+		res := newSchema().ValidateValues(`{"name":"a","extra":{"k":[1,true]},"meta":{"version":1,"tag":"x"}}`, url.Values{"trace": {"1"}, "ids": {"1", "2"}})
+		require.NoError(t, res.Error())
+		vo := res.MustGet()
+		require.Equal(t, map[string]any{
+			"extra": map[string]any{"k": []any{float64(1), true}},
+			"trace": "1",
+			"ids":   []string{"1", "2"},
+		}, vo.Unknown())
+		require.ElementsMatch(t, []string{"name", "meta"}, vo.Fields())
+		meta := vo.Get("meta").MustGet().(ValueObject)
+		require.Equal(t, map[string]any{"tag": "x"}, meta.Unknown())
+	})
+
+	t.Run("nothing unknown", func(t *testing.T) {
+		res := newSchema().Validate(`{"name":"a"}`)
+		require.NoError(t, res.Error())
+		require.Empty(t, res.MustGet().Unknown())
+	})
+
+	t.Run("not captured", func(t *testing.T) {
+		res := WithFields(Field[string]("name")).AllowUnknownFields().Validate(`{"name":"a","extra":1}`)
+		require.NoError(t, res.Error())
+		require.Nil(t, res.MustGet().Unknown())
+	})
+
+	t.Run("form", func(t *testing.T) {
+		res := newSchema().ValidateForm(url.Values{"name": {"a"}, "extra": {"x"}})
+		require.NoError(t, res.Error())
+		require.Equal(t, map[string]any{"extra": "x"}, res.MustGet().Unknown())
+		require.Equal(t, []string{"name"}, res.MustGet().Fields())
+	})
+}
@@ -1,10 +1,14 @@
 package view
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -19,13 +23,16 @@ import (
 	"github.com/tidwall/gjson"
 )
 
-// timeLayouts defines the supported time formats for parsing time.Time fields.
+// timeLayouts defines the supported time formats for parsing time.Time
+// fields, unless a field sets its own with Layouts.
 var timeLayouts = []string{time.RFC3339Nano, time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
 
 // validationError is a custom error type that holds a map of validation errors,
-// ensuring that there is only one error per field.
+// keeping every violation reported for a field in the order it was found.
 type validationError struct {
-	errors map[string]error
+	errors map[string][]error
+	// style renders the reported paths, see Schema.ErrorPaths.
+	style PathStyle
 }
 
 // Error implements the error interface, formatting all contained errors.
@@ -43,18 +50,200 @@ func (e *validationError) Error() string {
 	var b strings.Builder
 	b.WriteString("validation failed with the following errors:")
 	for _, k := range keys {
-		b.WriteString(fmt.Sprintf("- %s: %s", k, e.errors[k]))
+		for _, err := range e.errors[k] {
+			b.WriteString(fmt.Sprintf("- %s: %s", e.style.render(k), err))
+		}
 	}
 	return b.String()
 }
 
-// add adds a new error to the map.
+// add appends an error to the errors of fieldName.
 func (e *validationError) add(fieldName string, err error) {
 	if err != nil {
 		if e.errors == nil {
-			e.errors = make(map[string]error)
+			e.errors = make(map[string][]error)
+		}
+		e.errors[fieldName] = append(e.errors[fieldName], err)
+	}
+}
+
+// merge adds all errors of other, with their keys prefixed by prefix.
+func (e *validationError) merge(prefix string, other *validationError) {
+	for key, errs := range other.errors {
+		for _, err := range errs {
+			e.add(joinPath(prefix, key), err)
+		}
+	}
+}
+
+// first returns the first error reported for fieldName, or nil.
+func (e *validationError) first(fieldName string) error {
+	if errs := e.errors[fieldName]; len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidationError is implemented by the error of a failed validation. Use
+// errors.As to retrieve it:
+//
+//	var verr view.ValidationError
+//	if errors.As(res.Error(), &verr) {
+//		c.JSON(http.StatusBadRequest, verr) // {"errors":{"age":"must be greater than 18"},"codes":{"age":"number.gt"}}
+//	}
+type ValidationError interface {
+	error
+	// Errors returns one message per invalid field, keyed by the field path
+	// (e.g. "age", "address.city" or "items[1].id"). A field that failed
+	// several validators reports the first one.
+	Errors() map[string]string
+	// Violations returns every message per invalid field, keyed like Errors.
+	Violations() map[string][]string
+	// Codes returns the machine-readable error code of the first violation
+	// per invalid field, keyed like Errors, see validator.Code.
+	Codes() map[string]string
+}
+
+var _ ValidationError = (*validationError)(nil)
+
+// Errors implements ValidationError. Nested object errors are flattened into
+// dotted keys and the "field '...':" prefix is dropped from the messages.
+// Only the first violation of a field is reported, see Violations.
+func (e *validationError) Errors() map[string]string {
+	out := map[string]string{}
+	e.walk("", func(path string, err error) {
+		if _, ok := out[path]; !ok {
+			out[path] = message(err)
+		}
+	})
+	return out
+}
+
+// Violations implements ValidationError.
+func (e *validationError) Violations() map[string][]string {
+	out := map[string][]string{}
+	e.walk("", func(path string, err error) {
+		out[path] = append(out[path], message(err))
+	})
+	return out
+}
+
+// Codes implements ValidationError. Failed validators report their
+// validator.Code; missing fields report validator.CodeRequired and values of
+// the wrong type validator.CodeTypeMismatch or validator.CodeOverflow. Any
+// other error reports "invalid".
+func (e *validationError) Codes() map[string]string {
+	out := map[string]string{}
+	e.walk("", func(path string, err error) {
+		if _, ok := out[path]; !ok {
+			out[path] = codeOf(err)
+		}
+	})
+	return out
+}
+
+// walk calls fn for every leaf error with its flattened path, rendered in
+// the style of e.
+func (e *validationError) walk(prefix string, fn func(path string, err error)) {
+	if e == nil {
+		return
+	}
+	if e.style != PathBracket {
+		style := e.style
+		plain := *e
+		plain.style = PathBracket
+		plain.walk(prefix, func(path string, err error) { fn(style.render(path), err) })
+		return
+	}
+	for key, errs := range e.errors {
+		path := joinPath(prefix, key)
+		for _, err := range errs {
+			var nested *validationError
+			var fe *fieldError
+			switch {
+			case errors.As(err, &nested) && nested != e:
+				nested.walk(path, fn)
+			case errors.As(err, &fe):
+				fn(joinPath(path, fe.path), fe.err)
+			default:
+				fn(path, err)
+			}
+		}
+	}
+}
+
+// MarshalJSON renders the error as {"errors": {path: message}, "codes":
+// {path: code}}.
+func (e *validationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{"errors": e.Errors(), "codes": e.Codes()})
+}
+
+// ErrorBody returns the JSON body of a 400 response for err, as written by
+// the router adapters: the ValidationError itself, which renders the message
+// and code of every invalid field, or {"error": "..."} for any other error.
+func ErrorBody(err error) any {
+	var verr ValidationError
+	if errors.As(err, &verr) {
+		return verr
+	}
+	return map[string]string{"error": err.Error()}
+}
+
+// codeOf returns the error code of a single field error.
+func codeOf(err error) string {
+	var re *ruleError
+	switch {
+	case errors.As(err, &re):
+		return validator.Code(re.rule.Name)
+	case errors.Is(err, validator.ErrRequired):
+		return validator.CodeRequired
+	case errors.Is(err, validator.ErrTypeMismatch):
+		return validator.CodeTypeMismatch
+	case errors.Is(err, validator.ErrIntegerOverflow):
+		return validator.CodeOverflow
+	default:
+		return "invalid"
+	}
+}
+
+// ruleError is the error of a failed validator, carrying the validator's
+// rule so the message can be localized, see Localize.
+type ruleError struct {
+	rule validator.Rule
+	err  error
+}
+
+func (e *ruleError) Error() string { return e.err.Error() }
+func (e *ruleError) Unwrap() error { return e.err }
+
+// ruled wraps fn so that its errors carry rule.
+func ruled[T validator.FieldType](rule validator.Rule, fn validator.Validator[T]) validator.Validator[T] {
+	return func(v T) error {
+		if err := fn(v); err != nil {
+			return &ruleError{rule: rule, err: err}
+		}
+		return nil
+	}
+}
+
+// fieldError is an error of a nested field reported under its parent key,
+// e.g. the error of "id" reported under "items[1]".
+type fieldError struct {
+	path string
+	err  error
+}
+
+func (e *fieldError) Error() string { return e.err.Error() }
+func (e *fieldError) Unwrap() error { return e.err }
+
+// message strips the "field 'name': " prefixes added while validating.
+func message(err error) string {
+	for {
+		inner := errors.Unwrap(err)
+		if inner == nil || !strings.HasPrefix(err.Error(), "field '") || !strings.HasSuffix(err.Error(), "': "+inner.Error()) {
+			return strings.TrimSpace(err.Error())
 		}
-		e.errors[fieldName] = err
+		err = inner
 	}
 }
 
@@ -81,9 +270,13 @@ type ViewField interface {
 	IsArray() bool
 	IsObject() bool
 	Required() bool
-	validate(node gjson.Result) mo.Result[any]
+	validate(node gjson.Result, st walkState) mo.Result[any]
 	validateRaw(v string) mo.Result[any]
+	validateRawArray(vs []string) mo.Result[any]
 	embeddedObject() mo.Option[*Schema]
+	jsonSchema(refs map[*Schema]string) map[string]any
+	// optional returns a copy of the field that is not required, see Partial.
+	optional(done map[*Schema]*Schema) ViewField
 }
 
 type JSONField[T validator.FieldType] struct {
@@ -94,6 +287,46 @@ type JSONField[T validator.FieldType] struct {
 	object        bool
 	embedded      *Schema
 	validators    []validator.Validator[T]
+	// rules describes the validators of the field, including the constraints
+	// of a persistent field, for schema exporters such as JSONSchema.
+	rules []validator.Rule
+	// header is the HTTP header a HeaderField reads its value from.
+	header string
+	// transforms normalize parsed values before validation, in order.
+	transforms []func(T) T
+	// lenient accepts JSON strings for non-string types, see Lenient.
+	lenient bool
+	// requirements are presence rules depending on other fields, see RequiredIf.
+	requirements []requirement
+	// nullable accepts an explicit JSON null, see Nullable.
+	nullable bool
+	// minItems and maxItems bound the length of array fields; a zero
+	// maxItems means no limit.
+	minItems int
+	maxItems int
+	// unique rejects duplicate array elements, see UniqueItems; uniqueBy
+	// lists the keys identifying an element of an array of objects.
+	unique   bool
+	uniqueBy []string
+	// access marks a ReadOnly or WriteOnly field.
+	access access
+	// parse converts JSON values to T once compiled, see Schema.Compile.
+	parse func(gjson.Result) mo.Result[T]
+	// sensitive masks the value in ValueObject.Sanitized, see Sensitive.
+	sensitive bool
+	// stringEncoded accepts and renders the number as a JSON string, see
+	// StringEncoded.
+	stringEncoded bool
+	// aliases are other names the field is read from, see Alias.
+	aliases []string
+	// deprecated is the message of a Deprecated field.
+	deprecated string
+	// ctxValidators run with a context after validation, see CheckCtx.
+	ctxValidators []validator.ValidatorCtx[T]
+	// layouts and location override how time.Time values are parsed, see
+	// Layouts and Location.
+	layouts  []string
+	location *time.Location
 }
 
 // JSONField implements ViewField and optionally wraps a persistent `xql.Field`.
@@ -112,6 +345,13 @@ func (f *JSONField[T]) IsObject() bool {
 	return f.object
 }
 
+// Rules describes the validators of the field in declaration order, the
+// constraints of a persistent field first, for exporters other than
+// JSONSchema and OpenAPI. See validator.Describe.
+func (f *JSONField[T]) Rules() []validator.Rule {
+	return slices.Clone(f.rules)
+}
+
 func (f *JSONField[T]) embeddedObject() mo.Option[*Schema] {
 	return lo.Ternary(f.embedded == nil, mo.None[*Schema](), mo.Some(f.embedded))
 }
@@ -159,6 +399,182 @@ func (f *JSONField[T]) Optional() *JSONField[T] {
 	return f
 }
 
+// Nullable accepts an explicit JSON null for the field. The null is kept
+// apart from an absent field: getters report the field as absent while
+// ValueObject.IsNull reports true, so PATCH handlers can tell "clear" from
+// "keep". A null satisfies Required; validators do not run on it.
+func (f *JSONField[T]) Nullable() *JSONField[T] {
+	f.nullable = true
+	return f
+}
+
+func (f *JSONField[T]) optional(done map[*Schema]*Schema) ViewField {
+	cp := *f
+	cp.required = false
+	cp.requirements = nil
+	if cp.embedded != nil {
+		cp.embedded = cp.embedded.partial(done)
+	}
+	return &cp
+}
+
+// MinItems requires an array field to hold at least n elements.
+func (f *JSONField[T]) MinItems(n int) *JSONField[T] {
+	lo.Assertf(f.array, "xql: MinItems is only supported by array fields, field '%s'", f.Name())
+	lo.Assertf(n >= 0, "xql: MinItems must not be negative for field '%s'", f.Name())
+	f.minItems = n
+	return f
+}
+
+// MaxItems limits an array field to at most n elements. The limit is checked
+// before any element is parsed, so oversized payloads fail fast.
+func (f *JSONField[T]) MaxItems(n int) *JSONField[T] {
+	lo.Assertf(f.array, "xql: MaxItems is only supported by array fields, field '%s'", f.Name())
+	lo.Assertf(n > 0, "xql: MaxItems must be positive for field '%s'", f.Name())
+	f.maxItems = n
+	return f
+}
+
+// NonEmpty requires an array field to hold at least one element, it is
+// MinItems(1).
+func (f *JSONField[T]) NonEmpty() *JSONField[T] {
+	return f.MinItems(1)
+}
+
+// UniqueItems rejects duplicate elements of an array field. Primitive
+// arrays compare the parsed values; arrays of objects compare the values
+// of the given keys, and elements missing any of the keys are not compared:
+//
+//	ArrayField[string]("tags").UniqueItems()
+//	ArrayOfObjectField("items", item).UniqueItems("sku")
+//
+// Each duplicate is reported at its own index, after the element checks pass.
+func (f *JSONField[T]) UniqueItems(keys ...string) *JSONField[T] {
+	lo.Assertf(f.array, "xql: UniqueItems is only supported by array fields, field '%s'", f.Name())
+	if f.embedded != nil {
+		lo.Assertf(len(keys) > 0, "xql: UniqueItems requires the keys identifying an object of field '%s'", f.Name())
+		for _, key := range keys {
+			lo.Assertf(lo.ContainsBy(f.embedded.fields, func(vf ViewField) bool { return vf.Name() == key }),
+				"xql: UniqueItems key '%s' is not a field of '%s'", key, f.Name())
+		}
+	} else {
+		lo.Assertf(len(keys) == 0, "xql: UniqueItems keys are only supported by arrays of objects, field '%s'", f.Name())
+	}
+	f.unique, f.uniqueBy = true, keys
+	return f
+}
+
+// checkUnique reports every element of values equal to an earlier one,
+// comparing the identities returned by id; elements without one are skipped.
+func checkUnique[V any](f interface{ Name() string }, values []V, id func(V) (any, bool), errs *validationError) {
+	seen := map[any]int{}
+	for i, v := range values {
+		key, ok := id(v)
+		if !ok {
+			continue
+		}
+		if first, dup := seen[key]; dup {
+			errs.add(fmt.Sprintf("%s[%d]", f.Name(), i), &ruleError{
+				rule: validator.Rule{Name: "unique_items", Params: map[string]any{}},
+				err:  fmt.Errorf("duplicates element %d", first),
+			})
+			continue
+		}
+		seen[key] = i
+	}
+}
+
+// checkUniqueValues applies UniqueItems to the parsed elements of a primitive array.
+func (f *JSONField[T]) checkUniqueValues(values []T, errs *validationError) {
+	if !f.unique {
+		return
+	}
+	checkUnique(f, values, func(v T) (any, bool) {
+		if t, ok := any(v).(time.Time); ok {
+			return t.UnixNano(), true
+		}
+		return v, true
+	}, errs)
+}
+
+// checkUniqueObjects applies UniqueItems to the validated elements of an
+// array of objects, identified by the UniqueItems keys.
+func (f *JSONField[T]) checkUniqueObjects(values []ValueObject, errs *validationError) {
+	if !f.unique {
+		return
+	}
+	checkUnique(f, values, func(vo ValueObject) (any, bool) {
+		parts := make([]any, 0, len(f.uniqueBy))
+		for _, key := range f.uniqueBy {
+			v, ok := vo.Get(key).Get()
+			if !ok {
+				return nil, false
+			}
+			parts = append(parts, v)
+		}
+		return fmt.Sprintf("%#v", parts), true
+	}, errs)
+}
+
+// checkItems validates the number of elements of an array field, counting
+// no further than needed.
+func (f *JSONField[T]) checkItems(count int) error {
+	switch {
+	case count < f.minItems:
+		return fmt.Errorf("field '%s': %w", f.Name(), &ruleError{
+			rule: validator.Rule{Name: "min_items", Params: map[string]any{"min": f.minItems}},
+			err:  fmt.Errorf("must have at least %d items", f.minItems),
+		})
+	case f.maxItems > 0 && count > f.maxItems:
+		return fmt.Errorf("field '%s': %w", f.Name(), &ruleError{
+			rule: validator.Rule{Name: "max_items", Params: map[string]any{"max": f.maxItems}},
+			err:  fmt.Errorf("must have at most %d items", f.maxItems),
+		})
+	}
+	return nil
+}
+
+// countItems counts the elements of the JSON array node, stopping once the
+// count exceeds maxItems.
+func (f *JSONField[T]) countItems(node gjson.Result) int {
+	count := 0
+	node.ForEach(func(_, _ gjson.Result) bool {
+		count++
+		return f.maxItems == 0 || count <= f.maxItems
+	})
+	return count
+}
+
+// Lenient accepts JSON strings holding a value of the field type, such as
+// "123" for an int field or "true" for a bool field, parsing them like URL
+// parameters. Other type mismatches still fail. See Schema.Coerce to enable
+// this for every field of a schema.
+func (f *JSONField[T]) Lenient() *JSONField[T] {
+	f.lenient = true
+	return f
+}
+
+// Transform registers a normalization applied to each parsed value before
+// the validators run; the transformed value is what ends up in the
+// ValueObject. Transforms run in the order they are registered:
+//
+//	Field[string]("email", validator.Email()).Transform(strings.TrimSpace, strings.ToLower)
+func (f *JSONField[T]) Transform(fns ...func(T) T) *JSONField[T] {
+	for _, fn := range fns {
+		lo.Assertf(fn != nil, "xql: nil transform for field '%s'", f.Name())
+	}
+	f.transforms = append(f.transforms, fns...)
+	return f
+}
+
+// normalize applies the registered transforms to v.
+func (f *JSONField[T]) normalize(v T) T {
+	for _, fn := range f.transforms {
+		v = fn(v)
+	}
+	return v
+}
+
 // AsObject marks the JSONField as an embedded object and returns the field
 // so callers can chain: PersistentField(...).AsObject()
 func (f *JSONField[T]) AsObject() *JSONField[T] {
@@ -176,32 +592,79 @@ func (f *JSONField[T]) AsArray() *JSONField[T] {
 func (f *JSONField[T]) validateRaw(v string) mo.Result[any] {
 	// typedString[T] returns mo.Result[T]
 	// validateRaw needs to return mo.Result[any]
-	typedValResult := typedString[T](v)
+	typedValResult := f.parseString(v)
 	if typedValResult.IsError() {
 		// Wrap the error to provide more context about the field.
 		err := fmt.Errorf("field '%s': %w", f.Name(), typedValResult.Error())
 		return mo.Err[any](err)
 	}
 
-	val := typedValResult.MustGet()
 	// Run validators on the successfully parsed value.
+	return f.check(f.normalize(typedValResult.MustGet()))
+}
+
+// check runs every validator on val. A single violation is returned as the
+// field error; several are returned as a validationError under the field
+// name, so all of them are reported.
+func (f *JSONField[T]) check(val T) mo.Result[any] {
+	errs := &validationError{}
 	for _, vfn := range f.validators {
 		if err := vfn(val); err != nil {
-			err = fmt.Errorf("field '%s': %w", f.Name(), err)
-			return mo.Err[any](err)
+			errs.add(f.Name(), fmt.Errorf("field '%s': %w", f.Name(), err))
 		}
 	}
+	switch len(errs.errors[f.Name()]) {
+	case 0:
+		return mo.Ok[any](val)
+	case 1:
+		return mo.Err[any](errs.first(f.Name()))
+	default:
+		return mo.Err[any](errs)
+	}
+}
 
-	return mo.Ok[any](val)
+// validateRawArray parses and validates each raw string as an element of a
+// primitive array field, collecting errors per element index.
+func (f *JSONField[T]) validateRawArray(vs []string) mo.Result[any] {
+	if err := f.checkItems(len(vs)); err != nil {
+		return mo.Err[any](err)
+	}
+	errs := &validationError{}
+	values := make([]T, 0, len(vs))
+	for i, v := range vs {
+		key := fmt.Sprintf("%s[%d]", f.Name(), i)
+		typedVal := f.parseString(v)
+		if typedVal.IsError() {
+			errs.add(key, typedVal.Error())
+			continue
+		}
+		val := f.normalize(typedVal.MustGet())
+		for _, vfn := range f.validators {
+			if err := vfn(val); err != nil {
+				errs.add(key, err)
+			}
+		}
+		values = append(values, val)
+	}
+	if errs.err() == nil {
+		f.checkUniqueValues(values, errs)
+	}
+	return lo.Ternary(errs.err() != nil, mo.Err[any](errs.err()), mo.Ok[any](values))
 }
 
 // Validate checks the given raw string for the field. It returns a Result monad
 // containing the typedJson value or an error
-func (f *JSONField[T]) validate(node gjson.Result) mo.Result[any] {
+func (f *JSONField[T]) validate(node gjson.Result, st walkState) mo.Result[any] {
+	// time fields already parse strings; a lenient retry would bypass Layouts
+	lenient := (st.coerce || f.lenient || f.stringEncoded) && !f.timed()
+	strict := st.strict && !lenient
+	if f.nullable && node.Type == gjson.Null {
+		return mo.Ok[any](internal.Null)
+	}
 	// Case: Nested Single Object
 	if f.IsObject() && !f.IsArray() {
 		// Recursively validate. The result will be a mo.Result[ValueObject].
-		nestedResult := f.embeddedObject().MustGet().Validate(node.Raw)
+		nestedResult := f.embedded.validateAt(node.Raw, nil, nil, st.nest())
 		if nestedResult.IsError() {
 			// Wrap the error to provide context.
 			return mo.Err[any](fmt.Errorf("field '%s' validation failed, %w", f.Name(), nestedResult.Error()))
@@ -215,6 +678,9 @@ func (f *JSONField[T]) validate(node gjson.Result) mo.Result[any] {
 		if !node.IsArray() {
 			return mo.Err[any](fmt.Errorf("xql: field '%s' expected a JSON array but got Clause", f.Name()))
 		}
+		if err := f.checkItems(f.countItems(node)); err != nil {
+			return mo.Err[any](err)
+		}
 		errs := &validationError{}
 		// Subcase: Array of Objects
 		if f.embeddedObject().IsPresent() {
@@ -222,9 +688,9 @@ func (f *JSONField[T]) validate(node gjson.Result) mo.Result[any] {
 			node.ForEach(func(index, element gjson.Result) bool {
 				if !element.IsObject() {
 					errs.add(fmt.Sprintf("%s[%d]", f.Name(), index.Int()), fmt.Errorf("expected a JSON object but got Clause"))
-					return true // continue
+					return !st.full(errs)
 				}
-				result := f.embedded.Validate(element.Raw)
+				result := f.embedded.validateAt(element.Raw, nil, nil, st.nest())
 				if result.IsError() {
 					// To avoid embedded error messages, if the embedded validation returns a
 					// validationError with a single underlying error, we extract it.
@@ -232,16 +698,21 @@ func (f *JSONField[T]) validate(node gjson.Result) mo.Result[any] {
 					errToAdd := result.Error()
 					var nested *validationError
 					if errors.As(errToAdd, &nested) && len(nested.errors) == 1 {
-						for _, v := range nested.errors {
-							errToAdd = v
+						for k, v := range nested.errors {
+							if len(v) == 1 {
+								errToAdd = &fieldError{path: k, err: v[0]}
+							}
 						}
 					}
 					errs.add(fmt.Sprintf("%s[%d]", f.Name(), index.Int()), errToAdd)
 				} else if errs.err() == nil {
 					values = append(values, result.MustGet())
 				}
-				return true // continue
+				return !st.full(errs)
 			})
+			if errs.err() == nil {
+				f.checkUniqueObjects(values, errs)
+			}
 			return lo.Ternary(errs.err() != nil, mo.Err[any](errs.err()), mo.Ok[any](values))
 		}
 
@@ -249,13 +720,13 @@ func (f *JSONField[T]) validate(node gjson.Result) mo.Result[any] {
 		var values []T
 		node.ForEach(func(index, element gjson.Result) bool {
 			// We need to validate each element of the array.
-			typedVal := typedJson[T](element)
+			typedVal := typedNode(element, f.parser(), lenient, strict)
 			if typedVal.IsError() {
 				errs.add(fmt.Sprintf("%s[%d]", f.Name(), index.Int()), typedVal.Error())
-				return true // continue to collect all errors
+				return !st.full(errs) // continue to collect the errors
 			}
 
-			val := typedVal.MustGet()
+			val := f.normalize(typedVal.MustGet())
 			// Run validators on each element
 			for _, v := range f.validators {
 				if err := v(val); err != nil {
@@ -267,24 +738,50 @@ func (f *JSONField[T]) validate(node gjson.Result) mo.Result[any] {
 			if errs.err() == nil {
 				values = append(values, val)
 			}
-			return true
+			return !st.full(errs)
 		})
+		if errs.err() == nil {
+			f.checkUniqueValues(values, errs)
+		}
 		return lo.Ternary(errs.err() != nil, mo.Err[any](errs.err()), mo.Ok[any](values))
 	}
 	// --- Fallback for simple, non-array, non-object fields ---
-	typedVal := typedJson[T](node)
+	typedVal := typedNode(node, f.parser(), lenient, strict)
 	if typedVal.IsError() {
 		err := fmt.Errorf("field '%s': %w", f.Name(), typedVal.Error())
 		return mo.Err[any](err)
 	}
-	val := typedVal.MustGet()
-	for _, v := range f.validators {
-		if err := v(val); err != nil {
-			err = fmt.Errorf("field '%s': %w", f.Name(), err)
-			return mo.Err[any](err)
+	return f.check(f.normalize(typedVal.MustGet()))
+}
+
+// typedNode converts node with parse, typedJson or its compiled
+// equivalent; when lenient, a JSON string that
+// typedJson rejects is parsed with typedString before giving up, and the
+// original mismatch is reported if that fails too. When strict, a JSON
+// string is rejected for every numeric type.
+func typedNode[T validator.FieldType](node gjson.Result, parse func(gjson.Result) mo.Result[T], lenient, strict bool) mo.Result[T] {
+	if strict && node.Type == gjson.String && isNumeric[T]() {
+		return mo.Err[T](fmt.Errorf("%w: expected a JSON number but got string %s", validator.ErrTypeMismatch, node.Raw))
+	}
+	res := parse(node)
+	if res.IsError() && lenient && node.Type == gjson.String {
+		if parsed := typedString[T](node.Str); parsed.IsOk() {
+			return parsed
 		}
 	}
-	return mo.Ok[any](val)
+	return res
+}
+
+// isNumeric reports whether T is an integer or float type.
+func isNumeric[T validator.FieldType]() bool {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
 }
 
 // typedJson attempts to convert a gjson.Result into the specified FieldType.
@@ -445,13 +942,17 @@ func trait[T validator.FieldType](name string, isArray, isObject bool, nested *S
 	}
 	names := make(map[string]struct{})
 	var nf []validator.Validator[T]
+	var rules []validator.Rule
 	for _, v := range vfs {
-		n, f := v()
+		var rule validator.Rule
+		n, f := v(&rule)
 		if _, exists := names[n]; exists {
 			panic(fmt.Sprintf("xql: duplicate validator '%s' for field '%s'", n, name))
 		}
 		names[n] = struct{}{}
-		nf = append(nf, f)
+		rule.Name = n
+		nf = append(nf, ruled(rule, f))
+		rules = append(rules, rule)
 	}
 	return &JSONField[T]{
 		qualifiedName: name, // view-only fields: qualifiedName is the view key
@@ -460,6 +961,7 @@ func trait[T validator.FieldType](name string, isArray, isObject bool, nested *S
 		object:        isObject,
 		embedded:      nested,
 		validators:    nf,
+		rules:         rules,
 		required:      true,
 	}
 }
@@ -483,26 +985,32 @@ func PersistentField[T validator.FieldType](f *xql.PersistentField[T], vfs ...va
 	// name set used to detect duplicate validator names across persistent and view validators
 	names := make(map[string]struct{})
 
-	// Include validators from the persistent field first
+	// Include validators from the persistent field first; they are described
+	// like view validators so exporters translate them too.
+	var rules []validator.Rule
 	for _, vf := range f.Constraints() {
-		name, fn := vf()
+		var rule xql.Rule
+		name, fn := vf(&rule)
 		if _, exists := names[name]; exists {
 			panic(fmt.Sprintf("xql: duplicate validator '%s' from persistent field in PersistentField", name))
 		}
 		names[name] = struct{}{}
-		fnLocal := fn
-		validators = append(validators, func(v T) error { return fnLocal(v) })
+		rule.Name = name
+		rules = append(rules, rule)
+		validators = append(validators, ruled(rule, validator.Validator[T](fn)))
 	}
 
 	// Convert view-provided validator factory functions into concrete validators.
 	for _, vf := range vfs {
-		name, fn := vf()
+		var rule validator.Rule
+		name, fn := vf(&rule)
+		rule.Name = name
+		rules = append(rules, rule)
 		if _, ok := names[name]; ok {
 			panic(fmt.Sprintf("xql: duplicate validator '%s' in PersistentField", name))
 		}
 		names[name] = struct{}{}
-		fnLocal := fn
-		validators = append(validators, func(v T) error { return fnLocal(v) })
+		validators = append(validators, ruled(validator.Rule{Name: name, Params: rule.Params}, fn))
 	}
 
 	return &JSONField[T]{
@@ -513,6 +1021,8 @@ func PersistentField[T validator.FieldType](f *xql.PersistentField[T], vfs ...va
 		object:        false,
 		embedded:      nil,
 		validators:    validators,
+		rules:         rules,
+		nullable:      f.IsNullable(),
 	}
 }
 
@@ -520,6 +1030,23 @@ func PersistentField[T validator.FieldType](f *xql.PersistentField[T], vfs ...va
 type Schema struct {
 	fields             []ViewField
 	allowUnknownFields bool
+	coerce             bool
+	checks             []schemaCheck
+	// maxDepth bounds the nesting of embedded objects, see MaxDepth.
+	maxDepth int
+	// self marks the placeholder returned by SelfRef.
+	self bool
+	// pathStyle renders the paths of validation errors, see ErrorPaths.
+	pathStyle PathStyle
+	// strict rejects numeric strings for float fields, see StrictNumbers.
+	strict bool
+	// captureUnknown keeps unknown fields in ValueObject.Unknown, see
+	// CaptureUnknown.
+	captureUnknown bool
+	// known caches the lookup table of the fields, see Compile.
+	known map[string]bool
+	// maxErrors stops validation after as many errors, see MaxErrors.
+	maxErrors int
 }
 
 // WithFields constructs a Schema from the provided ViewField values.
@@ -529,10 +1056,12 @@ func WithFields(fields ...ViewField) *Schema {
 	// Defensive duplicate name check similar to previous behavior.
 	names := make(map[string]struct{})
 	for _, f := range fields {
-		if _, exists := names[f.Name()]; exists {
-			panic(fmt.Sprintf("xql: duplicate field name '%s' in Schema definition", f.Name()))
+		for _, name := range inputNames(f) {
+			if _, exists := names[name]; exists {
+				panic(fmt.Sprintf("xql: duplicate field name '%s' in Schema definition", name))
+			}
+			names[name] = struct{}{}
 		}
-		names[f.Name()] = struct{}{}
 	}
 	// New: ensure QualifiedName uniqueness for fields that provide one.
 	qnames := make(map[string]struct{})
@@ -546,7 +1075,9 @@ func WithFields(fields ...ViewField) *Schema {
 		}
 		qnames[qn] = struct{}{}
 	}
-	return &Schema{fields: fields, allowUnknownFields: false}
+	schema := &Schema{fields: fields, allowUnknownFields: false}
+	schema.resolveSelf()
+	return schema
 }
 
 // AllowUnknownFields is a fluent helper to enable acceptance of unknown JSON/url
@@ -560,6 +1091,59 @@ func (s *Schema) AllowUnknownFields() *Schema {
 	return s
 }
 
+// Coerce enables lenient type coercion for every field of the schema,
+// including embedded objects: JSON strings such as "123" are accepted for
+// numeric, bool and time fields (see JSONField.Lenient). It returns the
+// same Schema pointer for chaining.
+func (s *Schema) Coerce() *Schema {
+	if s == nil {
+		return s
+	}
+	s.coerce = true
+	return s
+}
+
+// StrictNumbers requires numeric fields of every kind to be given as JSON
+// numbers, including embedded objects. By default float fields also accept
+// numeric strings such as "1.5" while integer fields do not; with
+// StrictNumbers "1.5" is a type mismatch for float fields too. Coerce and
+// JSONField.Lenient take precedence and accept numeric strings for every
+// numeric kind. It returns the same Schema pointer for chaining.
+func (s *Schema) StrictNumbers() *Schema {
+	if s == nil {
+		return s
+	}
+	s.strict = true
+	return s
+}
+
+// Partial returns a copy of s in which every field, including the fields of
+// embedded objects, is optional and conditional requirements are dropped,
+// while the validators still apply to the fields that are present. It lets
+// one definition serve both POST and PATCH endpoints:
+//
+//	create := view.WithFields(...)
+//	update := create.Partial()
+//
+// Checks registered with Check are kept and must cope with absent fields.
+func (s *Schema) Partial() *Schema {
+	return s.partial(map[*Schema]*Schema{})
+}
+
+// partial implements Partial; done maps the schemas already converted to
+// their copy, so recursive schemas stay recursive.
+func (s *Schema) partial(done map[*Schema]*Schema) *Schema {
+	if cp, ok := done[s]; ok {
+		return cp
+	}
+	cp := *s
+	done[s] = &cp
+	cp.fields = lo.Map(s.fields, func(field ViewField, _ int) ViewField {
+		return field.optional(done)
+	})
+	return &cp
+}
+
 func (s *Schema) Extend(another *Schema) *Schema {
 	// 1. Create a new field slice with enough capacity.
 	newFields := make([]ViewField, 0, len(s.fields)+len(another.fields))
@@ -578,13 +1162,71 @@ func (s *Schema) Extend(another *Schema) *Schema {
 	}
 
 	// 4. Return a new Schema with the combined fields.
-	// If either of the original objects allowed unknown fields, the new one should too.
+	return s.combine(another, newFields)
+}
+
+// combine returns a Schema of fields with the options of s and another.
+// If either of the original objects allowed unknown fields, the new one should too.
+func (s *Schema) combine(another *Schema, fields []ViewField) *Schema {
 	return &Schema{
-		fields:             newFields,
+		fields:             fields,
 		allowUnknownFields: s.allowUnknownFields || another.allowUnknownFields,
+		coerce:             s.coerce || another.coerce,
+		strict:             s.strict || another.strict,
+		checks:             append(append([]schemaCheck(nil), s.checks...), another.checks...),
+		maxDepth:           max(s.maxDepth, another.maxDepth),
+		maxErrors:          lo.Ternary(s.maxErrors > 0, s.maxErrors, another.maxErrors),
+		pathStyle:          lo.Ternary(s.pathStyle != PathBracket, s.pathStyle, another.pathStyle),
+		captureUnknown:     s.captureUnknown || another.captureUnknown,
 	}
 }
 
+// OnConflict selects how Merge resolves fields present in both schemas.
+type OnConflict int
+
+const (
+	// ConflictError fails the merge on the first duplicate field name.
+	ConflictError OnConflict = iota
+	// KeepLeft keeps the field of the receiver.
+	KeepLeft
+	// KeepRight keeps the field of the merged schema, at the position of the
+	// receiver's field.
+	KeepRight
+)
+
+// Merge is Extend for schemas composed at runtime: instead of panicking on
+// duplicate field names it resolves them with onConflict, returning an
+// error for ConflictError:
+//
+//	schema, err := base.Merge(plugin, view.KeepLeft)
+//
+// Fields of another follow those of s, options are combined as by Extend,
+// and neither schema is modified.
+func (s *Schema) Merge(another *Schema, onConflict OnConflict) (*Schema, error) {
+	if s == nil || another == nil {
+		return nil, errors.New("view: cannot merge a nil schema")
+	}
+	fields := append([]ViewField(nil), s.fields...)
+	index := make(map[string]int, len(fields))
+	for i, f := range fields {
+		index[f.Name()] = i
+	}
+	for _, f := range another.fields {
+		i, exists := index[f.Name()]
+		switch {
+		case !exists:
+			index[f.Name()] = len(fields)
+			fields = append(fields, f)
+		case onConflict == KeepRight:
+			fields[i] = f
+		case onConflict == KeepLeft:
+		default:
+			return nil, fmt.Errorf("view: duplicate field name '%s' found during Merge", f.Name())
+		}
+	}
+	return s.combine(another, fields), nil
+}
+
 // ValueObject is a sealed interface for a type-safe map holding validated Schema.
 // The seal method prevents implementations outside this package.
 //
@@ -632,8 +1274,26 @@ type ValueObject interface {
 	// MstBoolArray returns a slice of bools for the given name.
 	// It panics if the key is not found or the value is not a []bool.
 	MstBoolArray(name string) []bool
+	// IsNull reports whether the field was explicitly set to JSON null, which
+	// a Nullable field accepts. Getters report such a field as absent.
+	IsNull(name string) bool
+	// Unknown returns the unknown fields captured by Schema.CaptureUnknown,
+	// or nil when the schema does not capture them.
+	Unknown() map[string]any
+	// Sanitized returns a copy of the ValueObject with the values of
+	// Sensitive fields masked, safe to marshal or log.
+	Sanitized() ValueObject
+	// Merge returns a copy overlaid with the fields present in patch, an
+	// explicit null removing the field. MergeDeep merges embedded objects
+	// recursively, following JSON merge patch.
+	Merge(patch ValueObject) ValueObject
+	MergeDeep(patch ValueObject) ValueObject
+	// Warnings returns the non-fatal findings of the validation that
+	// produced the ValueObject, such as Deprecated fields, keyed by path.
+	Warnings() map[string]string
 	// FlatMap converts the ValueObject into a flattened map keyed by dotted
-	// qualified names (e.g. "table.column.view" or "table.column").
+	// qualified names (e.g. "table.column.view" or "table.column"). Null
+	// fields map to nil.
 	FlatMap() sqlx.FlatMap
 	seal()
 }
@@ -643,16 +1303,18 @@ type ValueObject interface {
 // We forward method calls to internal.Data converters when necessary.
 type valueObject struct {
 	internal.Data
+	// unknown holds the fields captured by Schema.CaptureUnknown.
+	unknown map[string]any
+	// sensitive lists the keys of the Sensitive fields, see Sanitized.
+	sensitive []string
+	// encoded lists the keys of the StringEncoded fields, see MarshalJSON.
+	encoded []string
+	// warnings are the validation warnings, see Warnings.
+	warnings map[string]string
 }
 
 var _ ValueObject = (*valueObject)(nil)
 
-// MarshalJSON ensures the valueObject is serialized as the underlying map
-// (i.e. the embedded Data) instead of as a struct with a "Data" field.
-func (vo valueObject) MarshalJSON() ([]byte, error) {
-	return json.Marshal(vo.Data)
-}
-
 func (vo valueObject) seal() {}
 
 // FlatMap converts the valueObject into a flattened map[string]any. It iterates over
@@ -674,8 +1336,7 @@ func (vo valueObject) FlatMap() sqlx.FlatMap {
 		case valueObject:
 			// expose underlying Data for nested valueObject
 			for _, fk := range val.Fields() {
-				if opt := val.Get(fk); opt.IsPresent() {
-					v := opt.MustGet()
+				if v := val.Data[fk]; v != nil {
 					nk := fk
 					if prefix != "" {
 						nk = prefix + "." + fk
@@ -694,7 +1355,7 @@ func (vo valueObject) FlatMap() sqlx.FlatMap {
 		default:
 			// arrays and primitives are stored as-is under the accumulated prefix
 			if prefix != "" {
-				out[prefix] = val
+				out[prefix] = lo.Ternary(val == internal.Null, nil, val)
 			}
 		}
 	}
@@ -702,6 +1363,8 @@ func (vo valueObject) FlatMap() sqlx.FlatMap {
 	for _, k := range vo.Fields() {
 		if opt := vo.Get(k); opt.IsPresent() {
 			walk(k, opt.MustGet())
+		} else if vo.IsNull(k) {
+			out[k] = nil
 		}
 	}
 	return out
@@ -769,17 +1432,102 @@ func setNestedField(object internal.Data, key string, val any) {
 	setObjectField(cur, final, val)
 }
 
+// Validate validates a JSON payload, optionally merged with URL parameters
+// (path and query values). Each URL parameter is parsed with the type of the
+// field of the same name; use ValidateValues when a parameter may repeat.
 func (s *Schema) Validate(json string, urlParams ...map[string]string) mo.Result[ValueObject] {
+	return s.validate(context.Background(), json, singleValues(urlParams), nil)
+}
+
+// singleValues converts single-valued URL parameters to url.Values.
+func singleValues(urlParams []map[string]string) []url.Values {
+	params := make([]url.Values, 0, len(urlParams))
+	for _, pair := range urlParams {
+		values := make(url.Values, len(pair))
+		for k, v := range pair {
+			values[k] = []string{v}
+		}
+		params = append(params, values)
+	}
+	return params
+}
+
+// ValidateValues is Validate with multi-valued URL parameters such as
+// http.Request.URL.Query(). Repeated values of a parameter feed an ArrayField
+// (`?tags=a&tags=b` validates as ["a", "b"]); repeating a parameter mapped
+// to a non-array field is an error.
+func (s *Schema) ValidateValues(json string, urlParams ...url.Values) mo.Result[ValueObject] {
+	return s.validate(context.Background(), json, urlParams, nil)
+}
+
+// defaultMaxDepth bounds the nesting of embedded objects unless MaxDepth
+// is set.
+const defaultMaxDepth = 32
+
+// walkState is the state of a validation run handed down to embedded
+// schemas.
+type walkState struct {
+	// coerce enables lenient type coercion, see Coerce.
+	coerce bool
+	// strict rejects numeric strings, see StrictNumbers.
+	strict bool
+	// depth is the nesting depth of the schema being validated.
+	depth int
+	// maxDepth is the nesting limit of the run, see MaxDepth.
+	maxDepth int
+	// maxErrors stops the run after as many errors, see MaxErrors.
+	maxErrors int
+}
+
+// nest returns the state of an embedded schema.
+func (st walkState) nest() walkState {
+	st.depth++
+	return st
+}
+
+// validate validates json merged with urlParams; header fields read their
+// value from headers. CheckCtx validators run with ctx.
+func (s *Schema) validate(ctx context.Context, json string, urlParams []url.Values, headers http.Header) mo.Result[ValueObject] {
+	return s.styled(s.checkCtx(ctx, s.validateAt(json, urlParams, headers, walkState{})))
+}
+
+// styled applies the path style of the schema to the error of res.
+func (s *Schema) styled(res mo.Result[ValueObject]) mo.Result[ValueObject] {
+	var verr *validationError
+	if errors.As(res.Error(), &verr) {
+		verr.style = s.pathStyle
+		if s.maxErrors > 0 {
+			if limited := verr.truncate(s.maxErrors); limited != verr {
+				return mo.Err[ValueObject](limited)
+			}
+		}
+	}
+	return s.styledWarnings(res)
+}
+
+// validateAt is validate for a schema reached with st, embedded schemas
+// inheriting the state of their parent.
+func (s *Schema) validateAt(json string, urlParams []url.Values, headers http.Header, st walkState) mo.Result[ValueObject] {
+	st.coerce = st.coerce || s.coerce
+	st.strict = st.strict || s.strict
+	if st.maxDepth == 0 {
+		st.maxDepth = lo.Ternary(s.maxDepth > 0, s.maxDepth, defaultMaxDepth)
+	}
+	if st.maxErrors == 0 {
+		st.maxErrors = s.maxErrors
+	}
+	if st.depth > st.maxDepth {
+		return mo.Err[ValueObject](fmt.Errorf("payload exceeds the maximum depth of %d nested objects", st.maxDepth))
+	}
 	if len(json) > 0 && !gjson.Valid(json) {
 		return mo.Err[ValueObject](fmt.Errorf("invalid json %s", json))
 	}
 	object := internal.Data{}
 	errs := &validationError{}
 	// Check for unknown fields first if not allowed.
-	voFields := lo.SliceToMap(s.fields, func(field ViewField) (string, bool) {
-		return field.Name(), field.IsArray() || field.IsObject()
-	})
-	urlPair := map[string]string{}
+	// Header fields never read from the payload or url parameters.
+	voFields := s.knownFields()
+	urlPair := map[string][]string{}
 	for _, pair := range urlParams {
 		for k, v := range pair {
 			// self conflict check
@@ -797,38 +1545,80 @@ func (s *Schema) Validate(json string, urlParams ...map[string]string) mo.Result
 		}
 	}
 
-	lo.ForEach(gjson.Get(json, "@keys").Array(), func(field gjson.Result, index int) {
-		jsonKey := field.String()
-		if _, ok := urlPair[jsonKey]; ok {
-			errs.add(jsonKey, fmt.Errorf("duplicate parameter in url and json '%s'", jsonKey))
-		}
-		if !s.allowUnknownFields {
-			if _, ok := voFields[jsonKey]; !ok {
-				errs.add(jsonKey, fmt.Errorf("unknown json field '%s'", jsonKey))
+	// The document is walked once: every top-level member is checked here and
+	// indexed by key, so fields below look their node up instead of searching
+	// the payload again.
+	nodes := map[string]gjson.Result{}
+	if root := gjson.Parse(json); root.IsObject() {
+		root.ForEach(func(key, value gjson.Result) bool {
+			jsonKey := key.String()
+			if _, ok := urlPair[jsonKey]; ok {
+				errs.add(jsonKey, fmt.Errorf("duplicate parameter in url and json '%s'", jsonKey))
 			}
-		}
-	})
+			if !s.allowUnknownFields {
+				if _, ok := voFields[jsonKey]; !ok {
+					errs.add(jsonKey, fmt.Errorf("unknown json field '%s'", jsonKey))
+				}
+			}
+			// like gjson.Get, the first of duplicated keys wins
+			if _, ok := nodes[jsonKey]; !ok {
+				nodes[jsonKey] = value
+			}
+			return true
+		})
+	}
 
 	// fail first for conflict
 	if errs.err() != nil {
 		return mo.Err[ValueObject](errs.err())
 	}
 
+	var warnings map[string]string
 	for _, field := range s.fields {
+		if st.full(errs) {
+			break
+		}
 		var rs mo.Result[any]
-		node := gjson.Get(json, field.Name())
-		if !node.Exists() {
+		if header := headerOf(field); header != "" {
+			values := headers.Values(header)
+			switch {
+			case len(values) == 0:
+				if field.Required() {
+					errs.add(field.Name(), fmt.Errorf("header '%s' %w", header, validator.ErrRequired))
+				}
+				continue
+			case field.IsArray():
+				rs = field.validateRawArray(values)
+			case len(values) > 1:
+				rs = mo.Err[any](fmt.Errorf("header '%s' has multiple values", header))
+			default:
+				rs = field.validateRaw(values[0])
+			}
+		} else if name, err := payloadName(field, nodes, urlPair); err != nil {
+			errs.add(field.Name(), err)
+			continue
+		} else if _, inURL := urlPair[name]; accessOf(field) == readOnly && (inURL || nodes[name].Exists()) {
+			errs.add(field.Name(), readOnlyError(field))
+			continue
+		} else if node := nodes[name]; !node.Exists() {
 			// need to check in urlPair
-			urlValue, ok := urlPair[field.Name()]
+			urlValue, ok := urlPair[name]
 			if !ok {
 				if field.Required() {
 					errs.add(field.Name(), fmt.Errorf("%s %w", field.Name(), validator.ErrRequired))
 				}
 				continue
 			}
-			rs = field.validateRaw(urlValue)
+			switch {
+			case field.IsArray():
+				rs = field.validateRawArray(urlValue)
+			case len(urlValue) > 1:
+				rs = mo.Err[any](fmt.Errorf("url parameter '%s' has multiple values", field.Name()))
+			default:
+				rs = field.validateRaw(urlValue[0])
+			}
 		} else {
-			rs = field.validate(node)
+			rs = field.validate(node, st)
 		}
 		if rs.IsError() {
 			// If the returned error is a validationError, it likely came from a
@@ -836,9 +1626,8 @@ func (s *Schema) Validate(json string, urlParams ...map[string]string) mo.Result
 			// instead of nesting the error object, which would create ugly, duplicated messages.
 			var nestedErr *validationError
 			if errors.As(rs.Error(), &nestedErr) {
-				for key, err := range nestedErr.errors {
-					errs.add(key, err)
-				}
+				// keys of an embedded object are relative to the field
+				errs.merge(lo.Ternary(field.IsObject() && !field.IsArray(), field.Name(), ""), nestedErr)
 			} else {
 				errs.add(field.Name(), rs.Error())
 			}
@@ -852,18 +1641,29 @@ func (s *Schema) Validate(json string, urlParams ...map[string]string) mo.Result
 		key := field.UniqueName()
 		// Store into nested map structure to support dot-path lookups via internal.Get
 		setNestedField(object, key, val)
+		warnings = addWarnings(warnings, field, val)
 	}
 
-	// Add unknown URL parameters to the final object if allowed.
-	if s.allowUnknownFields {
+	s.checkConditions(object, errs)
+	s.runChecks(object, errs)
+	// Add unknown URL parameters to the final object if allowed, or keep
+	// every unknown field apart when captured.
+	var unknown map[string]any
+	if s.captureUnknown {
+		unknown = unknownValues(nodes, urlPair, voFields)
+	} else if s.allowUnknownFields {
 		for k, v := range urlPair {
 			if _, exists := object[k]; !exists {
-				object[k] = v
+				object[k] = lo.Ternary[any](len(v) == 1, v[0], v)
 			}
 		}
 	}
 	return lo.Ternary(errs.err() != nil, mo.Err[ValueObject](errs.err()), mo.Ok[ValueObject](valueObject{
-		Data: object,
+		Data:      object,
+		unknown:   unknown,
+		sensitive: s.sensitivePaths(),
+		encoded:   s.encodedPaths(),
+		warnings:  warnings,
 	}))
 }
 
@@ -871,7 +1671,10 @@ func (s *Schema) Validate(json string, urlParams ...map[string]string) mo.Result
 //
 // Behavior and contract:
 //   - Each provided `xql.Field` must be a concrete `*xql.PersistentField[T]` produced
-//     by generator code (common generated fields live under `sample/gen/field`).
+//     by generator code (common generated fields live under `sample/gen/field`), or an
+//     `*xql.BlobField` generated for a []byte column, which becomes a `BlobField`
+//     without size limit, or an `*xql.DocumentField` generated for a json column,
+//     which becomes a `DocumentField` requiring the same JSON shape.
 //   - The function converts each persistent field into a view-layer `ViewField` by
 //     creating a `PersistentField[T]` wrapper. Any validator factories attached to the
 //     persistent field are carried into the resulting view field so view-layer
@@ -926,6 +1729,12 @@ func WithXQLFields(fields ...xql.Field) *Schema {
 			vf = append(vf, PersistentField[bool](concrete))
 		case *xql.PersistentField[time.Time]:
 			vf = append(vf, PersistentField[time.Time](concrete))
+		case *xql.BlobField:
+			vf = append(vf, PersistentBytesField(concrete, 0))
+		case *xql.DocumentField:
+			doc := PersistentRawJSONField(concrete, 0)
+			doc.shape = concrete.Shape()
+			vf = append(vf, doc)
 		default:
 			panic(fmt.Sprintf("view: WithXQLFields: unsupported xql.Field concrete type %T", f))
 		}
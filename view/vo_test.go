@@ -12,6 +12,7 @@ import (
 
 	"github.com/kcmvp/xql"
 	"github.com/kcmvp/xql/validator"
+	"github.com/samber/lo"
 	"github.com/samber/mo"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
@@ -772,8 +773,8 @@ func TestValidationError_Error(t *testing.T) {
 		{
 			name: "one error",
 			err: &validationError{
-				errors: map[string]error{
-					"field1": errors.New("error 1"),
+				errors: map[string][]error{
+					"field1": {errors.New("error 1")},
 				},
 			},
 			check: func(t *testing.T, got string) {
@@ -783,9 +784,9 @@ func TestValidationError_Error(t *testing.T) {
 		{
 			name: "multiple errors",
 			err: &validationError{
-				errors: map[string]error{
-					"field1": errors.New("error 1"),
-					"field2": errors.New("error 2"),
+				errors: map[string][]error{
+					"field1": {errors.New("error 1")},
+					"field2": {errors.New("error 2")},
 				},
 			},
 			check: func(t *testing.T, got string) {
@@ -810,35 +811,36 @@ func TestValidationError_add(t *testing.T) {
 		err := errors.New("some error")
 		e.add("field1", err)
 		require.NotNil(t, e.errors)
-		require.Equal(t, err, e.errors["field1"])
+		require.Equal(t, []error{err}, e.errors["field1"])
 	})
 
 	t.Run("add to existing error map", func(t *testing.T) {
 		e := &validationError{
-			errors: make(map[string]error),
+			errors: make(map[string][]error),
 		}
 		err1 := errors.New("error 1")
 		e.add("field1", err1)
-		require.Equal(t, err1, e.errors["field1"])
+		require.Equal(t, []error{err1}, e.errors["field1"])
 
 		err2 := errors.New("error 2")
 		e.add("field2", err2)
-		require.Equal(t, err2, e.errors["field2"])
+		require.Equal(t, []error{err2}, e.errors["field2"])
 		require.Len(t, e.errors, 2)
 	})
 
-	t.Run("overwrite existing error", func(t *testing.T) {
+	t.Run("append to existing error", func(t *testing.T) {
 		e := &validationError{
-			errors: make(map[string]error),
+			errors: make(map[string][]error),
 		}
 		err1 := errors.New("error 1")
 		e.add("field1", err1)
-		require.Equal(t, err1, e.errors["field1"])
+		require.Equal(t, []error{err1}, e.errors["field1"])
 
-		errOverwrite := errors.New("overwrite error")
-		e.add("field1", errOverwrite)
-		require.Equal(t, errOverwrite, e.errors["field1"])
+		err2 := errors.New("error 2")
+		e.add("field1", err2)
+		require.Equal(t, []error{err1, err2}, e.errors["field1"])
 		require.Len(t, e.errors, 1)
+		require.Equal(t, err1, e.first("field1"))
 	})
 
 	t.Run("add nil error", func(t *testing.T) {
@@ -847,7 +849,7 @@ func TestValidationError_add(t *testing.T) {
 		require.Nil(t, e.errors)
 		require.Len(t, e.errors, 0)
 
-		e.errors = make(map[string]error)
+		e.errors = make(map[string][]error)
 		e.add("field2", nil)
 		require.Len(t, e.errors, 0)
 	})
@@ -871,14 +873,14 @@ func TestValidationError_err(t *testing.T) {
 		},
 		{
 			name:    "validationError with empty errors map",
-			err:     &validationError{errors: make(map[string]error)},
+			err:     &validationError{errors: make(map[string][]error)},
 			wantErr: false,
 		},
 		{
 			name: "validationError with one error",
 			err: &validationError{
-				errors: map[string]error{
-					"field1": errors.New("error 1"),
+				errors: map[string][]error{
+					"field1": {errors.New("error 1")},
 				},
 			},
 			wantErr: true,
@@ -944,7 +946,7 @@ func TestSchemaField_validate(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			rs := tc.field.validate(gjson.Get(tc.json, tc.field.Name()))
+			rs := tc.field.validate(gjson.Get(tc.json, tc.field.Name()), walkState{})
 			if tc.wantErr != nil {
 				require.Error(t, rs.Error())
 				require.ErrorIs(t, rs.Error(), tc.wantErr)
@@ -1835,6 +1837,49 @@ func TestSchema_Extend(t *testing.T) {
 	})
 }
 
+func TestSchema_Merge(t *testing.T) {
+	left := WithFields(Field[string]("id"), Field[string]("name"))
+	right := WithFields(Field[int]("name"), Field[string]("email")).AllowUnknownFields()
+
+	tests := []struct {
+		name       string
+		onConflict OnConflict
+		wantErr    string
+		nameValue  string
+	}{
+		{name: "error", onConflict: ConflictError, wantErr: "view: duplicate field name 'name' found during Merge"},
+		{name: "keep left", onConflict: KeepLeft, nameValue: `"bob"`},
+		{name: "keep right", onConflict: KeepRight, nameValue: `7`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, err := left.Merge(right, tc.onConflict)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				require.Nil(t, merged)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{"id", "name", "email"}, lo.Map(merged.fields, func(f ViewField, _ int) string { return f.Name() }))
+			require.True(t, merged.allowUnknownFields)
+			require.NoError(t, merged.Validate(`{"id":"1","name":`+tc.nameValue+`,"email":"a@b.c"}`).Error())
+		})
+	}
+
+	t.Run("schemas are not modified", func(t *testing.T) {
+		_, err := left.Merge(right, KeepRight)
+		require.NoError(t, err)
+		require.Len(t, left.fields, 2)
+		require.Len(t, right.fields, 2)
+		require.False(t, left.allowUnknownFields)
+	})
+
+	t.Run("nil schema", func(t *testing.T) {
+		_, err := left.Merge(nil, KeepLeft)
+		require.Error(t, err)
+	})
+}
+
 // Tests for persistentField adapter (migrated from persistent_adapter_test.go)
 
 type dummyEntity struct{}
@@ -1863,3 +1908,35 @@ func TestWrapField_Validators(t *testing.T) {
 	r = vf.validateRaw("abcd")
 	require.False(t, r.IsError())
 }
+
+func TestField_Finite(t *testing.T) {
+	schema := WithFields(Field[float64]("value", validator.Finite[float64]()))
+	require.NoError(t, schema.Validate(`{"value": 1.7976931348623157e+308}`).Error())
+	res := schema.Validate(`{"value": 1.8e+308}`)
+	require.ErrorContains(t, res.Error(), validator.ErrNotFinite.Error())
+	require.Equal(t, map[string]string{"value": "number.finite"}, res.Error().(*validationError).Codes())
+}
+
+func TestField_Not(t *testing.T) {
+	schema := WithFields(Field[string]("role", validator.OneOf("admin", "root", "alice"), validator.Not(validator.OneOf("admin", "root"))))
+	require.NoError(t, schema.Validate(`{"role":"alice"}`).Error())
+	res := schema.Validate(`{"role":"root"}`)
+	require.Equal(t, map[string]string{"role": "not.value.one_of"}, res.Error().(*validationError).Codes())
+	require.Panics(t, func() {
+		Field[string]("role", validator.Not(validator.OneOf("admin")), validator.Not(validator.OneOf("root")))
+	})
+}
+
+func TestField_Custom(t *testing.T) {
+	even := validator.Custom("even", func(n int) error {
+		return lo.Ternary(n%2 != 0, errors.New("must be even"), nil)
+	})
+	schema := WithFields(Field[int]("count", validator.Gt(0), even))
+	require.NoError(t, schema.Validate(`{"count":4}`).Error())
+	res := schema.Validate(`{"count":3}`)
+	require.Equal(t, map[string]string{"count": "must be even"}, res.Error().(*validationError).Errors())
+	require.Equal(t, map[string]string{"count": "even"}, res.Error().(*validationError).Codes())
+	require.PanicsWithValue(t, "xql: duplicate validator 'even' for field 'count'", func() {
+		Field[int]("count", even, even)
+	})
+}